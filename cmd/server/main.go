@@ -6,10 +6,12 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joekhosbayar/go-mighty/internal/api"
+	"github.com/joekhosbayar/go-mighty/internal/bot"
 	"github.com/joekhosbayar/go-mighty/internal/infra"
 	"github.com/joekhosbayar/go-mighty/internal/ratelimit"
 	"github.com/joekhosbayar/go-mighty/internal/service"
@@ -30,6 +32,14 @@ const (
 	connsPerIP       = 20
 )
 
+// turnSweepInterval is how often the server looks for turns that have run
+// out of time, and so roughly how late a timeout move can be.
+const turnSweepInterval = time.Second
+
+// presenceSweepInterval is how often the server looks for players whose
+// sockets were lost with another instance or whose grace period is over.
+const presenceSweepInterval = 5 * time.Second
+
 func main() {
 	// 0. Logging Config
 	logLevel := os.Getenv("LOG_LEVEL")
@@ -85,7 +95,27 @@ func main() {
 	limiter := ratelimit.New(rlClient)
 
 	// 3. Service
-	svc := service.NewGame(redisStore, pgStore)
+	// Expert bots think in-process; BOT_THINK_TIME (e.g. "300ms") and
+	// BOT_ITERATIONS cap each move's search. Unset keeps bot.DefaultBudget.
+	var botBudget bot.Budget
+	if raw := os.Getenv("BOT_THINK_TIME"); raw != "" {
+		if botBudget.Time, err = time.ParseDuration(raw); err != nil {
+			log.Fatalf("Invalid BOT_THINK_TIME %q: %v", raw, err)
+		}
+	}
+
+	if raw := os.Getenv("BOT_ITERATIONS"); raw != "" {
+		if botBudget.Iterations, err = strconv.Atoi(raw); err != nil {
+			log.Fatalf("Invalid BOT_ITERATIONS %q: %v", raw, err)
+		}
+	}
+
+	svc := service.NewGame(redisStore, pgStore, service.WithBotBudget(botBudget))
+
+	// Idle players are timed out from a schedule in Redis, which every
+	// instance sweeps; each deadline is claimed by one of them.
+	go svc.RunTurnTimers(context.Background(), turnSweepInterval)
+	go svc.RunPresence(context.Background(), presenceSweepInterval)
 
 	// 4. API
 	cognitoPoolID := os.Getenv("COGNITO_POOL_ID")
@@ -167,9 +197,19 @@ func main() {
 		handler.RateLimitByUser("creategame", ratelimit.PerHour(10))(
 			http.HandlerFunc(handler.CreateGameHandler))))
 	mux.HandleFunc("POST /games/{id}/join", handler.JoinGameHandler)
+	mux.HandleFunc("POST /games/{id}/leave", handler.LeaveGameHandler)
+	mux.HandleFunc("POST /games/{id}/takeover", handler.TakeoverHandler)
+	mux.HandleFunc("POST /games/{id}/takeover/vote", handler.VoteTakeoverHandler)
+	mux.HandleFunc("PUT /games/{id}/spectating", handler.SetSpectatingHandler)
 	mux.HandleFunc("POST /games/{id}/move", handler.MoveHandler)
 	mux.HandleFunc("GET /games/{id}", handler.GetGameHandler)
+	mux.HandleFunc("GET /games/{id}/legal-moves", handler.LegalMovesHandler)
+	mux.HandleFunc("GET /games/{id}/hint", handler.HintHandler)
+	mux.HandleFunc("GET /games/{id}/record", handler.RecordHandler)
+	mux.HandleFunc("POST /games/{id}/bots", handler.AddBotHandler)
+	mux.HandleFunc("DELETE /games/{id}/bots/{playerID}", handler.RemoveBotHandler)
 	mux.HandleFunc("GET /games/{id}/ws", handler.WSHandler) // WebSocket
+	mux.HandleFunc("GET /games/{id}/spectate", handler.SpectateHandler)
 	mux.HandleFunc("GET /healthz", api.HealthzHandler)
 
 	// 6. Server
@@ -0,0 +1,135 @@
+// Command simulate plays complete games between bots in memory and reports
+// how the contracts and scores came out. Every game is dealt from a fixed
+// seed, so a run can be repeated exactly, and the engine's invariants are
+// checked after every move.
+//
+// Usage:
+//
+//	simulate -games 500 -seed 1 -players 4 -fail-dist two_one_split -bots hard,medium
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/bot"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+func main() {
+	var (
+		opts      options
+		bots      string
+		players   int
+		ruleSet   string
+		scoring   string
+		failDist  string
+		rounds    int
+		thinkTime time.Duration
+	)
+
+	flag.IntVar(&opts.games, "games", 100, "number of games to play")
+	flag.Uint64Var(&opts.seed, "seed", 1, "seed of the first game; game i is dealt from seed+i")
+	flag.StringVar(&bots, "bots", string(bot.Medium), "comma-separated bot levels, repeated round the table from seat 0")
+	flag.IntVar(&players, "players", 5, "seats at the table: 4, 5 or 6")
+	flag.StringVar(&ruleSet, "rule-set", game.RuleSetCampus, "special-card house rules: campus or official")
+	flag.StringVar(&scoring, "scoring", string(game.ScoringOfficial), "scoring: official, campus or trick_points")
+	flag.StringVar(&failDist, "fail-dist", string(game.FailEqualSplit), "four-player failure split: equal_split, declarer_alone or two_one_split")
+	flag.IntVar(&rounds, "rounds", 1, "scored rounds in each game")
+	flag.IntVar(&opts.budget.Iterations, "iterations", 20, "sampled deals per expert move")
+	flag.DurationVar(&thinkTime, "think-time", 50*time.Millisecond, "time limit per expert move")
+	flag.Parse()
+
+	opts.budget.Time = thinkTime
+
+	for _, name := range strings.Split(bots, ",") {
+		level, ok := bot.ParseLevel(strings.TrimSpace(name))
+		if !ok {
+			fail("unknown bot level %q", name)
+		}
+		opts.bots = append(opts.bots, level)
+	}
+
+	opts.cfg = game.DefaultConfig()
+	opts.cfg.NumPlayers = players
+	opts.cfg.Match.Rounds = rounds
+
+	switch players {
+	case 4, 5, 6:
+	default:
+		fail("players must be 4, 5 or 6")
+	}
+
+	rs, ok := game.RuleSetByName(ruleSet)
+	if !ok {
+		fail("unknown rule set %q", ruleSet)
+	}
+	opts.cfg.Rules = rs
+
+	switch s := game.Scoring(scoring); s {
+	case game.ScoringOfficial, game.ScoringCampus, game.ScoringTrickPoints:
+		opts.cfg.Scoring = s
+	default:
+		fail("unknown scoring %q", scoring)
+	}
+
+	switch fd := game.FailDist(failDist); fd {
+	case game.FailEqualSplit, game.FailDeclarerAlone, game.FailTwoOneSplit:
+		opts.cfg.FailDist = fd
+	default:
+		fail("unknown fail distribution %q", failDist)
+	}
+
+	if opts.games < 1 || rounds < 1 {
+		fail("games and rounds must be positive")
+	}
+
+	r := simulate(opts)
+	r.print(os.Stdout, opts)
+
+	if len(r.violations) > 0 {
+		os.Exit(1)
+	}
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "simulate: "+format+"\n", args...)
+	os.Exit(2)
+}
+
+// print writes the report for humans.
+func (r *report) print(w io.Writer, opts options) {
+	levels := make([]string, len(opts.bots))
+	for i, l := range opts.bots {
+		levels[i] = string(l)
+	}
+
+	fmt.Fprintf(w, "games           %d (seeds %d-%d), %d players, %s rules, %s scoring, bots %s\n",
+		r.games, opts.seed, opts.seed+uint64(opts.games)-1, opts.cfg.NumPlayers, opts.cfg.Rules.Name, opts.cfg.Scoring, strings.Join(levels, ","))
+	fmt.Fprintf(w, "rounds          %d\n", r.rounds)
+	fmt.Fprintf(w, "bid success     %s (%d/%d), %d conceded\n", percent(r.made, r.rounds), r.made, r.rounds, r.conceded)
+	fmt.Fprintf(w, "runs            %s\n", percent(r.runs, r.rounds))
+	fmt.Fprintf(w, "back-runs       %s\n", percent(r.backRuns, r.rounds))
+	fmt.Fprintf(w, "no-friend       %s\n", percent(r.noFriend, r.rounds))
+	fmt.Fprintln(w, "average score by seat")
+
+	for seat, total := range r.seatTotals {
+		fmt.Fprintf(w, "  seat %d %-8s %+.2f\n", seat, "("+string(opts.levelAt(seat))+")", float64(total)/float64(max(r.games, 1)))
+	}
+
+	fmt.Fprintf(w, "invariant violations: %d\n", len(r.violations))
+	for _, v := range r.violations {
+		fmt.Fprintf(w, "  %s\n", v)
+	}
+}
+
+func percent(n, of int) string {
+	if of == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(n)/float64(of))
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/joekhosbayar/go-mighty/internal/bot"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// movesPerRound bounds a round, so a rule bug that never ends a hand is
+// reported instead of hanging the run.
+const movesPerRound = 1000
+
+// options describes one simulation run.
+type options struct {
+	games  int
+	seed   uint64
+	bots   []bot.Level // repeated round the table
+	cfg    game.GameConfig
+	budget bot.Budget
+}
+
+// levelAt is the bot level playing seat.
+func (o options) levelAt(seat int) bot.Level {
+	return o.bots[seat%len(o.bots)]
+}
+
+// report totals a run.
+type report struct {
+	games      int
+	rounds     int
+	made       int // contracts made
+	conceded   int
+	runs       int // the declarer's side took all 20 scoring cards
+	backRuns   int // the defenders took 11 or more
+	noFriend   int // announced solos
+	seatTotals []int
+	violations []string
+}
+
+// simulate plays opts.games games and totals them.
+func simulate(opts options) *report {
+	r := &report{seatTotals: make([]int, opts.cfg.NumPlayers)}
+
+	for i := range opts.games {
+		seed := opts.seed + uint64(i)
+
+		g, err := playGame(opts, seed)
+		if err != nil {
+			r.violations = append(r.violations, fmt.Sprintf("seed %d: %v", seed, err))
+			continue
+		}
+
+		r.add(g)
+	}
+
+	return r
+}
+
+// add counts a completed game.
+func (r *report) add(g *game.Game) {
+	r.games++
+
+	for _, round := range g.ScoreHistory {
+		r.rounds++
+		if round.Success {
+			r.made++
+		}
+		if round.Solo == game.SoloAnnounced {
+			r.noFriend++
+		}
+		if round.Conceded {
+			r.conceded++
+			continue
+		}
+		if round.P == 20 {
+			r.runs++
+		}
+		if 20-round.P >= 11 {
+			r.backRuns++
+		}
+	}
+
+	for seat, p := range g.Players {
+		if p != nil && seat < len(r.seatTotals) {
+			r.seatTotals[seat] += g.TotalScores[p.ID]
+		}
+	}
+}
+
+// playGame plays one game dealt from seed to the end of its match, checking
+// the engine after every move.
+func playGame(opts options, seed uint64) (*game.Game, error) {
+	var deal game.Seed
+	binary.LittleEndian.PutUint64(deal[:], seed)
+
+	g := game.NewWithConfig(fmt.Sprintf("sim-%d", seed), opts.cfg, game.WithSeed(deal))
+	for seat := range opts.cfg.NumPlayers {
+		level := opts.levelAt(seat)
+		g.SeatBot(seat, fmt.Sprintf("bot-%d", seat), fmt.Sprintf("Bot %d (%s)", seat+1, level), string(level))
+	}
+
+	rng := rand.New(rand.NewPCG(seed, 0))
+	limit := movesPerRound * max(opts.cfg.Match.Rounds, 1)
+
+	for moves := 0; g.Status != game.PhaseMatchOver; moves++ {
+		if moves == limit {
+			return nil, fmt.Errorf("no result after %d moves, stuck in %s", limit, g.Status)
+		}
+
+		id, m, ok := bot.NextMove(g, rng, opts.budget)
+		if !ok {
+			return nil, fmt.Errorf("version %d: no bot can move in %s", g.Version, g.Status)
+		}
+
+		before := g.Version
+		if err := g.ApplyMove(id, m.Type, m.Payload); err != nil {
+			return nil, fmt.Errorf("version %d: %s's %s %v was listed as legal but rejected: %w", before, id, m.Type, m.Payload, err)
+		}
+
+		if err := g.CheckInvariants(before); err != nil {
+			return nil, fmt.Errorf("after %s's %s at version %d: %w", id, m.Type, before, err)
+		}
+	}
+
+	return g, nil
+}
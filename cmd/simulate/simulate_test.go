@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/joekhosbayar/go-mighty/internal/bot"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+func TestSimulateIsCleanAndRepeatable(t *testing.T) {
+	t.Parallel()
+
+	cfg := game.DefaultConfig()
+	cfg.Match.Rounds = 2
+	opts := options{games: 6, seed: 40, bots: bot.Levels, cfg: cfg, budget: bot.Budget{Iterations: 2}}
+
+	first := simulate(opts)
+	if len(first.violations) > 0 {
+		t.Fatalf("violations: %v", first.violations)
+	}
+
+	if first.games != 6 || first.rounds != 12 {
+		t.Fatalf("played %d games, %d rounds; want 6 and 12", first.games, first.rounds)
+	}
+
+	sum := 0
+	for _, total := range first.seatTotals {
+		sum += total
+	}
+	if sum != 0 {
+		t.Fatalf("seat totals must sum to zero: %v", first.seatTotals)
+	}
+
+	again := simulate(opts)
+	if again.made != first.made || again.backRuns != first.backRuns {
+		t.Fatal("the same seeds must play the same games")
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/joekhosbayar/go-mighty/internal/bot"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/ratelimit"
 	"github.com/joekhosbayar/go-mighty/internal/service"
@@ -27,6 +29,17 @@ type GameService interface {
 	Subscribe(ctx context.Context, gameID string) *redis.PubSub
 	GetGame(ctx context.Context, gameID string) (*game.Game, error)
 	ListGamesByStatus(ctx context.Context, status game.Phase) ([]*game.Game, error)
+	AddBot(ctx context.Context, gameID, requesterID string, level bot.Level) (*game.Game, error)
+	RemoveBot(ctx context.Context, gameID, requesterID, botID string) (*game.Game, error)
+	ExportGame(ctx context.Context, gameID string) (*game.Record, error)
+	LeaveGame(ctx context.Context, gameID, playerID string) (*game.Game, error)
+	Connect(ctx context.Context, gameID, playerID, socketID string) error
+	Disconnect(ctx context.Context, gameID, playerID, socketID string) error
+	RequestTakeover(ctx context.Context, gameID, playerID, playerName string, seat int) (*game.Game, error)
+	VoteTakeover(ctx context.Context, gameID, playerID string, approve bool) (*game.Game, error)
+	Spectate(ctx context.Context, gameID, viewerID, socketID string) (*game.Game, error)
+	StopSpectating(ctx context.Context, gameID, socketID string) error
+	SetSpectating(ctx context.Context, gameID, requesterID string, open bool) (*game.Game, error)
 }
 
 // TokenValidator authenticates bearer tokens into local user claims.
@@ -118,12 +131,29 @@ func (h *Handler) CreateGameHandler(w http.ResponseWriter, r *http.Request) {
 	cfg := game.DefaultConfig()
 	if r.Body != nil {
 		var req struct {
-			NumPlayers        int    `json:"num_players"`
-			AllowJokerPartner *bool  `json:"allow_joker_partner"`
-			FailDist          string `json:"fail_dist"`
+			NumPlayers        int                  `json:"num_players"`
+			AllowJokerPartner *bool                `json:"allow_joker_partner"`
+			FailDist          string               `json:"fail_dist"`
+			DealMiss          *game.DealMissConfig `json:"deal_miss"`
+			Concede           *game.ConcedeConfig  `json:"concede"`
+			Forfeit           *game.ForfeitConfig  `json:"forfeit"`
+			Timers            *game.TurnTimers     `json:"timers"`
+			Presence          *game.PresenceConfig `json:"presence"`
+			Takeover          string               `json:"takeover"`
+			BidOrder          string               `json:"bid_order"`
+			RuleSet           string               `json:"rule_set"`
+			Rules             json.RawMessage      `json:"rules"`
+			Scoring           string               `json:"scoring"`
+			Practice          bool                 `json:"practice"`
+			TargetScore       int                  `json:"target_score"`
+			Rounds            int                  `json:"rounds"`
+			Spectators        *struct {
+				Open  *bool `json:"open"`
+				Delay int   `json:"delay"`
+			} `json:"spectators"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err == nil {
-			if req.NumPlayers == 4 || req.NumPlayers == 5 {
+			if req.NumPlayers >= 4 && req.NumPlayers <= 6 {
 				cfg.NumPlayers = req.NumPlayers
 			}
 			if req.AllowJokerPartner != nil {
@@ -133,6 +163,65 @@ func (h *Handler) CreateGameHandler(w http.ResponseWriter, r *http.Request) {
 			case game.FailEqualSplit, game.FailDeclarerAlone, game.FailTwoOneSplit:
 				cfg.FailDist = game.FailDist(req.FailDist)
 			}
+			if req.DealMiss != nil {
+				cfg.DealMiss = req.DealMiss
+			}
+			if req.Concede != nil {
+				cfg.Concede = req.Concede
+			}
+			if req.Forfeit != nil && req.Forfeit.Penalty >= 0 {
+				cfg.Forfeit = req.Forfeit
+			}
+			if req.Timers != nil {
+				cfg.Timers = req.Timers
+			}
+			if p := req.Presence; p != nil && p.Grace >= 0 {
+				switch p.Policy {
+				case game.SubstitutePause, game.SubstituteAutoPlay, game.SubstituteForfeit:
+					cfg.Presence = p
+				}
+			}
+			switch game.TakeoverPolicy(req.Takeover) {
+			case game.TakeoverInherit, game.TakeoverReset, game.TakeoverSplit:
+				cfg.Takeover = game.TakeoverPolicy(req.Takeover)
+			}
+			if sp := req.Spectators; sp != nil && sp.Delay >= 0 {
+				cfg.Spectators = &game.SpectatorConfig{Open: sp.Open == nil || *sp.Open, Delay: sp.Delay}
+			}
+			switch game.BidOrder(req.BidOrder) {
+			case game.BidOrderPoints, game.BidOrderNoTrump, game.BidOrderSuitRank:
+				cfg.BidOrder = game.BidOrder(req.BidOrder)
+			}
+			if req.RuleSet != "" {
+				rs, ok := game.RuleSetByName(req.RuleSet)
+				if !ok {
+					http.Error(w, "unknown rule_set: "+req.RuleSet, http.StatusBadRequest)
+					return
+				}
+				cfg.Rules = rs
+			}
+			if len(req.Rules) > 0 {
+				// rules changes single fields of the chosen preset.
+				rs := cfg.Rules
+				if err := json.Unmarshal(req.Rules, &rs); err != nil {
+					http.Error(w, "invalid rules: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+				if rs.Name = cfg.Rules.Name; rs != cfg.Rules {
+					rs.Name = game.RuleSetCustom
+				}
+				if err := rs.Validate(); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				cfg.Rules = rs
+			}
+			switch game.Scoring(req.Scoring) {
+			case game.ScoringOfficial, game.ScoringCampus, game.ScoringTrickPoints:
+				cfg.Scoring = game.Scoring(req.Scoring)
+			}
+			cfg.Practice = req.Practice
+			cfg.Match = game.MatchConfig{TargetScore: max(req.TargetScore, 0), Rounds: max(req.Rounds, 0)}
 		}
 	}
 
@@ -153,7 +242,7 @@ func (h *Handler) CreateGameHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(updatedState)
+	_ = json.NewEncoder(w).Encode(updatedState.View(claims.UserID))
 }
 
 // JoinGameHandler - POST /games/{id}/join.
@@ -189,7 +278,144 @@ func (h *Handler) JoinGameHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(g)
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// LeaveGameHandler - POST /games/{id}/leave. The caller gives up their
+// seat, forfeiting the hand if one is in progress.
+func (h *Handler) LeaveGameHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	g, err := h.svc.LeaveGame(r.Context(), r.PathValue("id"), claims.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGameNotFound), errors.Is(err, service.ErrNotSeated):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrGameBusy):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, service.ErrGameCorrupted):
+			http.Error(w, service.ErrGameCorrupted.Error(), http.StatusInternalServerError)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// TakeoverHandler - POST /games/{id}/takeover. The caller asks to take
+// over an abandoned seat; the table votes on it through VoteTakeoverHandler.
+func (h *Handler) TakeoverHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	var req struct {
+		Seat *int `json:"seat"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Seat == nil {
+		http.Error(w, "expected a seat to take over", http.StatusBadRequest)
+		return
+	}
+
+	g, err := h.svc.RequestTakeover(r.Context(), r.PathValue("id"), claims.UserID, claims.Username, *req.Seat)
+	if err != nil {
+		writeTakeoverError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// VoteTakeoverHandler - POST /games/{id}/takeover/vote. A seated player
+// approves or rejects the pending takeover.
+func (h *Handler) VoteTakeoverHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	var req struct {
+		Approve *bool `json:"approve"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Approve == nil {
+		http.Error(w, "expected approve to be true or false", http.StatusBadRequest)
+		return
+	}
+
+	g, err := h.svc.VoteTakeover(r.Context(), r.PathValue("id"), claims.UserID, *req.Approve)
+	if err != nil {
+		writeTakeoverError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// writeTakeoverError maps a failed takeover request or vote to its status
+// code.
+func writeTakeoverError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrGameNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrGameBusy):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrGameCorrupted):
+		http.Error(w, service.ErrGameCorrupted.Error(), http.StatusInternalServerError)
+	case errors.Is(err, game.ErrInvalidMove):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// SetSpectatingHandler - PUT /games/{id}/spectating. The table's owner
+// opens or closes the game to spectators.
+func (h *Handler) SetSpectatingHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	var req struct {
+		Open *bool `json:"open"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Open == nil {
+		http.Error(w, "expected open to be true or false", http.StatusBadRequest)
+		return
+	}
+
+	g, err := h.svc.SetSpectating(r.Context(), r.PathValue("id"), claims.UserID, *req.Open)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrGameNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrNotOwner):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		case errors.Is(err, service.ErrGameBusy):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
 }
 
 // MoveHandler - POST /games/{id}/move.
@@ -230,12 +456,29 @@ func (h *Handler) MoveHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// The violations name hidden cards, so they stay in the server log.
+		if errors.Is(err, service.ErrGameCorrupted) {
+			http.Error(w, service.ErrGameCorrupted.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// A rejected claim carries the line of play that defeats it, so the
+		// client can show why rather than just that it failed.
+		var rejected *game.ClaimRejectedError
+		if errors.As(err, &rejected) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": err.Error(), "counter_line": rejected.Line})
+
+			return
+		}
+
 		http.Error(w, err.Error(), http.StatusBadRequest) // Assume generic 400 for logic error
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(g)
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
 }
 
 // ConvertPayload converts generic map/interface to concrete struct.
@@ -245,66 +488,91 @@ func ConvertPayload(moveType game.MoveType, payload any) (any, error) {
 		return nil, err
 	}
 
-	switch moveType {
-	case game.MoveBid:
-		var lastBid game.Bid
-		if err := json.Unmarshal(data, &lastBid); err != nil {
-			return nil, err
-		}
+	return game.DecodePayload(moveType, data)
+}
 
-		return lastBid, nil
-	case game.MoveDiscard:
-		var cards []game.Card
-		if err := json.Unmarshal(data, &cards); err != nil {
-			return nil, err
-		}
+// GetGameHandler - GET /games/{id}. Authentication is optional: a seated
+// caller sees their own hand, everyone else gets the public projection.
+func (h *Handler) GetGameHandler(w http.ResponseWriter, r *http.Request) {
+	viewerID, err := h.optionalViewer(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
 
-		return cards, nil
-	case game.MoveCallPartner:
-		var move game.CallPartnerMove
-		if err := json.Unmarshal(data, &move); err != nil {
-			return nil, err
+	gameID := r.PathValue("id")
+
+	g, err := h.svc.GetGame(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, service.ErrRedisStoreNotInitialized) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
 		}
 
-		if move.Card == nil && !move.NoFriend {
-			// Legacy shape: the payload is the card itself.
-			var card game.Card
-			if err := json.Unmarshal(data, &card); err == nil && card.Rank != "" {
-				return game.CallPartnerMove{Card: &card}, nil
-			}
+		http.Error(w, err.Error(), http.StatusNotFound)
 
-			return nil, errors.New("call_partner requires a card or no_friend")
-		}
+		return
+	}
 
-		return move, nil
-	case game.MovePlayCard:
-		// Attempt to unmarshal as PlayCardMove first
-		var playMove game.PlayCardMove
-		if err := json.Unmarshal(data, &playMove); err == nil && playMove.Card.Rank != "" {
-			return playMove, nil
-		}
-		// Fallback for raw Card payload
-		var card game.Card
-		if err := json.Unmarshal(data, &card); err == nil && card.Rank != "" {
-			return game.PlayCardMove{Card: card}, nil
-		}
+	if g == nil {
+		http.Error(w, service.ErrGameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(viewerID))
+}
 
-		return nil, errors.New("invalid play card payload: expected card or play_card_move object")
-	case game.MoveChangeConfig:
-		var cm game.ChangeConfigMove
-		if err := json.Unmarshal(data, &cm); err != nil {
-			return nil, err
+// LegalMovesHandler - GET /games/{id}/legal-moves. Lists every move the
+// authenticated player may make right now, with the game version to send as
+// client_version.
+func (h *Handler) LegalMovesHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	gameID := r.PathValue("id")
+
+	g, err := h.svc.GetGame(r.Context(), gameID)
+	if err != nil {
+		if errors.Is(err, service.ErrRedisStoreNotInitialized) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
 		}
-		return cm, nil
-	case game.MovePass, game.MovePlayAgain:
-		return nil, nil // No payload needed for pass or play_again
-	default:
-		return payload, nil
+
+		http.Error(w, err.Error(), http.StatusNotFound)
+
+		return
+	}
+
+	if g == nil {
+		http.Error(w, service.ErrGameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	if g.GetPlayer(claims.UserID) == nil {
+		http.Error(w, "player not in game", http.StatusForbidden)
+		return
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"version": g.Version,
+		"moves":   g.LegalMoves(claims.UserID),
+	})
 }
 
-// GetGameHandler - GET /games/{id}.
-func (h *Handler) GetGameHandler(w http.ResponseWriter, r *http.Request) {
+// HintHandler - GET /games/{id}/hint. Advises the authenticated player on a
+// bid for their own hand. Only practice games give hints.
+func (h *Handler) HintHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
 	gameID := r.PathValue("id")
 
 	g, err := h.svc.GetGame(r.Context(), gameID)
@@ -319,8 +587,126 @@ func (h *Handler) GetGameHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if g == nil {
+		http.Error(w, service.ErrGameNotFound.Error(), http.StatusNotFound)
+		return
+	}
+
+	if g.GetPlayer(claims.UserID) == nil {
+		http.Error(w, "player not in game", http.StatusForbidden)
+		return
+	}
+
+	if !g.Config.Practice {
+		http.Error(w, "hints are only available in practice games", http.StatusForbidden)
+		return
+	}
+
+	advice, err := g.AdviseBid(claims.UserID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(g)
+	_ = json.NewEncoder(w).Encode(advice)
+}
+
+// RecordHandler - GET /games/{id}/record. Exports a finished game in the text
+// notation of docs/notation.md.
+func (h *Handler) RecordHandler(w http.ResponseWriter, r *http.Request) {
+	gameID := r.PathValue("id")
+
+	rec, err := h.svc.ExportGame(r.Context(), gameID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRedisStoreNotInitialized):
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		case errors.Is(err, service.ErrGameNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, service.ErrGameNotFinished):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", gameID+".txt"))
+	_, _ = io.WriteString(w, rec.String())
+}
+
+// AddBotHandler - POST /games/{id}/bots. The table's owner seats a bot of
+// the requested level, medium when none is given.
+func (h *Handler) AddBotHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	var req struct {
+		Level string `json:"level"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	level := bot.Medium
+	if req.Level != "" {
+		var ok bool
+		if level, ok = bot.ParseLevel(req.Level); !ok {
+			http.Error(w, "unknown bot level: "+req.Level, http.StatusBadRequest)
+			return
+		}
+	}
+
+	g, err := h.svc.AddBot(r.Context(), r.PathValue("id"), claims.UserID, level)
+	if err != nil {
+		writeBotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// RemoveBotHandler - DELETE /games/{id}/bots/{playerID}. The table's owner
+// frees a bot's seat before the deal.
+func (h *Handler) RemoveBotHandler(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.authenticate(r)
+	if err != nil {
+		writeAuthError(w, err)
+		return
+	}
+
+	g, err := h.svc.RemoveBot(r.Context(), r.PathValue("id"), claims.UserID, r.PathValue("playerID"))
+	if err != nil {
+		writeBotError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(g.View(claims.UserID))
+}
+
+// writeBotError maps a failed bot seat change to its status code.
+func writeBotError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrGameNotFound), errors.Is(err, service.ErrNotABot):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrNotOwner):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrGameStarted), errors.Is(err, service.ErrGameFull), errors.Is(err, service.ErrGameBusy):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
 }
 
 // ListGamesHandler - GET /games.
@@ -333,7 +719,7 @@ func (h *Handler) ListGamesHandler(w http.ResponseWriter, r *http.Request) {
 
 	status := game.Phase(statusParam)
 	switch status {
-	case game.PhaseWaiting, game.PhaseBidding, game.PhaseExchanging, game.PhaseCalling, game.PhasePlaying, game.PhaseFinished:
+	case game.PhaseWaiting, game.PhaseBidding, game.PhaseEliminating, game.PhaseExchanging, game.PhaseCalling, game.PhasePlaying, game.PhaseFinished, game.PhaseMatchOver, game.PhaseCorrupted:
 	default:
 		http.Error(w, "invalid status", http.StatusBadRequest)
 		return
@@ -351,13 +737,15 @@ func (h *Handler) ListGamesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Make sure we return an empty array instead of null if no games are found
-	if games == nil {
-		games = []*game.Game{}
+	// Lobby listings are public, so every game goes out as the anonymous
+	// projection; a non-nil slice keeps the response [] rather than null.
+	views := make([]*game.Game, 0, len(games))
+	for _, g := range games {
+		views = append(views, g.View(""))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(games)
+	_ = json.NewEncoder(w).Encode(views)
 }
 
 // LoggingMiddleware logs the incoming HTTP requests and their responses.
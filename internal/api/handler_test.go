@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/joekhosbayar/go-mighty/internal/bot"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/service"
 	goredis "github.com/redis/go-redis/v9"
@@ -482,6 +484,39 @@ func (busyGameService) GetGame(_ context.Context, _ string) (*game.Game, error)
 func (busyGameService) ListGamesByStatus(_ context.Context, _ game.Phase) ([]*game.Game, error) {
 	return nil, nil
 }
+func (busyGameService) AddBot(_ context.Context, _, _ string, _ bot.Level) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) RemoveBot(_ context.Context, _, _, _ string) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) ExportGame(_ context.Context, _ string) (*game.Record, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) LeaveGame(_ context.Context, _, _ string) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) Connect(_ context.Context, _, _, _ string) error {
+	return service.ErrGameBusy
+}
+func (busyGameService) Disconnect(_ context.Context, _, _, _ string) error {
+	return service.ErrGameBusy
+}
+func (busyGameService) RequestTakeover(_ context.Context, _, _, _ string, _ int) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) VoteTakeover(_ context.Context, _, _ string, _ bool) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) Spectate(_ context.Context, _, _, _ string) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
+func (busyGameService) StopSpectating(_ context.Context, _, _ string) error {
+	return service.ErrGameBusy
+}
+func (busyGameService) SetSpectating(_ context.Context, _, _ string, _ bool) (*game.Game, error) {
+	return nil, service.ErrGameBusy
+}
 
 func TestMoveHandlerMapsGameBusyTo409(t *testing.T) {
 	t.Parallel()
@@ -501,6 +536,74 @@ func TestMoveHandlerMapsGameBusyTo409(t *testing.T) {
 	}
 }
 
+// claimRejectingService rejects every move as a failed claim.
+type claimRejectingService struct{ busyGameService }
+
+func (claimRejectingService) ProcessMove(_ context.Context, _, _ string, _ game.MoveType, _ any, _ int64) (*game.Game, error) {
+	return nil, &game.ClaimRejectedError{Tricks: 2, Line: []game.PlayedCard{
+		{PlayerID: "p1", Seat: 1, Card: game.Card{Suit: game.Spades, Rank: game.King}},
+	}}
+}
+
+func TestMoveHandlerReturnsClaimCounterLine(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(claimRejectingService{}, &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "alice"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/games/g1/move",
+		strings.NewReader(`{"move_type":"claim","client_version":1,"payload":null}`))
+	req.SetPathValue("id", "g1")
+	req.Header.Set("Authorization", "Bearer "+generateValidToken("user-1", "alice"))
+
+	rec := httptest.NewRecorder()
+	h.MoveHandler(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var body struct {
+		Error       string            `json:"error"`
+		CounterLine []game.PlayedCard `json:"counter_line"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if body.Error == "" || len(body.CounterLine) != 1 || body.CounterLine[0].PlayerID != "p1" {
+		t.Fatalf("unexpected body: %+v", body)
+	}
+}
+
+// corruptingService fails every move as a broken game.
+type corruptingService struct{ busyGameService }
+
+func (corruptingService) ProcessMove(_ context.Context, _, _ string, _ game.MoveType, _ any, _ int64) (*game.Game, error) {
+	return nil, fmt.Errorf("%w: %w", service.ErrGameCorrupted, &game.InvariantError{Problems: []string{"♠A is held 2 times"}})
+}
+
+func TestMoveHandlerHidesInvariantViolations(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(corruptingService{}, &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "alice"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/games/g1/move",
+		strings.NewReader(`{"move_type":"pass","client_version":1,"payload":null}`))
+	req.SetPathValue("id", "g1")
+	req.Header.Set("Authorization", "Bearer "+generateValidToken("user-1", "alice"))
+
+	rec := httptest.NewRecorder()
+	h.MoveHandler(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	if strings.Contains(rec.Body.String(), "♠A") {
+		t.Fatalf("violations must not reach the client: %s", rec.Body.String())
+	}
+}
+
 func TestJoinHandlerMapsGameBusyTo409(t *testing.T) {
 	t.Parallel()
 
@@ -518,6 +621,47 @@ func TestJoinHandlerMapsGameBusyTo409(t *testing.T) {
 	}
 }
 
+// ownerOnlyService refuses every bot change as coming from a non-owner.
+type ownerOnlyService struct{ busyGameService }
+
+func (ownerOnlyService) AddBot(_ context.Context, _, _ string, _ bot.Level) (*game.Game, error) {
+	return nil, service.ErrNotOwner
+}
+
+func TestAddBotHandlerErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		svc  GameService
+		body string
+		want int
+	}{
+		{name: "unknown level", svc: busyGameService{}, body: `{"level":"godlike"}`, want: http.StatusBadRequest},
+		{name: "not owner", svc: ownerOnlyService{}, body: `{"level":"easy"}`, want: http.StatusForbidden},
+		{name: "busy with default level", svc: busyGameService{}, want: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := NewHandler(tt.svc, &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "alice"}})
+
+			req := httptest.NewRequest(http.MethodPost, "/games/g1/bots", strings.NewReader(tt.body))
+			req.SetPathValue("id", "g1")
+			req.Header.Set("Authorization", "Bearer "+generateValidToken("user-1", "alice"))
+
+			rec := httptest.NewRecorder()
+			h.AddBotHandler(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestConvertPayloadCallPartnerShapes(t *testing.T) {
 	t.Parallel()
 
@@ -552,3 +696,245 @@ func TestConvertPayloadCallPartnerShapes(t *testing.T) {
 		t.Fatal("empty call_partner payload must be rejected")
 	}
 }
+
+// recordingService exports one finished game, g1.
+type recordingService struct{ busyGameService }
+
+func (recordingService) ExportGame(_ context.Context, gameID string) (*game.Record, error) {
+	switch gameID {
+	case "g1":
+		return &game.Record{Headers: []game.Header{{Name: "Game", Value: "g1"}}}, nil
+	case "live":
+		return nil, service.ErrGameNotFinished
+	}
+
+	return nil, service.ErrGameNotFound
+}
+
+func TestRecordHandler(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(recordingService{}, &fakeValidator{})
+
+	tests := []struct {
+		id   string
+		code int
+		body string
+	}{
+		{"g1", http.StatusOK, "[Game \"g1\"]\n"},
+		{"live", http.StatusConflict, "game is not finished\n"},
+		{"nope", http.StatusNotFound, "game not found\n"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/games/"+tt.id+"/record", nil)
+		req.SetPathValue("id", tt.id)
+
+		rec := httptest.NewRecorder()
+		h.RecordHandler(rec, req)
+
+		if rec.Code != tt.code || rec.Body.String() != tt.body {
+			t.Fatalf("%s: got %d %q, want %d %q", tt.id, rec.Code, rec.Body.String(), tt.code, tt.body)
+		}
+	}
+}
+
+// leavingService lets user-1 leave g1; anyone else is not seated there.
+type leavingService struct{ busyGameService }
+
+func (leavingService) LeaveGame(_ context.Context, gameID, playerID string) (*game.Game, error) {
+	if gameID != "g1" {
+		return nil, service.ErrGameNotFound
+	}
+	if playerID != "user-1" {
+		return nil, service.ErrNotSeated
+	}
+
+	return game.New(gameID), nil
+}
+
+func TestLeaveGameHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id   string
+		user string
+		code int
+	}{
+		{"g1", "user-1", http.StatusOK},
+		{"g1", "user-2", http.StatusNotFound},
+		{"nope", "user-1", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		h := NewHandler(leavingService{}, &fakeValidator{claims: &service.AuthClaims{UserID: tt.user, Username: tt.user}})
+
+		req := httptest.NewRequest(http.MethodPost, "/games/"+tt.id+"/leave", nil)
+		req.SetPathValue("id", tt.id)
+		req.Header.Set("Authorization", "Bearer "+generateValidToken(tt.user, tt.user))
+
+		rec := httptest.NewRecorder()
+		h.LeaveGameHandler(rec, req)
+
+		if rec.Code != tt.code {
+			t.Fatalf("%s leaving %s: got %d, want %d", tt.user, tt.id, rec.Code, tt.code)
+		}
+	}
+
+	h := NewHandler(busyGameService{}, &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "alice"}})
+
+	req := httptest.NewRequest(http.MethodPost, "/games/g1/leave", nil)
+	req.SetPathValue("id", "g1")
+	req.Header.Set("Authorization", "Bearer "+generateValidToken("user-1", "alice"))
+
+	rec := httptest.NewRecorder()
+	h.LeaveGameHandler(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("busy game: got %d, want %d", rec.Code, http.StatusConflict)
+	}
+}
+
+// takeoverService lets anyone take over seat 2 of g1; any other seat is not
+// abandoned.
+type takeoverService struct{ busyGameService }
+
+func (takeoverService) RequestTakeover(_ context.Context, gameID, _, _ string, seat int) (*game.Game, error) {
+	if gameID != "g1" {
+		return nil, service.ErrGameNotFound
+	}
+	if seat != 2 {
+		return nil, fmt.Errorf("%w: seat %d is not abandoned", game.ErrInvalidMove, seat)
+	}
+
+	return game.New(gameID), nil
+}
+
+func TestTakeoverHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id   string
+		body string
+		code int
+	}{
+		{"g1", `{"seat": 2}`, http.StatusOK},
+		{"g1", `{"seat": 0}`, http.StatusBadRequest},
+		{"g1", `{}`, http.StatusBadRequest},
+		{"nope", `{"seat": 2}`, http.StatusNotFound},
+	}
+
+	h := NewHandler(takeoverService{}, &fakeValidator{claims: &service.AuthClaims{UserID: "user-9", Username: "newcomer"}})
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/games/"+tt.id+"/takeover", strings.NewReader(tt.body))
+		req.SetPathValue("id", tt.id)
+		req.Header.Set("Authorization", "Bearer "+generateValidToken("user-9", "newcomer"))
+
+		rec := httptest.NewRecorder()
+		h.TakeoverHandler(rec, req)
+
+		if rec.Code != tt.code {
+			t.Fatalf("%s %s: got %d, want %d", tt.id, tt.body, rec.Code, tt.code)
+		}
+	}
+}
+
+// spectatingService lets only user-1, the owner of g1, open or close it to
+// spectators.
+type spectatingService struct{ busyGameService }
+
+func (spectatingService) SetSpectating(_ context.Context, gameID, requesterID string, open bool) (*game.Game, error) {
+	if gameID != "g1" {
+		return nil, service.ErrGameNotFound
+	}
+	if requesterID != "user-1" {
+		return nil, service.ErrNotOwner
+	}
+
+	g := game.New(gameID)
+	g.SetSpectating(open)
+
+	return g, nil
+}
+
+func TestSetSpectatingHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id   string
+		user string
+		body string
+		code int
+	}{
+		{"g1", "user-1", `{"open": false}`, http.StatusOK},
+		{"g1", "user-1", `{}`, http.StatusBadRequest},
+		{"g1", "user-2", `{"open": false}`, http.StatusForbidden},
+		{"nope", "user-1", `{"open": true}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		h := NewHandler(spectatingService{}, &fakeValidator{claims: &service.AuthClaims{UserID: tt.user, Username: tt.user}})
+
+		req := httptest.NewRequest(http.MethodPut, "/games/"+tt.id+"/spectating", strings.NewReader(tt.body))
+		req.SetPathValue("id", tt.id)
+		req.Header.Set("Authorization", "Bearer "+generateValidToken(tt.user, tt.user))
+
+		rec := httptest.NewRecorder()
+		h.SetSpectatingHandler(rec, req)
+
+		if rec.Code != tt.code {
+			t.Fatalf("%s %s %s: got %d, want %d", tt.id, tt.user, tt.body, rec.Code, tt.code)
+		}
+	}
+}
+
+// creatingService remembers the config of the last game it created.
+type creatingService struct {
+	busyGameService
+	cfg game.GameConfig
+}
+
+func (s *creatingService) CreateGame(_ context.Context, id string, cfg game.GameConfig) (*game.Game, error) {
+	s.cfg = cfg
+	return game.NewWithConfig(id, cfg), nil
+}
+
+func (s *creatingService) JoinGame(_ context.Context, id, _, _ string) (*game.Game, error) {
+	return game.NewWithConfig(id, s.cfg), nil
+}
+
+func TestCreateGameHandlerRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		body string
+		code int
+		name string
+	}{
+		{`{"rule_set": "campus"}`, http.StatusOK, "campus"},
+		{`{"rule_set": "casino"}`, http.StatusBadRequest, ""},
+		{`{"rules": {"alt_mighty": {"suit": "clubs", "rank": "A"}, "first_trick_joker": true}}`, http.StatusOK, game.RuleSetCustom},
+		{`{"rule_set": "campus", "rules": {"joker_call": "free"}}`, http.StatusOK, game.RuleSetCustom},
+		{`{"rules": {"mighty": {"suit": "clubs", "rank": "3"}}}`, http.StatusBadRequest, ""},
+		{`{"rules": {"joker_call": "sometimes"}}`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		svc := &creatingService{}
+		h := NewHandler(svc, &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "user-1"}})
+
+		req := httptest.NewRequest(http.MethodPost, "/games", strings.NewReader(tt.body))
+		req.Header.Set("Authorization", "Bearer "+generateValidToken("user-1", "user-1"))
+
+		rec := httptest.NewRecorder()
+		h.CreateGameHandler(rec, req)
+
+		if rec.Code != tt.code {
+			t.Fatalf("%s: got %d, want %d: %s", tt.body, rec.Code, tt.code, rec.Body.String())
+		}
+		if tt.code == http.StatusOK && svc.cfg.Rules.Name != tt.name {
+			t.Fatalf("%s: rule set %q, want %q", tt.body, svc.cfg.Rules.Name, tt.name)
+		}
+	}
+}
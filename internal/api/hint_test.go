@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/service"
+)
+
+func TestHintHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		userID   string
+		practice bool
+		wantCode int
+	}{
+		{name: "practice game", userID: "player-1", practice: true, wantCode: http.StatusOK},
+		{name: "ranked game", userID: "player-1", wantCode: http.StatusForbidden},
+		{name: "not seated", userID: "outsider", practice: true, wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := dealtGame(testGameID)
+			g.Config.Practice = tt.practice
+
+			redisStore := &fakeRedisStore{games: map[string]*game.Game{testGameID: g}}
+			handler, _, db := setupLobbyTestEnvWithRedis(t, redisStore)
+			defer func() { _ = db.Close() }()
+			handler.authSvc = &fakeValidator{claims: &service.AuthClaims{UserID: tt.userID, Username: "user"}}
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games/"+testGameID+"/hint", nil)
+			req.SetPathValue("id", testGameID)
+			req.Header.Set("Authorization", "Bearer "+generateValidToken(tt.userID, "user"))
+
+			rec := httptest.NewRecorder()
+			handler.HintHandler(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var advice game.BidAdvice
+			if err := json.Unmarshal(rec.Body.Bytes(), &advice); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			want := g.EvaluateHand(g.GetPlayer(tt.userID).Hand)
+			if advice.Explanation == "" || len(advice.Strains) != len(want) || advice.Strains[0] != want[0] {
+				t.Fatalf("advice is not for the caller's own hand: %+v", advice)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/service"
+)
+
+func TestLegalMovesHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		userID   string
+		token    string
+		wantCode int
+	}{
+		{name: "seated player", userID: "player-1", token: generateValidToken("player-1", "alice"), wantCode: http.StatusOK},
+		{name: "not seated", userID: "outsider", token: generateValidToken("outsider", "eve"), wantCode: http.StatusForbidden},
+		{name: "no token", userID: "player-1", wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			redisStore := &fakeRedisStore{games: map[string]*game.Game{testGameID: dealtGame(testGameID)}}
+			handler, _, db := setupLobbyTestEnvWithRedis(t, redisStore)
+			defer func() { _ = db.Close() }()
+			handler.authSvc = &fakeValidator{claims: &service.AuthClaims{UserID: tt.userID, Username: "user"}}
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games/"+testGameID+"/legal-moves", nil)
+			req.SetPathValue("id", testGameID)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.LegalMovesHandler(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Version int64 `json:"version"`
+				Moves   []struct {
+					Type game.MoveType `json:"move_type"`
+				} `json:"moves"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			// Seat 0 opens the bidding: 8 levels x 5 strains, plus pass.
+			counts := map[game.MoveType]int{}
+			for _, m := range body.Moves {
+				counts[m.Type]++
+			}
+
+			if counts[game.MoveBid] != 40 || counts[game.MovePass] != 1 || body.Version == 0 {
+				t.Fatalf("unexpected legal moves: %v (version %d)", counts, body.Version)
+			}
+		})
+	}
+}
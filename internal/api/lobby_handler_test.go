@@ -12,11 +12,13 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/service"
 	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -74,6 +76,34 @@ func (f *fakeRedisStore) PublishEvent(_ context.Context, _ string, _ any) error
 	return nil
 }
 func (f *fakeRedisStore) Subscribe(_ context.Context, _ string) *redis.PubSub { return nil }
+func (f *fakeRedisStore) ScheduleTurn(_ context.Context, _ string, _ time.Time) error {
+	return nil
+}
+func (f *fakeRedisStore) CancelTurn(_ context.Context, _ string) error { return nil }
+func (f *fakeRedisStore) ClaimDueTurns(_ context.Context, _ time.Time, _ time.Duration, _ int) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeRedisStore) TouchSocket(_ context.Context, _, _, _ string, _ time.Duration) (bool, error) {
+	return false, nil
+}
+func (f *fakeRedisStore) RemoveSocket(_ context.Context, _, _, _ string) (bool, error) {
+	return false, nil
+}
+func (f *fakeRedisStore) CountSockets(_ context.Context, _, _ string) (int, error) { return 0, nil }
+func (f *fakeRedisStore) WatchPresence(_ context.Context, _, _ string, _ time.Time) error {
+	return nil
+}
+func (f *fakeRedisStore) UnwatchPresence(_ context.Context, _, _ string) error { return nil }
+func (f *fakeRedisStore) ClaimDuePresence(_ context.Context, _ time.Time, _ time.Duration, _ int) ([]redisstore.PlayerKey, error) {
+	return nil, nil
+}
+func (f *fakeRedisStore) TouchSpectator(_ context.Context, _, _ string, _ time.Duration) (bool, error) {
+	return false, nil
+}
+func (f *fakeRedisStore) RemoveSpectator(_ context.Context, _, _ string) (bool, error) {
+	return false, nil
+}
+func (f *fakeRedisStore) CountSpectators(_ context.Context, _ string) (int, error) { return 0, nil }
 
 func setupLobbyTestEnv(t *testing.T) (*Handler, sqlmock.Sqlmock, *sql.DB) {
 	t.Helper()
@@ -152,6 +182,80 @@ func TestListGamesHandler_Success(t *testing.T) {
 	}
 }
 
+func TestListGamesHandler_SkipsGamesRedisHasLost(t *testing.T) {
+	t.Parallel()
+	redisStore := &fakeRedisStore{
+		games: map[string]*game.Game{
+			testGameID: {ID: testGameID, Status: game.PhasePlaying},
+		},
+	}
+
+	handler, mock, db := setupLobbyTestEnvWithRedis(t, redisStore)
+	defer func() { _ = db.Close() }()
+
+	// game-lost is only in Postgres; a listing must not replay it.
+	mock.ExpectQuery(`SELECT id FROM games WHERE status = \$1 ORDER BY created_at DESC LIMIT 50`).
+		WithArgs("playing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("game-lost").AddRow(testGameID))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games?status=playing", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ListGamesHandler(rec, req)
+
+	var resp []*game.Game
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp) != 1 || resp[0].ID != testGameID {
+		t.Fatalf("expected only %s, got %+v", testGameID, resp)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListGamesHandler_MatchOverFromFinalState(t *testing.T) {
+	t.Parallel()
+
+	// Redis has expired the match; its final state is all that is left.
+	handler, mock, db := setupLobbyTestEnv(t)
+	defer func() { _ = db.Close() }()
+
+	final := game.New("game-done")
+	final.Status = game.PhaseMatchOver
+	final.Standings = []game.Standing{{PlayerID: "p0", Rank: 1, Total: 12}}
+	stateJSON, _ := json.Marshal(final)
+
+	mock.ExpectQuery(`SELECT final_state FROM games WHERE status = \$1 AND final_state IS NOT NULL ORDER BY created_at DESC LIMIT 50`).
+		WithArgs("match_over").
+		WillReturnRows(sqlmock.NewRows([]string{"final_state"}).AddRow(stateJSON))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games?status=match_over", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ListGamesHandler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Body: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	var resp []*game.Game
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(resp) != 1 || resp[0].ID != "game-done" || len(resp[0].Standings) != 1 || resp[0].Standings[0].Total != 12 {
+		t.Fatalf("expected the final standings of game-done, got %+v", resp)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func TestJoinGameHandler_Unauthorized_NoToken(t *testing.T) {
 	t.Parallel()
 	handler, _, db := setupLobbyTestEnv(t)
@@ -238,9 +342,14 @@ func TestJoinGameHandler_Unauthorized_QueryToken(t *testing.T) {
 
 func TestJoinGameHandler_GameNotFound(t *testing.T) {
 	t.Parallel()
-	handler, _, db := setupLobbyTestEnv(t)
+	handler, mock, db := setupLobbyTestEnv(t)
 	defer func() { _ = db.Close() }()
 
+	// Redis misses, so the service looks for a ledger to replay.
+	mock.ExpectQuery(`SELECT config, deal_seed, created_at, status, version FROM games`).
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
 	token := generateValidToken("player-1", "alice")
 	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "/games/missing/join", nil)
 	req.Header.Set("Authorization", "Bearer "+token)
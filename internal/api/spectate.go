@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// heldEvent is an event a delayed spectator is sent at a later time.
+type heldEvent struct {
+	at   time.Time
+	data []byte
+}
+
+// projectSpectatorEvent rewrites a raw pub/sub event for a spectator. A
+// live spectator sees what someone with no seat at the table sees; a
+// delayed one, whose events are held back, sees every hand and the
+// declarer's discard. Like projectEvent it returns nil for an event it
+// cannot project.
+func projectSpectatorEvent(raw []byte, delayed bool) []byte {
+	if !delayed {
+		return projectEvent(raw, "")
+	}
+
+	var event map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil
+	}
+
+	state, ok := event["game_state"]
+	if !ok {
+		return raw
+	}
+
+	var g game.Game
+	if err := json.Unmarshal(state, &g); err != nil {
+		return nil
+	}
+
+	data, err := json.Marshal(g.SpectatorView())
+	if err != nil {
+		return nil
+	}
+
+	event["game_state"] = data
+
+	if data, err = json.Marshal(event); err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// closesSpectating reports whether a raw pub/sub event closes the game to
+// spectators, whose sockets are then closed too.
+func closesSpectating(raw []byte) bool {
+	var event struct {
+		Type string `json:"type"`
+		Open bool   `json:"open"`
+	}
+
+	return json.Unmarshal(raw, &event) == nil && event.Type == "spectating_changed" && !event.Open
+}
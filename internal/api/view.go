@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// optionalViewer resolves the caller for read endpoints that anonymous
+// clients may also use. No Authorization header means an anonymous view; a
+// header that fails validation is still an error, so a client with an expired
+// token learns about it instead of silently losing its own hand.
+func (h *Handler) optionalViewer(r *http.Request) (string, error) {
+	if _, ok := ClaimsFromContext(r.Context()); !ok && r.Header.Get("Authorization") == "" {
+		return "", nil
+	}
+
+	claims, err := h.authenticate(r)
+	if err != nil {
+		return "", err
+	}
+
+	return claims.UserID, nil
+}
+
+// projectEvent rewrites a raw pub/sub event for one WebSocket client. The
+// service publishes the full game_state once per move; every socket swaps it
+// for the viewer's own projection, and a discard payload (the declarer's
+// secret kitty choice) is blanked for everyone but the declarer. The
+// projection fails closed: an event that cannot be decoded or re-encoded is
+// dropped (nil is returned) rather than forwarded with the full state.
+func projectEvent(raw []byte, viewerID string) []byte {
+	var event map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil
+	}
+
+	changed := false
+
+	if state, ok := event["game_state"]; ok {
+		var g game.Game
+		if err := json.Unmarshal(state, &g); err != nil {
+			return nil
+		}
+
+		data, err := json.Marshal(g.View(viewerID))
+		if err != nil {
+			return nil
+		}
+
+		event["game_state"] = data
+		changed = true
+	}
+
+	var moveType game.MoveType
+	_ = json.Unmarshal(event["move_type"], &moveType)
+
+	var playerID string
+	_ = json.Unmarshal(event["player_id"], &playerID)
+
+	if moveType == game.MoveDiscard && playerID != viewerID {
+		event["payload"] = json.RawMessage("null")
+		changed = true
+	}
+
+	if !changed {
+		return raw
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+func dealtGame(id string) *game.Game {
+	g := game.New(id)
+	for i := range 5 {
+		g.Players[i] = &game.Player{ID: fmt.Sprintf("player-%d", i+1), Seat: i}
+	}
+
+	g.Start()
+
+	return g
+}
+
+func TestGetGameHandler_RedactsHands(t *testing.T) {
+	t.Parallel()
+
+	redisStore := &fakeRedisStore{games: map[string]*game.Game{testGameID: dealtGame(testGameID)}}
+	handler, _, db := setupLobbyTestEnvWithRedis(t, redisStore)
+	defer func() { _ = db.Close() }()
+
+	tests := []struct {
+		name     string
+		token    string
+		ownSeat  int
+		wantCode int
+	}{
+		{name: "anonymous", ownSeat: -1, wantCode: http.StatusOK},
+		{name: "seated player", token: generateValidToken("player-1", "alice"), ownSeat: 0, wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games/"+testGameID, nil)
+			req.SetPathValue("id", testGameID)
+
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.GetGameHandler(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+
+			var got game.Game
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+
+			for seat, p := range got.Players {
+				if p == nil {
+					continue
+				}
+
+				if seat == tt.ownSeat && len(p.Hand) != 10 {
+					t.Fatalf("own hand missing: %v", p.Hand)
+				}
+
+				if seat != tt.ownSeat && len(p.Hand) != 0 {
+					t.Fatalf("seat %d hand leaked: %v", seat, p.Hand)
+				}
+			}
+
+			if len(got.Kitty) != 0 {
+				t.Fatalf("kitty leaked: %v", got.Kitty)
+			}
+		})
+	}
+}
+
+func TestGetGameHandler_InvalidTokenIsUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	handler, _, db := setupLobbyTestEnv(t)
+	defer func() { _ = db.Close() }()
+	handler.authSvc = &fakeValidator{}
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/games/"+testGameID, nil)
+	req.SetPathValue("id", testGameID)
+	req.Header.Set("Authorization", "Bearer expired")
+
+	rec := httptest.NewRecorder()
+	handler.GetGameHandler(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestProjectEventPerViewer(t *testing.T) {
+	t.Parallel()
+
+	g := dealtGame(testGameID)
+	raw, err := json.Marshal(map[string]any{
+		"type":       "move",
+		"move_type":  game.MoveDiscard,
+		"player_id":  "player-1",
+		"payload":    g.Players[0].Hand[:3],
+		"game_state": g,
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var own, other struct {
+		Payload   []game.Card `json:"payload"`
+		GameState game.Game   `json:"game_state"`
+	}
+
+	if err := json.Unmarshal(projectEvent(raw, "player-1"), &own); err != nil {
+		t.Fatalf("decode own: %v", err)
+	}
+
+	if err := json.Unmarshal(projectEvent(raw, "player-2"), &other); err != nil {
+		t.Fatalf("decode other: %v", err)
+	}
+
+	if len(own.Payload) != 3 || len(own.GameState.Players[0].Hand) != 10 {
+		t.Fatalf("declarer lost their own discard or hand: %+v", own.Payload)
+	}
+
+	if other.Payload != nil || len(other.GameState.Players[0].Hand) != 0 {
+		t.Fatalf("discard or hand leaked to another seat: %+v", other.Payload)
+	}
+
+	if len(other.GameState.Players[1].Hand) != 10 {
+		t.Fatal("player-2 must still see their own hand")
+	}
+}
+
+func TestProjectEventFailsClosed(t *testing.T) {
+	t.Parallel()
+
+	for _, raw := range []string{
+		"not-json",
+		`{"type":"move","game_state":"not-a-game"}`,
+	} {
+		if got := projectEvent([]byte(raw), "player-1"); got != nil {
+			t.Fatalf("expected %q dropped, got %q", raw, got)
+		}
+
+		if got := projectSpectatorEvent([]byte(raw), true); got != nil {
+			t.Fatalf("expected %q dropped for a delayed spectator, got %q", raw, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/ratelimit"
@@ -95,8 +97,21 @@ type OutgoingWSError struct {
 	Error string `json:"error"`
 }
 
-// WSHandler handles websocket connections.
+// WSHandler handles a seated player's websocket connection. Anyone else
+// watches the game through SpectateHandler.
 func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
+	h.serveWS(w, r, false)
+}
+
+// SpectateHandler handles a spectator's websocket connection: the same
+// stream of events, as a spectator sees them, with no moves allowed.
+func (h *Handler) SpectateHandler(w http.ResponseWriter, r *http.Request) {
+	h.serveWS(w, r, true)
+}
+
+// serveWS runs a websocket connection to a game for a seated player or,
+// with spectator set, for a spectator.
+func (h *Handler) serveWS(w http.ResponseWriter, r *http.Request, spectator bool) {
 	gameID := r.PathValue("id")
 
 	up := h.upgrader()
@@ -171,6 +186,43 @@ func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
 		defer release()
 	}
 
+	// Presence spans every socket the player has open, on any instance; this
+	// one keeps itself counted with each ping, and stops counting on close.
+	// Spectator sockets are counted the same way, apart from the players.
+	socketID := uuid.NewString()
+
+	var delay time.Duration
+	if spectator {
+		g, err := h.svc.Spectate(r.Context(), gameID, claims.UserID, socketID)
+		if err != nil {
+			sendError(err.Error())
+			return
+		}
+
+		delay = g.SpectatorDelay()
+
+		defer func() {
+			if err := h.svc.StopSpectating(context.WithoutCancel(r.Context()), gameID, socketID); err != nil {
+				log.Warn().Str("game_id", gameID).Str("user_id", claims.UserID).Err(err).Msg("Failed to record spectator leaving")
+			}
+		}()
+	} else {
+		if err := h.seated(r.Context(), gameID, claims.UserID); err != nil {
+			sendError(err.Error())
+			return
+		}
+
+		if err := h.svc.Connect(r.Context(), gameID, claims.UserID, socketID); err != nil {
+			log.Warn().Str("game_id", gameID).Str("user_id", claims.UserID).Err(err).Msg("Failed to record websocket presence")
+		}
+
+		defer func() {
+			if err := h.svc.Disconnect(context.WithoutCancel(r.Context()), gameID, claims.UserID, socketID); err != nil {
+				log.Warn().Str("game_id", gameID).Str("user_id", claims.UserID).Err(err).Msg("Failed to record websocket disconnect")
+			}
+		}()
+	}
+
 	// 2. Swap the auth deadline for a rolling idle deadline. A pong or any
 	// inbound message refreshes it; a silent socket is reaped after
 	// wsIdleTimeout instead of pinning a goroutine forever.
@@ -191,11 +243,31 @@ func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
 	// Create a channel to signal connection closure
 	done := make(chan struct{})
 
+	send := func(data []byte) error {
+		wsWriteMu.Lock()
+		defer wsWriteMu.Unlock()
+
+		return conn.WriteMessage(websocket.TextMessage, data)
+	}
+
+	// hangUp ends a spectator's socket from the write loop; the read loop
+	// then fails and cleans up as for any other close.
+	hangUp := func(reason string) {
+		closeWithCode(conn, websocket.CloseNormalClosure, reason, &wsWriteMu)
+		_ = conn.Close()
+	}
+
 	// Write loop
 	go func() {
 		ticker := time.NewTicker(30 * time.Second)
 		defer ticker.Stop()
 
+		// A delayed spectator's events are held back until their time.
+		var (
+			held []heldEvent
+			due  <-chan time.Time
+		)
+
 		for {
 			select {
 			case <-done:
@@ -208,18 +280,73 @@ func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
 				if err != nil {
 					return
 				}
+
+				if spectator {
+					_, err := h.svc.Spectate(r.Context(), gameID, claims.UserID, socketID)
+					if errors.Is(err, service.ErrSpectatingClosed) || errors.Is(err, service.ErrSeated) {
+						hangUp(err.Error())
+						return
+					}
+
+					if err != nil {
+						log.Warn().Str("game_id", gameID).Str("user_id", claims.UserID).Err(err).Msg("Failed to refresh spectator")
+					}
+				} else if err := h.svc.Connect(r.Context(), gameID, claims.UserID, socketID); err != nil {
+					log.Warn().Str("game_id", gameID).Str("user_id", claims.UserID).Err(err).Msg("Failed to refresh websocket presence")
+				}
 			case msg, ok := <-ch:
 				if !ok {
 					return // pubsub closed
 				}
-				// msg.Payload is the JSON string from Redis
-				wsWriteMu.Lock()
-				err := conn.WriteMessage(websocket.TextMessage, []byte(msg.Payload))
-				wsWriteMu.Unlock()
+				// msg.Payload is the JSON string from Redis, carrying the
+				// full state; each socket only ever sees its own projection,
+				// and an event that cannot be projected is dropped.
+				if !spectator {
+					out := projectEvent([]byte(msg.Payload), claims.UserID)
+					if out == nil {
+						log.Warn().Str("game_id", gameID).Msg("Dropped an event that could not be projected")
+						continue
+					}
+
+					if err := send(out); err != nil {
+						return
+					}
+
+					continue
+				}
 
-				if err != nil {
+				if closesSpectating([]byte(msg.Payload)) {
+					hangUp(service.ErrSpectatingClosed.Error())
+					return
+				}
+
+				out := projectSpectatorEvent([]byte(msg.Payload), delay > 0)
+				if out == nil {
+					log.Warn().Str("game_id", gameID).Msg("Dropped an event that could not be projected")
+					continue
+				}
+
+				if delay == 0 {
+					if err := send(out); err != nil {
+						return
+					}
+
+					continue
+				}
+
+				held = append(held, heldEvent{at: time.Now().Add(delay), data: out})
+				if len(held) == 1 {
+					due = time.After(delay)
+				}
+			case <-due:
+				if err := send(held[0].data); err != nil {
 					return
 				}
+
+				held, due = held[1:], nil
+				if len(held) > 0 {
+					due = time.After(time.Until(held[0].at))
+				}
 			}
 		}
 	}()
@@ -258,6 +385,11 @@ func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		if inMsg.Type == WSMessageTypeMove && spectator {
+			sendError("spectators cannot make moves")
+			continue
+		}
+
 		if inMsg.Type == WSMessageTypeMove {
 			convertedPayload, err := ConvertPayload(inMsg.MoveType, inMsg.Payload)
 			if err != nil {
@@ -278,6 +410,25 @@ func (h *Handler) WSHandler(w http.ResponseWriter, r *http.Request) {
 	close(done)
 }
 
+// seated checks that playerID has a seat at the game, as only seated
+// players connect through WSHandler.
+func (h *Handler) seated(ctx context.Context, gameID, playerID string) error {
+	g, err := h.svc.GetGame(ctx, gameID)
+	if err != nil {
+		return err
+	}
+
+	if g == nil {
+		return service.ErrGameNotFound
+	}
+
+	if g.GetPlayer(playerID) == nil {
+		return service.ErrNotSeated
+	}
+
+	return nil
+}
+
 func (h *Handler) sendWSError(conn *websocket.Conn, errMsg string, writeMu *sync.Mutex) error {
 	errPayload := OutgoingWSError{
 		Type:  WSMessageTypeError,
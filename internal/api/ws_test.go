@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -13,10 +14,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/alicebob/miniredis/v2"
 	"github.com/gorilla/websocket"
+	"github.com/joekhosbayar/go-mighty/internal/bot"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/service"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -31,6 +36,10 @@ type fakeWSGameService struct {
 	processMoveCalled bool
 	processMoveCh     chan struct{}
 	processMoveErr    error
+	connected         []string    // the socket IDs Connect was called with
+	disconnectCh      chan string // receives each socket ID Disconnect is called with
+	seated            []string    // who GetGame seats
+	spectated         *game.Game  // what Spectate returns; nil when spectating is closed
 }
 
 func (f *fakeWSGameService) CreateGame(_ context.Context, _ string, _ game.GameConfig) (*game.Game, error) {
@@ -79,14 +88,83 @@ func (f *fakeWSGameService) Subscribe(ctx context.Context, gameID string) *redis
 	return f.redisClient.Subscribe(ctx, "game:"+gameID+":events")
 }
 
-func (_ *fakeWSGameService) GetGame(_ context.Context, _ string) (*game.Game, error) {
-	return nil, nil
+func (f *fakeWSGameService) GetGame(_ context.Context, gameID string) (*game.Game, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	g := game.New(gameID)
+	for i, id := range f.seated {
+		g.SeatPlayer(i, id, id)
+	}
+
+	return g, nil
 }
 
 func (_ *fakeWSGameService) ListGamesByStatus(_ context.Context, _ game.Phase) ([]*game.Game, error) {
 	return nil, nil
 }
 
+func (_ *fakeWSGameService) AddBot(_ context.Context, _, _ string, _ bot.Level) (*game.Game, error) {
+	return nil, nil
+}
+
+func (_ *fakeWSGameService) RemoveBot(_ context.Context, _, _, _ string) (*game.Game, error) {
+	return nil, nil
+}
+
+func (_ *fakeWSGameService) ExportGame(_ context.Context, _ string) (*game.Record, error) {
+	return nil, nil
+}
+
+func (_ *fakeWSGameService) LeaveGame(_ context.Context, _, _ string) (*game.Game, error) {
+	return nil, nil
+}
+
+func (f *fakeWSGameService) Connect(_ context.Context, _, _, socketID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.connected = append(f.connected, socketID)
+
+	return nil
+}
+
+func (_ *fakeWSGameService) RequestTakeover(_ context.Context, _, _, _ string, _ int) (*game.Game, error) {
+	return nil, nil
+}
+
+func (_ *fakeWSGameService) VoteTakeover(_ context.Context, _, _ string, _ bool) (*game.Game, error) {
+	return nil, nil
+}
+
+func (f *fakeWSGameService) Spectate(_ context.Context, _, _, _ string) (*game.Game, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.spectated == nil {
+		return nil, service.ErrSpectatingClosed
+	}
+
+	return f.spectated, nil
+}
+
+func (_ *fakeWSGameService) StopSpectating(_ context.Context, _, _ string) error {
+	return nil
+}
+
+func (_ *fakeWSGameService) SetSpectating(_ context.Context, _, _ string, _ bool) (*game.Game, error) {
+	return nil, nil
+}
+
+func (f *fakeWSGameService) Disconnect(_ context.Context, _, _, socketID string) error {
+	select {
+	case f.disconnectCh <- socketID:
+	default:
+	}
+
+	return nil
+}
+
 func (f *fakeWSGameService) WasProcessMoveCalled() bool {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -112,6 +190,8 @@ func setupWSTestHandler(t *testing.T) (*Handler, func()) {
 	svc := &fakeWSGameService{
 		redisClient:   client,
 		processMoveCh: make(chan struct{}, 1),
+		disconnectCh:  make(chan string, 1),
+		seated:        []string{"user-1"},
 	}
 	authSvc := &fakeValidator{claims: &service.AuthClaims{UserID: "user-1", Username: "alice"}}
 	handler := NewHandler(svc, authSvc)
@@ -137,6 +217,7 @@ func setupWSTestServer(t *testing.T) (*httptest.Server, *fakeWSGameService) {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/games/{id}/ws", handler.WSHandler)
+	mux.HandleFunc("/games/{id}/spectate", handler.SpectateHandler)
 
 	server := httptest.NewServer(mux)
 	t.Cleanup(server.Close)
@@ -327,6 +408,136 @@ func TestWSHandler_ValidMoveCallsProcessMoveAndForwardsEvent(t *testing.T) {
 	}
 }
 
+func TestWSHandler_ClosingTheSocketDisconnectsIt(t *testing.T) {
+	t.Parallel()
+	server, svc := setupWSTestServer(t)
+	conn := dialWS(t, server, "/games/game-1/ws", generateValidToken("user-2", "bob"))
+
+	_ = conn.Conn.Close()
+
+	select {
+	case socketID := <-svc.disconnectCh:
+		svc.mu.Lock()
+		connected := svc.connected
+		svc.mu.Unlock()
+
+		if len(connected) != 1 || connected[0] != socketID {
+			t.Fatalf("expected the connected socket %v to disconnect, got %q", connected, socketID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Disconnect once the socket closed")
+	}
+}
+
+func TestWSHandler_UnseatedPlayerMustSpectate(t *testing.T) {
+	t.Parallel()
+	server, svc := setupWSTestServer(t)
+
+	svc.mu.Lock()
+	svc.seated = []string{"user-2"}
+	svc.mu.Unlock()
+
+	conn := dialWS(t, server, "/games/game-1/ws", generateValidToken("user-1", "alice"))
+
+	if msg := conn.ReadText(t); msg.Type != WSMessageTypeError || msg.Error != service.ErrNotSeated.Error() {
+		t.Fatalf("expected a player without a seat turned away, got %+v", msg)
+	}
+}
+
+func TestSpectateHandler_RejectsMovesAndHidesHands(t *testing.T) {
+	t.Parallel()
+	server, svc := setupWSTestServer(t)
+
+	svc.mu.Lock()
+	svc.spectated = game.New("game-1")
+	svc.mu.Unlock()
+
+	conn := dialWS(t, server, "/games/game-1/spectate", generateValidToken("user-1", "alice"))
+
+	if err := conn.WriteJSON(map[string]any{keyType: WSMessageTypeMove, keyMoveType: "pass", "client_version": 1}); err != nil {
+		t.Fatalf("failed to write move: %v", err)
+	}
+
+	if msg := conn.ReadText(t); msg.Error != "spectators cannot make moves" || svc.WasProcessMoveCalled() {
+		t.Fatalf("expected the move refused, got %+v", msg)
+	}
+
+	g := dealtGame("game-1")
+	publishWSEvent(t, svc, map[string]any{keyType: "move", "game_state": g})
+
+	var event struct {
+		GameState game.Game `json:"game_state"`
+	}
+	if err := json.Unmarshal([]byte(conn.ReadRawText(t)), &event); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	for _, p := range event.GameState.Players {
+		if p != nil && p.Hand != nil {
+			t.Fatalf("a live spectator saw %s's hand", p.ID)
+		}
+	}
+
+	publishWSEvent(t, svc, map[string]any{keyType: "spectating_changed", "open": false})
+
+	_ = conn.setReadDeadline(2 * time.Second)
+	if _, _, err := conn.Conn.ReadMessage(); !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Fatalf("expected the socket closed with spectating, got %v", err)
+	}
+}
+
+func TestSpectateHandler_DelayedSpectatorSeesHandsLater(t *testing.T) {
+	t.Parallel()
+	server, svc := setupWSTestServer(t)
+
+	cfg := game.DefaultConfig()
+	cfg.Spectators = &game.SpectatorConfig{Open: true, Delay: 1}
+
+	svc.mu.Lock()
+	svc.spectated = game.NewWithConfig("game-1", cfg)
+	svc.mu.Unlock()
+
+	conn := dialWS(t, server, "/games/game-1/spectate", generateValidToken("user-1", "alice"))
+
+	// The refused move shows the socket is subscribed.
+	if err := conn.WriteJSON(map[string]any{keyType: WSMessageTypeMove, keyMoveType: "pass"}); err != nil {
+		t.Fatalf("failed to write move: %v", err)
+	}
+	_ = conn.ReadText(t)
+
+	start := time.Now()
+	publishWSEvent(t, svc, map[string]any{keyType: "move", "game_state": dealtGame("game-1")})
+
+	var event struct {
+		GameState game.Game `json:"game_state"`
+	}
+	if err := json.Unmarshal([]byte(conn.ReadRawText(t)), &event); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected the event held back a second, got it after %v", elapsed)
+	}
+
+	if len(event.GameState.Players[1].Hand) == 0 {
+		t.Fatal("a delayed spectator should see every hand")
+	}
+}
+
+// publishWSEvent publishes event to game-1's channel as the service would.
+func publishWSEvent(t *testing.T, svc *fakeWSGameService, event map[string]any) {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	if err := svc.redisClient.Publish(t.Context(), "game:game-1:events", data).Err(); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+}
+
 type wsErrorMessage struct {
 	Type  string `json:"type"`
 	Error string `json:"error"`
@@ -387,3 +598,82 @@ func (c *websocketConn) ReadRawText(t *testing.T) string {
 func (c *websocketConn) setReadDeadline(timeout time.Duration) error {
 	return c.Conn.SetReadDeadline(time.Now().Add(timeout))
 }
+
+func TestWSHandler_LastJoinerHandStaysHidden(t *testing.T) {
+	t.Parallel()
+
+	mini := miniredis.RunT(t)
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// The join ledgers the move, the first hand's seed and the new status.
+	mock.MatchExpectationsInOrder(false)
+	for range 3 {
+		mock.ExpectExec(".").WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	store := redisstore.NewStore(mini.Addr())
+	t.Cleanup(func() { _ = store.Close() })
+	svc := service.NewGame(store, postgres.NewStoreWithDB(db))
+
+	cfg := game.DefaultConfig()
+	cfg.Spectators = &game.SpectatorConfig{Open: true}
+	g := game.NewWithConfig("game-1", cfg)
+	for i := range 4 {
+		g.SeatPlayer(i, fmt.Sprintf("player-%d", i+1), fmt.Sprintf("player-%d", i+1))
+	}
+
+	if err := store.SaveGame(t.Context(), g, 0); err != nil {
+		t.Fatalf("seed game: %v", err)
+	}
+
+	seated := NewHandler(svc, &fakeValidator{claims: &service.AuthClaims{UserID: "player-1", Username: "alice"}})
+	watcher := NewHandler(svc, &fakeValidator{claims: &service.AuthClaims{UserID: "watcher", Username: "eve"}})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/games/{id}/ws", seated.WSHandler)
+	mux.HandleFunc("/games/{id}/spectate", watcher.SpectateHandler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	conns := map[string]*websocketConn{
+		"seated player": dialWS(t, server, "/games/game-1/ws", "token"),
+		"spectator":     dialWS(t, server, "/games/game-1/spectate", "token"),
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for mini.PubSubNumSub("game:game-1:events")["game:game-1:events"] < len(conns) {
+		if time.Now().After(deadline) {
+			t.Fatal("sockets never subscribed")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	joined, err := svc.JoinGame(t.Context(), "game-1", "player-5", "player-5")
+	if err != nil {
+		t.Fatalf("join: %v", err)
+	}
+
+	if len(joined.Players[4].Hand) != 10 {
+		t.Fatalf("expected the full table dealt, got %v", joined.Players[4].Hand)
+	}
+
+	for name, conn := range conns {
+		for {
+			msg := conn.ReadRawText(t)
+			if !strings.Contains(msg, `"type":"player_joined"`) {
+				continue
+			}
+
+			if strings.Contains(msg, `"hand"`) {
+				t.Fatalf("the %s saw the last joiner's hand: %s", name, msg)
+			}
+
+			break
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package bot provides computer players that can fill empty seats. A bot
+// decides from the game as its own seat is allowed to see it, and its move
+// then goes through ValidateMove and ApplyMove like anyone else's.
+package bot
+
+import (
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// Level is a bot's difficulty.
+type Level string
+
+const (
+	// Easy plays a random legal move.
+	Easy Level = "easy"
+	// Medium bids from the hand evaluator, calls the strongest friend it
+	// lacks, and plays simple trick-taking heuristics.
+	Medium Level = "medium"
+	// Hard plays like Medium but counts cards, leading a card once nothing
+	// unseen can beat it.
+	Hard Level = "hard"
+	// Expert bids, discards and calls like Hard, and chooses each card by
+	// simulating the rest of the hand over many deals of the unseen cards.
+	Expert Level = "expert"
+)
+
+// Levels lists every difficulty, easiest first.
+var Levels = []Level{Easy, Medium, Hard, Expert}
+
+// Budget bounds the search an Expert bot makes for one move: it stops at
+// whichever limit it reaches first. A zero field takes DefaultBudget's value.
+type Budget struct {
+	Iterations int           // sampled deals of the unseen cards
+	Time       time.Duration // wall-clock time
+}
+
+// DefaultBudget keeps an Expert move to a fraction of a second.
+var DefaultBudget = Budget{Iterations: 200, Time: 300 * time.Millisecond}
+
+// ParseLevel returns the named difficulty.
+func ParseLevel(name string) (Level, bool) {
+	l := Level(name)
+	return l, slices.Contains(Levels, l)
+}
+
+// Strategy picks moves for one seat.
+type Strategy interface {
+	// Choose returns playerID's next move in view, which must be the game
+	// as that player may see it. It returns false when the bot has nothing
+	// to do: it is someone else's turn, or the bot is waiting on others.
+	Choose(view *game.Game, playerID string) (game.LegalMove, bool)
+}
+
+// New returns a strategy for level drawing its random choices from rng.
+// budget bounds an Expert's search and is ignored by the other levels. An
+// unknown level plays as Medium.
+func New(level Level, rng *rand.Rand, budget Budget) Strategy {
+	switch level {
+	case Easy:
+		return &random{rng: rng}
+	case Hard:
+		return &heuristic{rng: rng, countCards: true}
+	case Expert:
+		return newMonteCarlo(rng, budget)
+	default:
+		return &heuristic{rng: rng}
+	}
+}
+
+// NextMove finds a seated bot with a move to make in g. It returns the bot's
+// ID and move, or false when no bot has anything to do.
+func NextMove(g *game.Game, rng *rand.Rand, budget Budget) (string, game.LegalMove, bool) {
+	for _, p := range g.Players {
+		if p == nil || p.Bot == "" {
+			continue
+		}
+
+		if m, ok := New(Level(p.Bot), rng, budget).Choose(g.View(p.ID), p.ID); ok {
+			return p.ID, m, true
+		}
+	}
+
+	return "", game.LegalMove{}, false
+}
+
+// options lists playerID's legal moves, leaving out the table-level choices
+// no bot makes on its own: throwing in a hand, conceding, changing the
+// trump and reshaping the table between rounds. A bot votes to play again
+// only once. Bots never claim, so claims are not searched for.
+func options(view *game.Game, playerID string) []game.LegalMove {
+	seat := view.GetPlayer(playerID).Seat
+
+	return slices.DeleteFunc(view.LegalMovesExceptClaims(playerID), func(m game.LegalMove) bool {
+		switch m.Type {
+		case game.MoveDealMiss, game.MoveConcede, game.MoveChangeTrump, game.MoveChangeConfig:
+			return true
+		case game.MovePlayAgain:
+			return view.PlayAgainVotes[seat]
+		}
+		return false
+	})
+}
+
+// random plays uniformly among its legal moves.
+type random struct {
+	rng *rand.Rand
+}
+
+func (r *random) Choose(view *game.Game, playerID string) (game.LegalMove, bool) {
+	moves := options(view, playerID)
+	if len(moves) == 0 {
+		return game.LegalMove{}, false
+	}
+
+	return r.pick(moves), true
+}
+
+// pick returns one of moves at random, resolving a discard to random cards.
+func (r *random) pick(moves []game.LegalMove) game.LegalMove {
+	m := moves[r.rng.IntN(len(moves))]
+	if opt, ok := m.Payload.(game.DiscardOption); ok {
+		from := slices.Clone(opt.From)
+		r.rng.Shuffle(len(from), func(i, j int) { from[i], from[j] = from[j], from[i] })
+		m.Payload = from[:opt.Count]
+	}
+
+	return m
+}
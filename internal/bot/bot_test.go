@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// testBudget keeps Expert searches small enough for whole test hands.
+var testBudget = Budget{Iterations: 4}
+
+// botTable seats n bots of level in a game dealt from seed.
+func botTable(n int, level Level, seed byte) *game.Game {
+	cfg := game.DefaultConfig()
+	cfg.NumPlayers = n
+	g := game.NewWithConfig("bots", cfg, game.WithSeed(game.Seed{seed}))
+
+	for seat := range n {
+		id := fmt.Sprintf("bot-%d", seat)
+		g.SeatBot(seat, id, "Bot "+id, string(level))
+	}
+
+	return g
+}
+
+func TestBotsPlayAHandToTheEnd(t *testing.T) {
+	t.Parallel()
+
+	for _, level := range Levels {
+		for n := 4; n <= 6; n++ {
+			for seed := range byte(5) {
+				t.Run(fmt.Sprintf("%s/%d players/seed %d", level, n, seed), func(t *testing.T) {
+					t.Parallel()
+
+					g := botTable(n, level, seed)
+					rng := rand.New(rand.NewPCG(uint64(seed), 7))
+
+					for step := 0; g.Status != game.PhaseFinished; step++ {
+						if step > 500 {
+							t.Fatalf("hand did not finish, stuck in %s", g.Status)
+						}
+
+						id, m, ok := NextMove(g, rng, testBudget)
+						if !ok {
+							t.Fatalf("no bot has a move in %s", g.Status)
+						}
+
+						if err := g.ApplyMove(id, m.Type, m.Payload); err != nil {
+							t.Fatalf("%s played an illegal %s %v: %v", id, m.Type, m.Payload, err)
+						}
+					}
+
+					sum := 0
+					for _, v := range g.Scores {
+						sum += v
+					}
+					if len(g.ScoreHistory) != 1 || sum != 0 {
+						t.Fatalf("hand not scored: history %d, scores %v", len(g.ScoreHistory), g.Scores)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBotsVoteToPlayAgainOnce(t *testing.T) {
+	t.Parallel()
+
+	g := botTable(5, Medium, 1)
+	g.Status = game.PhaseFinished
+	g.PlayAgainVotes = map[int]bool{0: true}
+
+	m, ok := New(Medium, rand.New(rand.NewPCG(1, 2)), testBudget).Choose(g.View("bot-1"), "bot-1")
+	if !ok || m.Type != game.MovePlayAgain {
+		t.Fatalf("expected a play_again vote, got %v %v", m, ok)
+	}
+
+	if _, ok := New(Easy, rand.New(rand.NewPCG(1, 2)), testBudget).Choose(g.View("bot-0"), "bot-0"); ok {
+		t.Fatal("a bot that already voted must wait for the others")
+	}
+}
+
+func TestHeuristicCallsTheMightyWhenItLacksIt(t *testing.T) {
+	t.Parallel()
+
+	// Find a deal where the declarer lacks the Mighty.
+	var g *game.Game
+	for seed := byte(0); g == nil; seed++ {
+		g = botTable(5, Medium, seed)
+		rng := rand.New(rand.NewPCG(uint64(seed), 3))
+		for g.Status != game.PhaseCalling {
+			id, m, ok := NextMove(g, rng, testBudget)
+			if !ok {
+				t.Fatalf("stuck in %s", g.Status)
+			}
+			if err := g.ApplyMove(id, m.Type, m.Payload); err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+		}
+
+		if g.Players[g.Declarer].HasMighty(g) {
+			g = nil
+		}
+	}
+
+	declarer := g.Players[g.Declarer]
+	m, ok := New(Medium, rand.New(rand.NewPCG(2, 3)), testBudget).Choose(g.View(declarer.ID), declarer.ID)
+	call, isCall := m.Payload.(game.CallPartnerMove)
+	if !ok || !isCall || call.Card == nil || !g.IsMighty(*call.Card) {
+		t.Fatalf("expected a Mighty call, got %+v", m)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+
+	if l, ok := ParseLevel("hard"); !ok || l != Hard {
+		t.Fatalf("ParseLevel(hard) = %q, %v", l, ok)
+	}
+
+	if _, ok := ParseLevel("godlike"); ok {
+		t.Fatal("unknown level accepted")
+	}
+}
@@ -0,0 +1,386 @@
+package bot
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// heuristic bids from the hand evaluator and plays simple trick-taking rules.
+// With countCards it also tracks what has been played and leads a card once
+// no unseen card can beat it.
+type heuristic struct {
+	rng        *rand.Rand
+	countCards bool
+}
+
+func (h *heuristic) Choose(view *game.Game, playerID string) (game.LegalMove, bool) {
+	moves := options(view, playerID)
+	if len(moves) == 0 {
+		return game.LegalMove{}, false
+	}
+
+	p := view.GetPlayer(playerID)
+
+	switch view.Status {
+	case game.PhaseBidding:
+		return h.bid(view, p, moves), true
+	case game.PhaseExchanging:
+		if m, ok := find(moves, game.MoveDiscard, nil); ok {
+			return discard(view, m), true
+		}
+	case game.PhaseCalling:
+		return h.call(view, p, moves), true
+	case game.PhasePlaying:
+		if m, ok := h.play(view, p, moves); ok {
+			return m, true
+		}
+	}
+
+	return h.pick(moves), true
+}
+
+// pick plays a random move, for the choices the heuristic has no opinion on.
+func (h *heuristic) pick(moves []game.LegalMove) game.LegalMove {
+	return (&random{rng: h.rng}).pick(moves)
+}
+
+// bid follows the hand evaluator's advice, passing when it cannot be bid.
+func (h *heuristic) bid(view *game.Game, p *game.Player, moves []game.LegalMove) game.LegalMove {
+	if advice, err := view.AdviseBid(p.ID); err == nil && advice.Bid != nil {
+		want := *advice.Bid
+		m, ok := find(moves, game.MoveBid, func(payload any) bool {
+			b := payload.(game.Bid)
+			return b.Points == want.Points && b.Suit == want.Suit && b.IsNoTrump == want.IsNoTrump
+		})
+		if ok {
+			return m
+		}
+	}
+
+	if m, ok := find(moves, game.MovePass, nil); ok {
+		return m
+	}
+
+	return h.pick(moves)
+}
+
+// discard returns the cards least worth keeping.
+func discard(view *game.Game, m game.LegalMove) game.LegalMove {
+	opt := m.Payload.(game.DiscardOption)
+	from := slices.Clone(opt.From)
+	slices.SortStableFunc(from, func(a, b game.Card) int {
+		return cmp.Compare(keepValue(view, a), keepValue(view, b))
+	})
+
+	m.Payload = from[:opt.Count]
+	return m
+}
+
+// call names the strongest friend card the declarer lacks: the Mighty, the
+// Joker, a top trump, then a side ace. Failing that it takes the first
+// trick's winner, and plays alone as a last resort.
+func (h *heuristic) call(view *game.Game, p *game.Player, moves []game.LegalMove) game.LegalMove {
+	var wanted []game.Card
+	for _, c := range game.NewDeck() {
+		if view.IsMighty(c) {
+			wanted = append(wanted, c)
+		}
+	}
+	wanted = append(wanted, game.Card{Suit: game.None, Rank: game.Joker})
+	if view.Trump != game.None {
+		for _, r := range []game.Rank{game.Ace, game.King, game.Queen} {
+			wanted = append(wanted, game.Card{Suit: view.Trump, Rank: r})
+		}
+	}
+	for _, s := range []game.Suit{game.Spades, game.Diamonds, game.Hearts, game.Clubs} {
+		if s != view.Trump {
+			wanted = append(wanted, game.Card{Suit: s, Rank: game.Ace})
+		}
+	}
+
+	for _, c := range wanted {
+		if p.HasCard(c) {
+			continue
+		}
+
+		m, ok := find(moves, game.MoveCallPartner, func(payload any) bool {
+			call := payload.(game.CallPartnerMove)
+			return call.Card != nil && *call.Card == c
+		})
+		if ok {
+			return m
+		}
+	}
+
+	fallbacks := []func(game.CallPartnerMove) bool{
+		func(call game.CallPartnerMove) bool { return call.Mode == game.FriendFirstTrick },
+		func(call game.CallPartnerMove) bool { return call.NoFriend },
+	}
+	for _, match := range fallbacks {
+		if m, ok := find(moves, game.MoveCallPartner, func(payload any) bool { return match(payload.(game.CallPartnerMove)) }); ok {
+			return m
+		}
+	}
+
+	return h.pick(moves)
+}
+
+// play chooses a card to lead or follow with.
+func (h *heuristic) play(view *game.Game, p *game.Player, moves []game.LegalMove) (game.LegalMove, bool) {
+	var plays []game.LegalMove
+	for _, m := range moves {
+		if m.Type == game.MovePlayCard {
+			plays = append(plays, m)
+		}
+	}
+	if len(plays) == 0 || len(view.Tricks) == 0 {
+		return game.LegalMove{}, false
+	}
+
+	t := view.Tricks[len(view.Tricks)-1]
+	if len(t.Cards) == 0 {
+		return h.lead(view, p, plays), true
+	}
+
+	return h.follow(view, p, t, plays), true
+}
+
+// lead opens a trick. The declarer's side draws trumps from the top; everyone
+// else leads low. When counting cards, a card nothing unseen can beat goes
+// first.
+func (h *heuristic) lead(view *game.Game, p *game.Player, plays []game.LegalMove) game.LegalMove {
+	cards := distinctCards(plays)
+
+	var unseen []game.Card
+	if h.countCards {
+		unseen = unseenCards(view, p)
+		for _, c := range cards {
+			if isBoss(view, p, c, unseen) {
+				return h.leadWith(view, plays, c, unseen)
+			}
+		}
+	}
+
+	if onDeclarerSide(view, p) && len(view.Tricks) > 1 {
+		trumps := slices.DeleteFunc(slices.Clone(cards), func(c game.Card) bool {
+			return c.Suit != view.Trump && !view.IsMighty(c)
+		})
+		if len(trumps) >= 2 || slices.ContainsFunc(trumps, view.IsMighty) {
+			top := slices.MaxFunc(trumps, func(a, b game.Card) int {
+				return cmp.Compare(keepValue(view, a), keepValue(view, b))
+			})
+			return h.leadWith(view, plays, top, unseen)
+		}
+	}
+
+	return h.leadWith(view, plays, cheapest(view, cards, keepValue), unseen)
+}
+
+// leadWith picks the play for c: a Joker lead calls the trump suit when there
+// is one, and a card-counting Joker Caller calls the Joker while it is still
+// out.
+func (h *heuristic) leadWith(view *game.Game, plays []game.LegalMove, c game.Card, unseen []game.Card) game.LegalMove {
+	var options []game.LegalMove
+	for _, m := range plays {
+		if m.Payload.(game.PlayCardMove).Card == c {
+			options = append(options, m)
+		}
+	}
+
+	jokerOut := slices.Contains(unseen, game.Card{Suit: game.None, Rank: game.Joker})
+	for _, m := range options {
+		move := m.Payload.(game.PlayCardMove)
+		if c.Rank == game.Joker && view.Trump != game.None && move.CalledSuit == view.Trump {
+			return m
+		}
+		if move.CallJoker && h.countCards && jokerOut {
+			return m
+		}
+	}
+
+	for _, m := range options {
+		if !m.Payload.(game.PlayCardMove).CallJoker {
+			return m
+		}
+	}
+
+	return h.pick(options)
+}
+
+// follow plays to a trick already led. With an ally winning it feeds them a
+// point card; otherwise it wins as cheaply as it can, saving the Mighty and
+// the Joker for tricks worth taking, and discards low when it cannot.
+func (h *heuristic) follow(view *game.Game, p *game.Player, t game.Trick, plays []game.LegalMove) game.LegalMove {
+	leader, _ := view.ResolveTrick(t)
+	cards := distinctCards(plays)
+
+	if isAlly(view, p, leader) {
+		points := slices.DeleteFunc(slices.Clone(cards), func(c game.Card) bool {
+			return !c.IsPointCard() || view.IsMighty(c) || c.Rank == game.Joker || c.Suit == view.Trump
+		})
+		if len(points) > 0 {
+			return playOf(plays, cheapest(view, points, keepValue))
+		}
+		return playOf(plays, cheapest(view, cards, keepValue))
+	}
+
+	trickHasPoints := slices.ContainsFunc(t.Cards, func(pc game.PlayedCard) bool { return pc.Card.IsPointCard() })
+
+	var winners []game.Card
+	for _, c := range cards {
+		after := t
+		after.Cards = append(slices.Clone(t.Cards), game.PlayedCard{PlayerID: p.ID, Seat: p.Seat, Card: c})
+		if seat, _ := view.ResolveTrick(after); seat != p.Seat {
+			continue
+		}
+		if (view.IsMighty(c) || c.Rank == game.Joker) && !trickHasPoints {
+			continue
+		}
+		winners = append(winners, c)
+	}
+	if len(winners) > 0 {
+		return playOf(plays, cheapest(view, winners, keepValue))
+	}
+
+	return playOf(plays, cheapest(view, cards, dumpValue))
+}
+
+// onDeclarerSide reports whether p is the declarer or knows itself to be the
+// friend.
+func onDeclarerSide(view *game.Game, p *game.Player) bool {
+	if p.Seat == view.Declarer || p.Seat == view.PartnerSeat {
+		return true
+	}
+
+	switch view.FriendMode {
+	case game.FriendMighty:
+		return slices.ContainsFunc(p.Hand, view.IsMighty)
+	case game.FriendJoker:
+		return p.HasRank(game.Joker)
+	}
+
+	return view.PartnerCard != nil && p.HasCard(*view.PartnerCard)
+}
+
+// isAlly reports whether p can tell that seat plays on its side. A defender
+// counts every seat but the declarer's and the revealed friend's.
+func isAlly(view *game.Game, p *game.Player, seat int) bool {
+	if seat == p.Seat {
+		return true
+	}
+
+	if onDeclarerSide(view, p) {
+		return seat == view.Declarer || (view.PartnerSeat >= 0 && seat == view.PartnerSeat)
+	}
+
+	return seat != view.Declarer && seat != view.PartnerSeat
+}
+
+// keepValue ranks a card by how much it is worth holding on to.
+func keepValue(view *game.Game, c game.Card) int {
+	switch {
+	case view.IsMighty(c):
+		return 100
+	case c.Rank == game.Joker:
+		return 90
+	case c.Suit == view.Trump:
+		return 40 + game.RankValue(c.Rank)
+	default:
+		return game.RankValue(c.Rank)
+	}
+}
+
+// dumpValue ranks a card by how costly it is to throw away, sparing point
+// cards the opponents would collect.
+func dumpValue(view *game.Game, c game.Card) int {
+	v := keepValue(view, c)
+	if c.IsPointCard() {
+		v += 20
+	}
+	return v
+}
+
+// cheapest returns the card of least value; cards is never empty.
+func cheapest(view *game.Game, cards []game.Card, value func(*game.Game, game.Card) int) game.Card {
+	return slices.MinFunc(cards, func(a, b game.Card) int {
+		return cmp.Compare(value(view, a), value(view, b))
+	})
+}
+
+// unseenCards lists the cards p has not seen: everything outside its own
+// hand and the tricks played so far.
+func unseenCards(view *game.Game, p *game.Player) []game.Card {
+	seen := make(map[game.Card]bool)
+	for _, c := range p.Hand {
+		seen[c] = true
+	}
+	for _, t := range view.Tricks {
+		for _, pc := range t.Cards {
+			seen[pc.Card] = true
+		}
+	}
+
+	var unseen []game.Card
+	for _, c := range game.NewDeckFor(view.Config.NumPlayers) {
+		if !seen[c] {
+			unseen = append(unseen, c)
+		}
+	}
+	return unseen
+}
+
+// isBoss reports whether leading c wins the trick whatever unseen card is
+// played to it.
+func isBoss(view *game.Game, p *game.Player, c game.Card, unseen []game.Card) bool {
+	lead := c.Suit
+	if c.Rank == game.Joker {
+		lead = view.Trump
+	}
+
+	t := game.Trick{LeadSuit: lead, Cards: []game.PlayedCard{{PlayerID: p.ID, Seat: p.Seat, Card: c}}}
+	trickNum := len(view.Tricks)
+	power := view.CalculatePower(c, t, trickNum)
+
+	for _, u := range unseen {
+		if view.CalculatePower(u, t, trickNum) > power {
+			return false
+		}
+	}
+	return c.Rank != game.Joker || view.Trump != game.None
+}
+
+// distinctCards lists the cards among plays, once each.
+func distinctCards(plays []game.LegalMove) []game.Card {
+	var cards []game.Card
+	for _, m := range plays {
+		c := m.Payload.(game.PlayCardMove).Card
+		if !slices.Contains(cards, c) {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// playOf returns the plain play of c, without a Joker call.
+func playOf(plays []game.LegalMove, c game.Card) game.LegalMove {
+	for _, m := range plays {
+		if move := m.Payload.(game.PlayCardMove); move.Card == c && !move.CallJoker {
+			return m
+		}
+	}
+	return plays[0]
+}
+
+// find returns the first move of type t whose payload satisfies match; a nil
+// match accepts any payload.
+func find(moves []game.LegalMove, t game.MoveType, match func(any) bool) (game.LegalMove, bool) {
+	for _, m := range moves {
+		if m.Type == t && (match == nil || match(m.Payload)) {
+			return m, true
+		}
+	}
+	return game.LegalMove{}, false
+}
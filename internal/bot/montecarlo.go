@@ -0,0 +1,273 @@
+package bot
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// dealAttempts is how many times determinize tries to deal the unseen cards
+// within every known void before it gives up on the voids.
+const dealAttempts = 10
+
+// montecarlo chooses each card by determinized search: it deals the cards it
+// cannot see in a way that fits everything the table has shown, plays every
+// candidate out to the end of the hand with the heuristic at every seat, and
+// keeps the card with the best total score over all the deals. Bidding, the
+// discard and the friend call are left to the card-counting heuristic, which
+// also breaks ties.
+type montecarlo struct {
+	rng      *rand.Rand
+	budget   Budget
+	fallback *heuristic
+}
+
+func newMonteCarlo(rng *rand.Rand, budget Budget) *montecarlo {
+	if budget.Iterations <= 0 {
+		budget.Iterations = DefaultBudget.Iterations
+	}
+	if budget.Time <= 0 {
+		budget.Time = DefaultBudget.Time
+	}
+
+	return &montecarlo{rng: rng, budget: budget, fallback: &heuristic{rng: rng, countCards: true}}
+}
+
+func (m *montecarlo) Choose(view *game.Game, playerID string) (game.LegalMove, bool) {
+	preferred, ok := m.fallback.Choose(view, playerID)
+	if !ok || view.Status != game.PhasePlaying || preferred.Type != game.MovePlayCard {
+		return preferred, ok
+	}
+
+	plays := []game.LegalMove{preferred}
+	for _, mv := range options(view, playerID) {
+		if mv.Type == game.MovePlayCard && mv.Payload != preferred.Payload {
+			plays = append(plays, mv)
+		}
+	}
+	if len(plays) == 1 {
+		return preferred, true
+	}
+
+	p := view.GetPlayer(playerID)
+	shown := readConstraints(view)
+	totals := make([]int, len(plays))
+	deadline := time.Now().Add(m.budget.Time)
+
+	for i := 0; i < m.budget.Iterations && (i == 0 || time.Now().Before(deadline)); i++ {
+		world := determinize(view, p, shown, m.rng)
+		for j, play := range plays {
+			totals[j] += m.simulate(world, p, play)
+		}
+	}
+
+	best := 0
+	for j := range totals {
+		if totals[j] > totals[best] {
+			best = j
+		}
+	}
+
+	return plays[best], true
+}
+
+// simulate plays play in a copy of world and the rest of the hand with the
+// heuristic, and returns p's score for the hand.
+func (m *montecarlo) simulate(world *game.Game, p *game.Player, play game.LegalMove) int {
+	sim := world.Clone()
+	if err := sim.ApplyMove(p.ID, play.Type, play.Payload); err != nil {
+		return math.MinInt32
+	}
+
+	rollout := &heuristic{rng: m.rng}
+	for sim.Status == game.PhasePlaying {
+		actor := sim.Players[sim.CurrentTurn]
+
+		mv, ok := rollout.Choose(sim, actor.ID)
+		if !ok || sim.ApplyMove(actor.ID, mv.Type, mv.Payload) != nil {
+			break
+		}
+	}
+
+	return sim.CalculateFinalScore()[p.Seat]
+}
+
+// constraints is what the tricks so far show about the hidden hands.
+type constraints struct {
+	void    map[int]map[game.Suit]bool // seats that failed to follow a suit
+	noJoker map[int]bool               // seats that did not answer a Joker call with it
+}
+
+func readConstraints(view *game.Game) constraints {
+	c := constraints{void: make(map[int]map[game.Suit]bool), noJoker: make(map[int]bool)}
+
+	for _, t := range view.Tricks {
+		if len(t.Cards) < 2 {
+			continue
+		}
+
+		for _, pc := range t.Cards[1:] {
+			if view.IsMighty(pc.Card) || pc.Card.Rank == game.Joker {
+				continue
+			}
+
+			if pc.Card.Suit != t.LeadSuit {
+				if c.void[pc.Seat] == nil {
+					c.void[pc.Seat] = make(map[game.Suit]bool)
+				}
+				c.void[pc.Seat][t.LeadSuit] = true
+			}
+
+			if t.JokerCalled && view.JokerCallForced() {
+				c.noJoker[pc.Seat] = true
+			}
+		}
+	}
+
+	return c
+}
+
+// allows reports whether seat may still hold card. The Mighty and the Joker
+// can be played on any lead, so a void says nothing about them.
+func (c constraints) allows(view *game.Game, seat int, card game.Card) bool {
+	switch {
+	case card.Rank == game.Joker:
+		return !c.noJoker[seat]
+	case view.IsMighty(card):
+		return true
+	default:
+		return !c.void[seat][card.Suit]
+	}
+}
+
+// determinize returns a copy of view with every hand p cannot see dealt from
+// the cards p has not seen, keeping each seat's hand size and known voids.
+// Whatever is left over becomes the kitty, which only the declarer knows.
+func determinize(view *game.Game, p *game.Player, shown constraints, rng *rand.Rand) *game.Game {
+	world := view.Clone()
+
+	known := make(map[game.Card]bool)
+	for _, c := range p.Hand {
+		known[c] = true
+	}
+	for _, c := range world.Kitty {
+		known[c] = true
+	}
+	for _, t := range world.Tricks {
+		for _, pc := range t.Cards {
+			known[pc.Card] = true
+		}
+	}
+	for _, q := range world.Players {
+		if q != nil {
+			for _, c := range q.Points {
+				known[c] = true
+			}
+		}
+	}
+
+	var pool []game.Card
+	for _, c := range game.NewDeckFor(view.Config.NumPlayers) {
+		if !known[c] {
+			pool = append(pool, c)
+		}
+	}
+
+	need := make(map[int]int)
+	hidden := 0
+	for seat, q := range world.Players {
+		if q != nil && q.ID != p.ID {
+			need[seat] = q.HandCount
+			hidden += q.HandCount
+		}
+	}
+
+	// The leftover goes to the kitty, seat -1, which takes any card.
+	const kitty = -1
+	need[kitty] = max(len(pool)-hidden, 0)
+
+	allows := func(seat int, c game.Card) bool {
+		return seat == kitty || shown.allows(view, seat, c)
+	}
+
+	dealt, ok := dealWithin(pool, need, allows, rng)
+	for attempt := 1; !ok && attempt < dealAttempts; attempt++ {
+		dealt, ok = dealWithin(pool, need, allows, rng)
+	}
+	if !ok {
+		dealt, _ = dealWithin(pool, need, func(int, game.Card) bool { return true }, rng)
+	}
+
+	for seat, q := range world.Players {
+		if q != nil && q.ID != p.ID {
+			q.Hand = dealt[seat]
+		}
+	}
+	if len(dealt[kitty]) > 0 {
+		world.Kitty = append(world.Kitty, dealt[kitty]...)
+	}
+
+	return world
+}
+
+// dealWithin deals pool to the seats in need, each card to a seat allows. The
+// most constrained cards go first, each to an eligible seat with probability
+// in proportion to the room it has left. It reports false when a card has
+// nowhere to go.
+func dealWithin(pool []game.Card, need map[int]int, allows func(int, game.Card) bool, rng *rand.Rand) (map[int][]game.Card, bool) {
+	seats := make([]int, 0, len(need))
+	for seat := range need {
+		seats = append(seats, seat)
+	}
+	slices.Sort(seats)
+
+	eligible := func(c game.Card) int {
+		n := 0
+		for _, seat := range seats {
+			if allows(seat, c) {
+				n++
+			}
+		}
+		return n
+	}
+
+	cards := slices.Clone(pool)
+	rng.Shuffle(len(cards), func(i, j int) { cards[i], cards[j] = cards[j], cards[i] })
+	slices.SortStableFunc(cards, func(a, b game.Card) int { return cmp.Compare(eligible(a), eligible(b)) })
+
+	room := make(map[int]int, len(need))
+	for seat, n := range need {
+		room[seat] = n
+	}
+
+	dealt := make(map[int][]game.Card, len(need))
+	for _, c := range cards {
+		total := 0
+		for _, seat := range seats {
+			if room[seat] > 0 && allows(seat, c) {
+				total += room[seat]
+			}
+		}
+		if total == 0 {
+			return nil, false
+		}
+
+		r := rng.IntN(total)
+		for _, seat := range seats {
+			if room[seat] == 0 || !allows(seat, c) {
+				continue
+			}
+			if r -= room[seat]; r < 0 {
+				dealt[seat] = append(dealt[seat], c)
+				room[seat]--
+				break
+			}
+		}
+	}
+
+	return dealt, true
+}
@@ -0,0 +1,104 @@
+package bot
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// midHand plays a Medium table from seed until tricks tricks are complete.
+func midHand(t *testing.T, seed byte, tricks int) *game.Game {
+	t.Helper()
+
+	g := botTable(5, Medium, seed)
+	rng := rand.New(rand.NewPCG(uint64(seed), 5))
+
+	for g.Status != game.PhasePlaying || len(g.Tricks) <= tricks || len(g.Tricks[len(g.Tricks)-1].Cards) > 0 {
+		id, m, ok := NextMove(g, rng, testBudget)
+		if !ok || g.Status == game.PhaseFinished {
+			t.Fatalf("hand ended before trick %d", tricks)
+		}
+		if err := g.ApplyMove(id, m.Type, m.Payload); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	return g
+}
+
+func TestDeterminizeFitsWhatTheViewerKnows(t *testing.T) {
+	t.Parallel()
+
+	for seed := range byte(4) {
+		g := midHand(t, seed, 4)
+		shown := readConstraints(g)
+
+		for _, viewer := range g.Players {
+			view := g.View(viewer.ID)
+			p := view.GetPlayer(viewer.ID)
+			rng := rand.New(rand.NewPCG(uint64(seed), uint64(viewer.Seat)))
+
+			for range 20 {
+				world := determinize(view, p, shown, rng)
+
+				if !slices.Equal(world.Players[viewer.Seat].Hand, viewer.Hand) {
+					t.Fatal("the viewer's own hand must not change")
+				}
+
+				seen := make(map[game.Card]int)
+				for _, c := range world.Kitty {
+					seen[c]++
+				}
+				for _, tr := range world.Tricks {
+					for _, pc := range tr.Cards {
+						seen[pc.Card]++
+					}
+				}
+
+				for seat, q := range world.Players {
+					if len(q.Hand) != len(g.Players[seat].Hand) {
+						t.Fatalf("seat %d dealt %d cards, holds %d", seat, len(q.Hand), len(g.Players[seat].Hand))
+					}
+
+					for _, c := range q.Hand {
+						seen[c]++
+						if seat != viewer.Seat && !shown.allows(g, seat, c) {
+							t.Fatalf("seat %d dealt %s against a shown void", seat, c)
+						}
+					}
+				}
+
+				for _, c := range game.NewDeck() {
+					if n := seen[c]; n != 1 {
+						t.Fatalf("%s accounted for %d times", c, n)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestExpertKeepsToItsTimeBudget(t *testing.T) {
+	t.Parallel()
+
+	g := midHand(t, 1, 2)
+	id := g.Players[g.CurrentTurn].ID
+	expert := New(Expert, rand.New(rand.NewPCG(1, 1)), Budget{Iterations: 1 << 30, Time: 20 * time.Millisecond})
+
+	start := time.Now()
+	m, ok := expert.Choose(g.View(id), id)
+	if !ok || m.Type != game.MovePlayCard {
+		t.Fatalf("expected a card, got %+v", m)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("search ran %s on a 20ms budget", elapsed)
+	}
+
+	if err := g.ValidateMove(id, m.Type, m.Payload); err != nil {
+		t.Fatalf("expert chose an illegal card: %v", err)
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Evaluator weights, in scoring cards the declarer's side can expect to take.
+// A hand with nothing special is worth about evalBase once the friend and the
+// kitty are counted; each feature below adds to that.
+const (
+	evalBase        = 8
+	evalMighty      = 3
+	evalJoker       = 2
+	evalJokerCaller = 1 // only without the Joker: it can strip the Joker's power
+	evalLongTrump   = 1 // per trump beyond the third
+	evalTopTrump    = 1 // trump A and K
+	evalSideAce     = 1
+	evalNoTrumpAce  = 2
+	evalNoTrumpKing = 1
+	evalNoTrumpCost = 3 // no trump suit to ruff with
+)
+
+// StrainEvaluation scores a hand for one candidate trump, or no-trump.
+type StrainEvaluation struct {
+	Suit        Suit `json:"suit"` // None for no-trump
+	NoTrump     bool `json:"no_trump"`
+	Mighty      bool `json:"mighty"`       // holds the Mighty under this trump
+	Joker       bool `json:"joker"`        // holds the Joker
+	JokerCaller bool `json:"joker_caller"` // holds the Joker Caller under this trump
+	TrumpLength int  `json:"trump_length"`
+	TopTrumps   int  `json:"top_trumps"` // trump A and K, not counting the Mighty
+	Aces        int  `json:"aces"`       // aces outside trump, not counting the Mighty
+	Kings       int  `json:"kings"`      // kings outside trump; only valued at no-trump
+	Strength    int  `json:"strength"`   // estimated scoring cards for the declarer's side
+}
+
+// BidAdvice is a recommended bid with the evaluations behind it. Bid is nil
+// when the advice is to pass.
+type BidAdvice struct {
+	Bid         *Bid               `json:"bid,omitempty"`
+	Strains     []StrainEvaluation `json:"strains"`
+	Explanation string             `json:"explanation"`
+}
+
+// EvaluateHand scores hand for each suit and no-trump under the game's rule
+// set, strongest first. Ties keep the suit order of bidSuits, with no-trump
+// last.
+func (g *Game) EvaluateHand(hand []Card) []StrainEvaluation {
+	rs := g.rules()
+	strains := append(slices.Clone(bidSuits), None)
+
+	out := make([]StrainEvaluation, 0, len(strains))
+	for _, trump := range strains {
+		e := StrainEvaluation{Suit: trump, NoTrump: trump == None, Strength: evalBase}
+		mighty, caller := rs.mightyUnder(trump), rs.jokerCallerUnder(trump)
+
+		for _, c := range hand {
+			switch {
+			case c == mighty:
+				e.Mighty = true
+			case c.Rank == Joker:
+				e.Joker = true
+			case c.Suit == trump:
+				e.TrumpLength++
+				if c.Rank == Ace || c.Rank == King {
+					e.TopTrumps++
+				}
+			case c.Rank == Ace:
+				e.Aces++
+			case c.Rank == King:
+				e.Kings++
+			}
+			if c == caller {
+				e.JokerCaller = true
+			}
+		}
+
+		if e.Mighty {
+			e.Strength += evalMighty
+		}
+		if e.Joker {
+			e.Strength += evalJoker
+		} else if e.JokerCaller {
+			e.Strength += evalJokerCaller
+		}
+
+		if e.NoTrump {
+			e.Strength += evalNoTrumpAce*e.Aces + evalNoTrumpKing*e.Kings - evalNoTrumpCost
+		} else {
+			e.Strength += evalLongTrump*max(e.TrumpLength-3, 0) + evalTopTrump*e.TopTrumps + evalSideAce*e.Aces
+		}
+		e.Strength = min(e.Strength, 20)
+
+		out = append(out, e)
+	}
+
+	slices.SortStableFunc(out, func(a, b StrainEvaluation) int {
+		return cmp.Compare(b.Strength, a.Strength)
+	})
+
+	return out
+}
+
+// AdviseBid recommends a bid for playerID's own hand: the strongest strain at
+// the level its estimate reaches, or a pass when that is under the minimum
+// bid or cannot overcall the current bid.
+func (g *Game) AdviseBid(playerID string) (BidAdvice, error) {
+	p := g.GetPlayer(playerID)
+	if p == nil {
+		return BidAdvice{}, fmt.Errorf("%w: player not in game", ErrInvalidMove)
+	}
+
+	if g.Status != PhaseBidding {
+		return BidAdvice{}, fmt.Errorf("%w: bid advice is only given while bidding", ErrInvalidMove)
+	}
+
+	advice := BidAdvice{Strains: g.EvaluateHand(p.Hand)}
+	best := advice.Strains[0]
+	level := min(best.Strength-10, 10)
+	summary := describeStrain(best)
+
+	switch {
+	case level < g.minBidPoints():
+		advice.Explanation = fmt.Sprintf("%s: about %d scoring cards, short of the %d the minimum bid of %d needs. Pass.",
+			summary, best.Strength, g.minBidPoints()+10, g.minBidPoints())
+	default:
+		bid := Bid{PlayerID: playerID, Points: level, Suit: best.Suit, IsNoTrump: best.NoTrump}
+		if g.CurrentBid != nil && g.CompareBids(bid, *g.CurrentBid) <= 0 {
+			advice.Explanation = fmt.Sprintf("%s: about %d scoring cards, worth %s, which does not beat the current %s. Pass.",
+				summary, best.Strength, bidName(bid), bidName(*g.CurrentBid))
+			break
+		}
+
+		advice.Bid = &bid
+		advice.Explanation = fmt.Sprintf("%s: about %d scoring cards. Bid %s (target %d).",
+			summary, best.Strength, bidName(bid), level+10)
+	}
+
+	return advice, nil
+}
+
+// describeStrain summarises what makes e strong, e.g. "spades (6 trumps,
+// 2 top) with the Mighty, 1 side ace".
+func describeStrain(e StrainEvaluation) string {
+	var parts []string
+	if e.Mighty {
+		parts = append(parts, "the Mighty")
+	}
+	if e.Joker {
+		parts = append(parts, "the Joker")
+	}
+	if e.JokerCaller && !e.Joker {
+		parts = append(parts, "the Joker Caller")
+	}
+	if e.Aces > 0 {
+		parts = append(parts, plural(e.Aces, "side ace", "side aces"))
+	}
+	if e.NoTrump && e.Kings > 0 {
+		parts = append(parts, plural(e.Kings, "king", "kings"))
+	}
+
+	head := "no-trump"
+	if !e.NoTrump {
+		head = fmt.Sprintf("%s (%s, %d top)", e.Suit, plural(e.TrumpLength, "trump", "trumps"), e.TopTrumps)
+	}
+
+	if len(parts) == 0 {
+		return head
+	}
+
+	return head + " with " + strings.Join(parts, ", ")
+}
+
+func bidName(b Bid) string {
+	if b.IsNoTrump {
+		return fmt.Sprintf("%d no-trump", b.Points)
+	}
+	return fmt.Sprintf("%d %s", b.Points, b.Suit)
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return "1 " + one
+	}
+	return fmt.Sprintf("%d %s", n, many)
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// biddingGame seats n players in PhaseBidding with p0 holding hand.
+func biddingGame(n int, hand []Card) *Game {
+	cfg := DefaultConfig()
+	cfg.NumPlayers = n
+	g := NewWithConfig("advice", cfg)
+	for i := range n {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i}
+	}
+
+	g.Status = PhaseBidding
+	g.Players[0].Hand = hand
+
+	return g
+}
+
+func parseHand(t *testing.T, text string) []Card {
+	t.Helper()
+
+	var hand []Card
+	suits := map[byte]Suit{'s': Spades, 'd': Diamonds, 'h': Hearts, 'c': Clubs}
+	for _, f := range strings.Fields(text) {
+		if f == "Joker" {
+			hand = append(hand, Card{Suit: None, Rank: Joker})
+			continue
+		}
+
+		hand = append(hand, Card{Suit: suits[f[0]], Rank: Rank(f[1:])})
+	}
+
+	return hand
+}
+
+func TestEvaluateHandCountsFeatures(t *testing.T) {
+	t.Parallel()
+
+	g := biddingGame(5, nil)
+	evals := g.EvaluateHand(parseHand(t, "sA dA Joker c3 hA hK hQ hJ h10 h9"))
+
+	byStrain := map[Suit]StrainEvaluation{}
+	for _, e := range evals {
+		byStrain[e.Suit] = e
+	}
+
+	hearts := byStrain[Hearts]
+	if !hearts.Mighty || !hearts.Joker || !hearts.JokerCaller || hearts.TrumpLength != 6 || hearts.TopTrumps != 2 || hearts.Aces != 1 {
+		t.Fatalf("hearts evaluation: %+v", hearts)
+	}
+
+	// With spades trump the Mighty moves to ♦A, so ♠A is an ordinary trump.
+	spades := byStrain[Spades]
+	if !spades.Mighty || spades.TrumpLength != 1 || spades.TopTrumps != 1 {
+		t.Fatalf("spades evaluation: %+v", spades)
+	}
+
+	if evals[0].Suit != Hearts {
+		t.Fatalf("hearts should be strongest, got %+v", evals[0])
+	}
+}
+
+func TestAdviseBid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		players int
+		hand    string
+		current *Bid
+		want    *Bid
+	}{
+		{name: "strong hearts", players: 5, hand: "sA Joker hA hK hQ hJ h10 h9 cA d2",
+			want: &Bid{Points: 9, Suit: Hearts}},
+		{name: "weak hand passes", players: 5, hand: "c2 c4 d3 d5 h2 h4 s5 s6 d7 h8"},
+		{name: "minimum five-player bid", players: 5, hand: "hA hK hQ h5 h6 cA d2 d4 c5 c6",
+			want: &Bid{Points: 3, Suit: Hearts}},
+		{name: "under the four-player minimum", players: 4, hand: "hA hK hQ h5 h6 cA d2 d4 c5 c6"},
+		{name: "cannot overcall", players: 5, hand: "hA hK hQ h5 h6 cA d2 d4 c5 c6",
+			current: &Bid{PlayerID: "p1", Points: 5, Suit: Spades}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := biddingGame(tt.players, parseHand(t, tt.hand))
+			g.CurrentBid = tt.current
+
+			advice, err := g.AdviseBid("p0")
+			if err != nil {
+				t.Fatalf("AdviseBid: %v", err)
+			}
+
+			if advice.Explanation == "" || len(advice.Strains) != 5 {
+				t.Fatalf("advice lacks its reasoning: %+v", advice)
+			}
+
+			if tt.want == nil {
+				if advice.Bid != nil || !strings.HasSuffix(advice.Explanation, "Pass.") {
+					t.Fatalf("expected a pass, got %+v", advice)
+				}
+				return
+			}
+
+			if advice.Bid == nil || advice.Bid.Points != tt.want.Points || advice.Bid.Suit != tt.want.Suit {
+				t.Fatalf("expected %+v, got %+v (%s)", tt.want, advice.Bid, advice.Explanation)
+			}
+
+			if err := g.validateBid(g.Players[0], *advice.Bid); err != nil {
+				t.Fatalf("advised bid is not legal: %v", err)
+			}
+		})
+	}
+}
+
+func TestAdviseBidOnlyWhileBidding(t *testing.T) {
+	t.Parallel()
+
+	g := biddingGame(5, nil)
+	g.Status = PhasePlaying
+
+	if _, err := g.AdviseBid("p0"); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("expected ErrInvalidMove outside bidding, got %v", err)
+	}
+
+	if _, err := g.AdviseBid("stranger"); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("expected ErrInvalidMove for an unseated player, got %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package game
+
+// BidOrder selects how two bids at the same level are ranked.
+type BidOrder string
+
+const (
+	// BidOrderPoints ranks bids by points alone; an overcall must bid higher.
+	BidOrderPoints BidOrder = "points"
+	// BidOrderNoTrump lets a no-trump bid overcall a suit bid at the same level.
+	BidOrderNoTrump BidOrder = "points_no_trump"
+	// BidOrderSuitRank also ranks suits at the same level
+	// (Clubs < Diamonds < Hearts < Spades), with no-trump above every suit.
+	BidOrderSuitRank BidOrder = "points_suit_no_trump"
+)
+
+// noTrumpRank places no-trump above every entry in suitRank.
+const noTrumpRank = 5
+
+// CompareBids returns a positive number when a outranks b, a negative number
+// when b outranks a, and zero when neither may overcall the other.
+func (g *Game) CompareBids(a, b Bid) int {
+	if a.Points != b.Points {
+		return a.Points - b.Points
+	}
+
+	switch g.Config.BidOrder {
+	case BidOrderNoTrump:
+		return boolRank(a.IsNoTrump) - boolRank(b.IsNoTrump)
+	case BidOrderSuitRank:
+		return bidSuitRank(a) - bidSuitRank(b)
+	default:
+		return 0
+	}
+}
+
+// isTopBid reports whether nothing can overcall bid, so bidding can end the
+// moment it is made.
+func (g *Game) isTopBid(bid Bid) bool {
+	if bid.Points < 10 {
+		return false
+	}
+
+	candidates := []Bid{{Points: 10, Suit: None, IsNoTrump: true}}
+	for s := range suitRank {
+		candidates = append(candidates, Bid{Points: 10, Suit: s})
+	}
+
+	for _, c := range candidates {
+		if g.CompareBids(c, bid) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func bidSuitRank(b Bid) int {
+	if b.IsNoTrump {
+		return noTrumpRank
+	}
+
+	return suitRank[b.Suit]
+}
+
+func boolRank(v bool) int {
+	if v {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCompareBidsByOrder(t *testing.T) {
+	t.Parallel()
+
+	clubs7 := Bid{Points: 7, Suit: Clubs}
+	spades7 := Bid{Points: 7, Suit: Spades}
+	hearts7 := Bid{Points: 7, Suit: Hearts}
+	nt7 := Bid{Points: 7, Suit: None, IsNoTrump: true}
+	clubs8 := Bid{Points: 8, Suit: Clubs}
+
+	tests := []struct {
+		order BidOrder
+		a, b  Bid
+		want  int // sign
+	}{
+		{BidOrderPoints, clubs8, nt7, 1},
+		{BidOrderPoints, spades7, clubs7, 0},
+		{BidOrderPoints, nt7, clubs7, 0},
+		{BidOrderNoTrump, nt7, spades7, 1},
+		{BidOrderNoTrump, spades7, clubs7, 0},
+		{BidOrderNoTrump, clubs8, nt7, 1},
+		{BidOrderSuitRank, spades7, hearts7, 1},
+		{BidOrderSuitRank, clubs7, hearts7, -1},
+		{BidOrderSuitRank, nt7, spades7, 1},
+		{BidOrderSuitRank, clubs8, nt7, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%v-%v", tt.order, tt.a, tt.b), func(t *testing.T) {
+			t.Parallel()
+
+			g := NewWithConfig("cmp", GameConfig{NumPlayers: 5, BidOrder: tt.order})
+			got := g.CompareBids(tt.a, tt.b)
+
+			if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+				t.Fatalf("CompareBids(%+v, %+v) = %d, want sign %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBidUsesBidOrder(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithConfig("order", GameConfig{NumPlayers: 5, BidOrder: BidOrderSuitRank})
+	g.Status = PhaseBidding
+	g.CurrentTurn = 0
+	g.Players[0] = &Player{ID: "P1", Seat: 0}
+	g.CurrentBid = &Bid{Points: 7, Suit: Hearts}
+
+	if err := g.ValidateMove("P1", MoveBid, Bid{Points: 7, Suit: Spades}); err != nil {
+		t.Fatalf("7 spades must overcall 7 hearts: %v", err)
+	}
+
+	if err := g.ValidateMove("P1", MoveBid, Bid{Points: 7, Suit: None, IsNoTrump: true}); err != nil {
+		t.Fatalf("7 no-trump must overcall 7 hearts: %v", err)
+	}
+
+	if err := g.ValidateMove("P1", MoveBid, Bid{Points: 7, Suit: Diamonds}); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("7 diamonds must not overcall 7 hearts, got %v", err)
+	}
+}
+
+func TestTenSuitBidStaysOpenWhenNoTrumpCanOvercall(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithConfig("ten", GameConfig{NumPlayers: 5, BidOrder: BidOrderNoTrump})
+	for i := range 5 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i}
+	}
+
+	g.Start()
+
+	if err := g.ApplyMove("p0", MoveBid, Bid{Points: 10, Suit: Spades}); err != nil {
+		t.Fatalf("10 spades: %v", err)
+	}
+
+	if g.Status != PhaseBidding || g.CurrentTurn != 1 {
+		t.Fatalf("10 spades must not close the auction when 10 NT can overcall: status=%s turn=%d", g.Status, g.CurrentTurn)
+	}
+
+	nt := Bid{Points: 10, Suit: None, IsNoTrump: true}
+	if err := g.ValidateMove("p1", MoveBid, nt); err != nil {
+		t.Fatalf("10 NT must overcall 10 spades: %v", err)
+	}
+
+	if err := g.ApplyMove("p1", MoveBid, nt); err != nil {
+		t.Fatalf("10 NT: %v", err)
+	}
+
+	if g.Status != PhaseExchanging || g.Declarer != 1 {
+		t.Fatalf("10 NT is unbeatable and must close the auction: status=%s declarer=%d", g.Status, g.Declarer)
+	}
+}
@@ -2,10 +2,7 @@
 // card definitions, game state management, and rules enforcement.
 package game
 
-import (
-	"fmt"
-	"math/rand"
-)
+import "fmt"
 
 // Suit represents the card suit.
 type Suit string
@@ -100,8 +97,9 @@ func NewDeck() Deck {
 	return NewDeckFor(5)
 }
 
-// NewDeckFor builds the deck for the given player count: 53 cards for five
-// players, or 43 for four players (all 2s, all 4s, and the two red 3s removed).
+// NewDeckFor builds the deck for the given player count: 53 cards for five or
+// six players, or 43 for four players (all 2s, all 4s, and the two red 3s
+// removed).
 func NewDeckFor(numPlayers int) Deck {
 	suits := []Suit{Spades, Diamonds, Hearts, Clubs}
 	ranks := []Rank{Ace, King, Queen, Jack, Ten, Nine, Eight, Seven, Six, Five, Four, Three, Two}
@@ -124,17 +122,35 @@ func NewDeckFor(numPlayers int) Deck {
 	return deck
 }
 
-// Shuffle shuffles the deck.
+// Shuffle shuffles the deck from a fresh CSPRNG seed.
 func (d Deck) Shuffle() {
-	rand.Shuffle(len(d), func(i, j int) {
-		d[i], d[j] = d[j], d[i]
-	})
+	d.ShuffleSeeded(NewSeed())
+}
+
+// handSizeFor is the number of cards dealt to each player: 8 with six players,
+// 10 otherwise.
+func handSizeFor(numPlayers int) int {
+	if numPlayers == 6 {
+		return 8
+	}
+	return 10
+}
+
+// kittySizeFor is the number of cards dealt to the kitty: 5 with six players, 3
+// otherwise.
+func kittySizeFor(numPlayers int) int {
+	if numPlayers == 6 {
+		return 5
+	}
+	return 3
 }
 
-// Deal distributes 10 cards to each of numPlayers players and 3 to the kitty.
+// Deal distributes handSizeFor cards to each of numPlayers players and the
+// remaining kittySizeFor cards to the kitty.
 // Returns the hands (one slice per player) and the kitty.
 func (d Deck) Deal(numPlayers int) ([][]Card, []Card) {
-	expected := numPlayers*10 + 3
+	handSize := handSizeFor(numPlayers)
+	expected := numPlayers*handSize + kittySizeFor(numPlayers)
 	if len(d) != expected {
 		return nil, nil
 	}
@@ -142,14 +158,14 @@ func (d Deck) Deal(numPlayers int) ([][]Card, []Card) {
 	hands := make([][]Card, numPlayers)
 	k := 0
 	for i := 0; i < numPlayers; i++ {
-		hands[i] = make([]Card, 10)
-		for j := 0; j < 10; j++ {
+		hands[i] = make([]Card, handSize)
+		for j := 0; j < handSize; j++ {
 			hands[i][j] = d[k]
 			k++
 		}
 	}
 
-	kitty := make([]Card, 3)
+	kitty := make([]Card, len(d)-k)
 	copy(kitty, d[k:])
 	return hands, kitty
 }
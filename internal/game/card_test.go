@@ -65,3 +65,15 @@ func TestDealFivePlayer(t *testing.T) {
 		t.Fatalf("five-player deal shape wrong: %d hands, %d kitty", len(hands), len(kitty))
 	}
 }
+
+func TestDealSixPlayer(t *testing.T) {
+	hands, kitty := NewDeckFor(6).Deal(6)
+	if len(hands) != 6 || len(kitty) != 5 {
+		t.Fatalf("six-player deal shape wrong: %d hands, %d kitty", len(hands), len(kitty))
+	}
+	for i, h := range hands {
+		if len(h) != 8 {
+			t.Errorf("hand %d: got %d cards, want 8", i, len(h))
+		}
+	}
+}
@@ -0,0 +1,630 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// claimNodeBudget caps the plays a claim check may try. A claim that needs
+// more is rejected as unverifiable rather than stalling the request.
+const claimNodeBudget = 200_000
+
+// ClaimMove is the payload for a claim: the number of remaining tricks the
+// claimant guarantees to win, or 0 for all of them.
+type ClaimMove struct {
+	Tricks int `json:"tricks,omitempty"`
+}
+
+// Claim records a verified claim and the hand the claimant showed.
+type Claim struct {
+	PlayerID  string `json:"player_id"`
+	Seat      int    `json:"seat"`
+	Tricks    int    `json:"tricks"`    // tricks claimed
+	Remaining int    `json:"remaining"` // tricks left when the claim was made
+	Hand      []Card `json:"hand"`
+}
+
+// ClaimRejectedError is returned for a claim that is not guaranteed. Line is
+// a play-out, consistent with every card the claimant could not see, on which
+// the claimant wins fewer tricks than claimed.
+type ClaimRejectedError struct {
+	Tricks int
+	Line   []PlayedCard
+}
+
+func (e *ClaimRejectedError) Error() string {
+	plays := make([]string, len(e.Line))
+	for i, pc := range e.Line {
+		plays[i] = fmt.Sprintf("%s %s", pc.PlayerID, pc.Card)
+	}
+
+	return fmt.Sprintf("%s: claim of %d tricks is not guaranteed, counter-line: %s", ErrInvalidMove, e.Tricks, strings.Join(plays, ", "))
+}
+
+// Unwrap makes a rejected claim an ErrInvalidMove.
+func (e *ClaimRejectedError) Unwrap() error { return ErrInvalidMove }
+
+// asClaimMove accepts a ClaimMove or no payload, which claims every trick.
+func asClaimMove(payload any) (ClaimMove, error) {
+	switch v := payload.(type) {
+	case ClaimMove:
+		return v, nil
+	case nil:
+		return ClaimMove{}, nil
+	default:
+		return ClaimMove{}, fmt.Errorf("%w: invalid payload for claim", ErrInvalidMove)
+	}
+}
+
+// validateClaim allows any active player to claim at a trick boundary, then
+// checks the claim holds however the other hands are dealt and played.
+func (g *Game) validateClaim(p *Player, payload any) error {
+	if g.Status != PhasePlaying {
+		return fmt.Errorf("%w: not in playing phase", ErrInvalidMove)
+	}
+
+	if g.isEliminated(p.Seat) {
+		return fmt.Errorf("%w: seat %d is sitting out this hand", ErrInvalidMove, p.Seat)
+	}
+
+	move, err := asClaimMove(payload)
+	if err != nil {
+		return err
+	}
+
+	if len(g.Tricks) == 0 || len(g.Tricks[len(g.Tricks)-1].Cards) != 0 {
+		return fmt.Errorf("%w: claims are made between tricks", ErrInvalidMove)
+	}
+
+	remaining := len(p.Hand)
+	if move.Tricks < 0 || move.Tricks > remaining {
+		return fmt.Errorf("%w: cannot claim %d of %d remaining tricks", ErrInvalidMove, move.Tricks, remaining)
+	}
+
+	need := move.Tricks
+	if need == 0 {
+		need = remaining
+	}
+
+	s := newClaimSearch(g, p, need)
+	ok, line := s.turn(g.CurrentTurn)
+
+	switch {
+	case s.nodes > claimNodeBudget:
+		return fmt.Errorf("%w: claim is too complex to verify, play on", ErrInvalidMove)
+	case !ok:
+		return &ClaimRejectedError{Tricks: need, Line: line}
+	}
+
+	return nil
+}
+
+// applyClaim ends the hand on a verified claim by playing out the verified
+// line on the actual hands: the claimant keeps to plays the claim check
+// proves still make the claim, within the check's node budget, and otherwise
+// plays their strongest card; the other side contests every trick it can,
+// and the claimant's partners hold back. Tricks and point cards then fall as
+// they would in that play, so a partial claim concedes the tricks it leaves
+// out without handing over the partners' point cards, and a full claim takes
+// every point card still in play.
+func (g *Game) applyClaim(p *Player, move ClaimMove) {
+	remaining := len(p.Hand)
+	claimed := move.Tricks
+	if claimed == 0 {
+		claimed = remaining
+	}
+
+	g.Claim = &Claim{PlayerID: p.ID, Seat: p.Seat, Tricks: claimed, Remaining: remaining, Hand: slices.Clone(p.Hand)}
+
+	g.revealFriend()
+
+	for _, play := range newClaimSearch(g, p, claimed).verifiedLine(g) {
+		g.playCard(g.Players[play.Seat], play.Move)
+	}
+}
+
+// revealFriend names the called friend when a hand ends before they have
+// shown themselves. A first-trick friend is known as soon as trick 1 ends.
+func (g *Game) revealFriend() {
+	if g.PartnerSeat < 0 && g.FriendMode != FriendFirstTrick {
+		if fs := g.friendSeat(); fs >= 0 && fs != g.Declarer {
+			g.PartnerSeat = fs
+		}
+	}
+}
+
+// claimGroups are the groups a seat can be shown void in; the Joker, in its
+// own group, can always be played.
+var claimGroups = []Suit{Spades, Diamonds, Hearts, Clubs, None}
+
+func claimGroup(c Card) int {
+	if c.Rank == Joker {
+		return len(claimGroups) - 1
+	}
+
+	return slices.Index(claimGroups, c.Suit)
+}
+
+// claimSearch plays out the rest of a hand on a scratch copy of the game.
+// The claimant plays only legal cards from their own hand; every other seat
+// may play any card the claimant has not seen, as long as the unseen cards
+// could still be dealt to those seats and the kitty given how many each
+// holds and which suits each seat has shown void in; the kitty never plays.
+// Other rules that would restrict the defence are ignored, so the search can
+// only err towards rejecting a claim.
+type claimSearch struct {
+	g        *Game
+	claimant *Player
+	need     int
+	wins     int
+	left     int
+	unseen   []Card
+	counts   []int // unseen cards left per seat
+	buried   int   // unseen cards in the kitty
+	voids    []int // bitmask over claimGroups per seat
+	nodes    int
+	seen     map[string]claimResult
+}
+
+func newClaimSearch(g *Game, p *Player, need int) *claimSearch {
+	sim := *g
+	sim.Players = slices.Clone(g.Players)
+	claimant := *p
+	claimant.Hand = slices.Clone(p.Hand)
+	sim.Players[p.Seat] = &claimant
+	sim.Tricks = slices.Clone(g.Tricks)
+	sim.Tricks[len(sim.Tricks)-1] = Trick{Cards: []PlayedCard{}}
+
+	s := &claimSearch{
+		g:        &sim,
+		claimant: &claimant,
+		need:     need,
+		left:     len(p.Hand),
+		counts:   make([]int, len(g.Players)),
+		voids:    make([]int, len(g.Players)),
+		seen:     make(map[string]claimResult),
+	}
+
+	// The claimant has seen their own hand, the cards played and, as
+	// declarer, the discard. Any other card may be with a seat still holding
+	// cards or buried in the kitty.
+	seen := slices.Clone(p.Hand)
+	for _, t := range g.Tricks {
+		for _, pc := range t.Cards {
+			seen = append(seen, pc.Card)
+		}
+	}
+	if p.Seat == g.Declarer {
+		seen = append(seen, g.Kitty...)
+	}
+
+	for _, c := range NewDeckFor(g.numSeats()) {
+		if !slices.Contains(seen, c) {
+			s.unseen = append(s.unseen, c)
+		}
+	}
+
+	s.buried = len(s.unseen)
+	for seat, other := range g.Players {
+		if other == nil || seat == p.Seat || g.isEliminated(seat) {
+			continue
+		}
+		s.counts[seat] = len(other.Hand)
+		s.buried -= len(other.Hand)
+	}
+
+	return s
+}
+
+// turn plays the card for seat and everything after it. It reports whether
+// the claimant still reaches need wins, and when not, the line that stops it.
+func (s *claimSearch) turn(seat int) (bool, []PlayedCard) {
+	idx := len(s.g.Tricks) - 1
+	if len(s.g.Tricks[idx].Cards) == s.g.numActive() {
+		return s.endTrick()
+	}
+
+	if s.nodes > claimNodeBudget {
+		return true, nil
+	}
+
+	if seat == s.claimant.Seat {
+		return s.claimantTurn(idx, seat)
+	}
+
+	return s.defenceTurn(idx, seat)
+}
+
+// claimantTurn succeeds if any legal play keeps the claim alive. On failure
+// it reports the refutation of the strongest play.
+func (s *claimSearch) claimantTurn(idx, seat int) (bool, []PlayedCard) {
+	var refutation []PlayedCard
+
+	for _, move := range s.playVariants(s.g.Tricks[idx], s.claimant.Hand) {
+		s.g.CurrentTurn = seat
+		if s.g.validatePlayCard(s.claimant, move) != nil {
+			continue
+		}
+
+		s.nodes++
+		pc := PlayedCard{PlayerID: s.claimant.ID, Seat: seat, Card: move.Card}
+		hand := s.claimant.Hand
+		s.claimant.Hand = removeCard(hand, move.Card)
+		saved := s.g.Tricks[idx]
+		s.g.addToTrick(&s.g.Tricks[idx], pc, move)
+
+		ok, line := s.turn(s.g.nextSeat(seat))
+
+		s.g.Tricks[idx] = saved
+		s.claimant.Hand = hand
+
+		if ok {
+			return true, nil
+		}
+		if refutation == nil {
+			refutation = append([]PlayedCard{pc}, line...)
+		}
+	}
+
+	return false, refutation
+}
+
+// defenceTurn fails the claim if any card seat could hold defeats it.
+func (s *claimSearch) defenceTurn(idx, seat int) (bool, []PlayedCard) {
+	t := s.g.Tricks[idx]
+
+	var candidates []Card
+	for _, c := range s.unseen {
+		if s.voids[seat]&(1<<claimGroup(c)) == 0 {
+			candidates = append(candidates, c)
+		}
+	}
+
+	// In the last trick, once the claimant has played, one card that leaves
+	// them the trick is as good as any other.
+	settled := s.left == 1 && slices.ContainsFunc(t.Cards, func(pc PlayedCard) bool { return pc.Seat == s.claimant.Seat })
+	conceded := false
+
+	for _, move := range s.playVariants(t, candidates) {
+		concedes := false
+		if settled {
+			trial := t
+			trial.Cards = append(slices.Clone(t.Cards), PlayedCard{Seat: seat, Card: move.Card})
+			winner, _ := s.g.ResolveTrick(trial)
+			concedes = winner == s.claimant.Seat
+		}
+		if concedes && conceded {
+			continue
+		}
+
+		s.nodes++
+		if s.nodes > claimNodeBudget {
+			return true, nil
+		}
+
+		voids := s.voids[seat]
+		if len(t.Cards) > 0 && move.Card.Suit != t.LeadSuit && move.Card.Rank != Joker && !s.g.IsMighty(move.Card) {
+			if grp := slices.Index(claimGroups, t.LeadSuit); grp >= 0 {
+				s.voids[seat] |= 1 << grp
+			}
+		}
+
+		unseen := s.unseen
+		s.unseen = removeCard(unseen, move.Card)
+		s.counts[seat]--
+
+		if !s.dealable() {
+			s.unseen = unseen
+			s.counts[seat]++
+			s.voids[seat] = voids
+			continue
+		}
+
+		conceded = conceded || concedes
+
+		pc := PlayedCard{PlayerID: s.g.Players[seat].ID, Seat: seat, Card: move.Card}
+		s.g.addToTrick(&s.g.Tricks[idx], pc, move)
+
+		ok, line := s.turn(s.g.nextSeat(seat))
+
+		s.g.Tricks[idx] = t
+		s.unseen = unseen
+		s.counts[seat]++
+		s.voids[seat] = voids
+
+		if !ok {
+			return false, append([]PlayedCard{pc}, line...)
+		}
+	}
+
+	return true, nil
+}
+
+// endTrick scores the finished trick and opens the next one.
+func (s *claimSearch) endTrick() (bool, []PlayedCard) {
+	t := s.g.Tricks[len(s.g.Tricks)-1]
+	winner, _ := s.g.ResolveTrick(t)
+
+	won := 0
+	if winner == s.claimant.Seat {
+		won = 1
+	}
+
+	s.wins += won
+	s.left--
+	defer func() {
+		s.wins -= won
+		s.left++
+	}()
+
+	switch {
+	case s.wins >= s.need:
+		return true, nil
+	case s.wins+s.left < s.need || s.left == 0:
+		return false, nil
+	}
+
+	// Many orders of play reach the same position at the end of a trick, so
+	// each is searched once.
+	key := s.position(winner)
+	if r, ok := s.seen[key]; ok {
+		return r.ok, r.line
+	}
+
+	s.g.Tricks = append(s.g.Tricks, Trick{Cards: []PlayedCard{}})
+	defer func() { s.g.Tricks = s.g.Tricks[:len(s.g.Tricks)-1] }()
+
+	ok, line := s.turn(winner)
+	s.seen[key] = claimResult{ok: ok, line: line}
+
+	return ok, line
+}
+
+// claimResult is the outcome of searching a position: whether the claim
+// holds from it, and the line that stops it when not.
+type claimResult struct {
+	ok   bool
+	line []PlayedCard
+}
+
+// position identifies the position at the end of a trick that leader is to
+// lead next from: everything the rest of the search depends on. The unseen
+// cards and the claimant's hand only ever lose cards, so a set of cards is
+// always listed in the same order.
+func (s *claimSearch) position(leader int) string {
+	var b strings.Builder
+	b.Write([]byte{byte(len(s.g.Tricks)), byte(leader), byte(s.wins), byte(s.left)})
+	for seat := range s.counts {
+		b.Write([]byte{byte(s.counts[seat]), byte(s.voids[seat])})
+	}
+
+	for _, cards := range [][]Card{s.claimant.Hand, s.unseen} {
+		b.WriteByte('|')
+		for _, c := range cards {
+			b.WriteString(string(c.Suit))
+			b.WriteByte(' ')
+			b.WriteString(string(c.Rank))
+			b.WriteByte(',')
+		}
+	}
+
+	return b.String()
+}
+
+// claimPlay is one play of a claim's verified line.
+type claimPlay struct {
+	Seat int
+	Move PlayCardMove
+}
+
+// verifiedLine plays the rest of the hand on g's actual hands, from the
+// position the search was built at. At each of the claimant's turns it takes
+// the strongest play claimantPlay can prove still makes the claim. Every
+// other seat plays as otherPlay picks.
+func (s *claimSearch) verifiedLine(g *Game) []claimPlay {
+	hands := make([][]Card, len(g.Players))
+	for seat, p := range g.Players {
+		if p != nil {
+			hands[seat] = slices.Clone(p.Hand)
+		}
+	}
+
+	var line []claimPlay
+
+	seat := s.g.CurrentTurn
+	for s.left > 0 {
+		idx := len(s.g.Tricks) - 1
+		if len(s.g.Tricks[idx].Cards) == s.g.numActive() {
+			winner, _ := s.g.ResolveTrick(s.g.Tricks[idx])
+			if winner == s.claimant.Seat {
+				s.wins++
+			}
+
+			s.left--
+			s.g.Tricks = append(s.g.Tricks, Trick{Cards: []PlayedCard{}})
+			seat = winner
+
+			continue
+		}
+
+		s.g.CurrentTurn = seat
+		t := s.g.Tricks[idx]
+
+		var play PlayCardMove
+		if seat == s.claimant.Seat {
+			play = s.claimantPlay(idx, seat)
+			s.claimant.Hand = removeCard(s.claimant.Hand, play.Card)
+		} else {
+			play = s.otherPlay(idx, seat, hands[seat])
+
+			if len(t.Cards) > 0 && play.Card.Suit != t.LeadSuit && play.Card.Rank != Joker && !s.g.IsMighty(play.Card) {
+				if grp := slices.Index(claimGroups, t.LeadSuit); grp >= 0 {
+					s.voids[seat] |= 1 << grp
+				}
+			}
+
+			hands[seat] = removeCard(hands[seat], play.Card)
+			s.unseen = removeCard(s.unseen, play.Card)
+			s.counts[seat]--
+		}
+
+		s.g.addToTrick(&s.g.Tricks[idx], PlayedCard{PlayerID: s.g.Players[seat].ID, Seat: seat, Card: play.Card}, play)
+		line = append(line, claimPlay{Seat: seat, Move: play})
+		seat = s.g.nextSeat(seat)
+	}
+
+	return line
+}
+
+// otherPlay picks the play of a seat other than the claimant's. A seat on the
+// other side leads its strongest card and otherwise takes the trick as
+// cheaply as it can unless its own side already holds it; a partner of the
+// claimant plays its weakest card.
+func (s *claimSearch) otherPlay(idx, seat int, hand []Card) PlayCardMove {
+	t := s.g.Tricks[idx]
+	player := &Player{ID: s.g.Players[seat].ID, Seat: seat, Hand: hand}
+
+	var legal []PlayCardMove
+	for _, move := range s.playVariants(t, slices.Clone(hand)) {
+		if s.g.validatePlayCard(player, move) == nil {
+			legal = append(legal, move)
+		}
+	}
+
+	weakest := legal[len(legal)-1]
+	if s.g.sameSide(seat, s.claimant.Seat) {
+		return weakest
+	}
+
+	if len(t.Cards) == 0 {
+		return legal[0]
+	}
+
+	if leader, _ := s.g.ResolveTrick(t); !s.g.sameSide(leader, s.claimant.Seat) {
+		return weakest
+	}
+
+	for i := len(legal) - 1; i >= 0; i-- {
+		trial := t
+		trial.Cards = append(slices.Clone(t.Cards), PlayedCard{Seat: seat, Card: legal[i].Card})
+		if winner, _ := s.g.ResolveTrick(trial); winner == seat {
+			return legal[i]
+		}
+	}
+
+	return weakest
+}
+
+// sameSide reports whether two seats play on the same side of the hand: the
+// declarer and the friend, or the defence.
+func (g *Game) sameSide(a, b int) bool {
+	fs := g.friendSeat()
+
+	return (a == g.Declarer || a == fs) == (b == g.Declarer || b == fs)
+}
+
+// claimantPlay returns the claimant's first legal play into trick idx after
+// which every continuation still makes the claim. The checks share one node
+// budget for the whole line; once it is spent, no play counts as proven and
+// the claimant plays their strongest legal card.
+func (s *claimSearch) claimantPlay(idx, seat int) PlayCardMove {
+	var fallback *PlayCardMove
+
+	for _, move := range s.playVariants(s.g.Tricks[idx], s.claimant.Hand) {
+		if s.g.validatePlayCard(s.claimant, move) != nil {
+			continue
+		}
+
+		if fallback == nil {
+			fallback = &move
+		}
+
+		hand := s.claimant.Hand
+		s.claimant.Hand = removeCard(hand, move.Card)
+		saved := s.g.Tricks[idx]
+		s.g.addToTrick(&s.g.Tricks[idx], PlayedCard{PlayerID: s.claimant.ID, Seat: seat, Card: move.Card}, move)
+
+		ok, _ := s.turn(s.g.nextSeat(seat))
+
+		s.g.Tricks[idx] = saved
+		s.claimant.Hand = hand
+		s.g.CurrentTurn = seat
+
+		if ok && s.nodes <= claimNodeBudget {
+			return move
+		}
+	}
+
+	return *fallback
+}
+
+// playVariants lists the plays of cards into t, strongest first. A lead of
+// the Joker names each suit, and a lead of the Joker Caller may call.
+func (s *claimSearch) playVariants(t Trick, cards []Card) []PlayCardMove {
+	var moves []PlayCardMove
+	for _, c := range cards {
+		switch {
+		case len(t.Cards) > 0:
+			moves = append(moves, PlayCardMove{Card: c})
+		case c.Rank == Joker:
+			for _, suit := range bidSuits {
+				moves = append(moves, PlayCardMove{Card: c, CalledSuit: suit})
+			}
+		case s.g.IsJokerCaller(c) && s.g.rules().jokerCallAllowed(len(s.g.Tricks)):
+			moves = append(moves, PlayCardMove{Card: c, CallJoker: true}, PlayCardMove{Card: c})
+		default:
+			moves = append(moves, PlayCardMove{Card: c})
+		}
+	}
+
+	trickNum := len(s.g.Tricks)
+	slices.SortStableFunc(moves, func(a, b PlayCardMove) int {
+		return cmp.Compare(s.g.CalculatePower(b.Card, t, trickNum), s.g.CalculatePower(a.Card, t, trickNum))
+	})
+
+	return moves
+}
+
+// dealable reports whether the unseen cards can still be split among the
+// other seats and the kitty: by Hall's theorem, every set of suit groups
+// must fit in the kitty and the seats not void in all of them.
+func (s *claimSearch) dealable() bool {
+	var inGroup [5]int
+	for _, c := range s.unseen {
+		inGroup[claimGroup(c)]++
+	}
+
+	for mask := 1; mask < 1<<len(claimGroups); mask++ {
+		cards := 0
+		for g := range claimGroups {
+			if mask&(1<<g) != 0 {
+				cards += inGroup[g]
+			}
+		}
+
+		room := s.buried
+		for seat, n := range s.counts {
+			if s.voids[seat]&mask != mask {
+				room += n
+			}
+		}
+
+		if cards > room {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeCard returns cards without the first copy of c, leaving cards intact.
+func removeCard(cards []Card, c Card) []Card {
+	i := slices.Index(cards, c)
+	if i < 0 {
+		return cards
+	}
+
+	return slices.Concat(cards[:i], cards[i+1:])
+}
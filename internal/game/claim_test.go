@@ -0,0 +1,276 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// claimFixture is callingGame two tricks from the end of the hand, with the
+// Joker buried in the kitty. p1 holds the called ♥K. Every card not in a
+// hand or the kitty was played in the first eight tricks.
+func claimFixture(turn int, hands ...[]Card) *Game {
+	g := callingGame()
+	g.Status = PhasePlaying
+	g.PartnerCard = &Card{Suit: Hearts, Rank: King}
+	g.Tricks = make([]Trick, 9)
+	g.Tricks[8] = Trick{Cards: []PlayedCard{}}
+	g.CurrentTurn = turn
+
+	for seat, hand := range hands {
+		g.Players[seat].Hand = hand
+	}
+
+	var rest []Card
+	for _, c := range NewDeck() {
+		if c.Rank != Joker && !slices.ContainsFunc(hands, func(h []Card) bool { return slices.Contains(h, c) }) {
+			rest = append(rest, c)
+		}
+	}
+
+	g.Kitty = append([]Card{{Suit: None, Rank: Joker}}, rest[:2]...)
+	rest = rest[2:]
+
+	for i := range 8 {
+		g.Tricks[i] = Trick{Cards: []PlayedCard{}}
+		for seat, p := range g.Players {
+			g.Tricks[i].Cards = append(g.Tricks[i].Cards, PlayedCard{PlayerID: p.ID, Seat: seat, Card: rest[5*i+seat]})
+		}
+	}
+
+	return g
+}
+
+func claimHands(declarer []Card) [][]Card {
+	return [][]Card{
+		declarer,
+		{{Suit: Hearts, Rank: King}, {Suit: Clubs, Rank: Two}},
+		{{Suit: Spades, Rank: King}, {Suit: Hearts, Rank: Ten}},
+		{{Suit: Clubs, Rank: Ace}, {Suit: Hearts, Rank: Three}},
+		{{Suit: Diamonds, Rank: King}, {Suit: Clubs, Rank: Four}},
+	}
+}
+
+func TestClaimWithTopCardsEndsTheHand(t *testing.T) {
+	t.Parallel()
+
+	// p0 holds the Mighty and the ♠A; p2 is on lead but cannot stop either.
+	g := claimFixture(2, claimHands([]Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Ace}})...)
+
+	if err := g.ApplyMove("p0", MoveClaim, ClaimMove{}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Status != PhaseFinished {
+		t.Fatalf("expected finished, got %s", g.Status)
+	}
+
+	if g.Claim == nil || g.Claim.Tricks != 2 || g.Claim.Remaining != 2 || len(g.Claim.Hand) != 2 {
+		t.Fatalf("claim not recorded: %+v", g.Claim)
+	}
+
+	if g.PartnerSeat != 1 {
+		t.Fatalf("holder of the called card must be revealed, got seat %d", g.PartnerSeat)
+	}
+
+	// ♦A, ♠A, ♥K, ♠K, ♥10, ♣A and ♦K all go to the claimant.
+	if n := len(g.Players[0].Points); n != 7 {
+		t.Fatalf("claimant should take every point card in play, got %d: %v", n, g.Players[0].Points)
+	}
+
+	if g.ScoreHistory == nil || len(g.Scores) == 0 {
+		t.Fatal("a claimed hand must be scored")
+	}
+}
+
+func TestClaimRejectedWithCounterLine(t *testing.T) {
+	t.Parallel()
+
+	// p2's ♠K beats both of p0's trumps.
+	g := claimFixture(0, claimHands([]Card{{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}})...)
+
+	err := g.ValidateMove("p0", MoveClaim, ClaimMove{})
+
+	var rejected *ClaimRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("expected ClaimRejectedError, got %v", err)
+	}
+
+	if !errors.Is(err, ErrInvalidMove) {
+		t.Fatal("a rejected claim must be an ErrInvalidMove")
+	}
+
+	if len(rejected.Line) == 0 || !slices.ContainsFunc(rejected.Line, func(pc PlayedCard) bool {
+		return pc.Card == Card{Suit: Spades, Rank: King}
+	}) {
+		t.Fatalf("counter-line should show the ♠K winning a trick: %v", rejected.Line)
+	}
+
+	if g.Status != PhasePlaying || g.Claim != nil {
+		t.Fatal("validation must not change the game")
+	}
+}
+
+func TestClaimCountsTheKittyAsUnseen(t *testing.T) {
+	t.Parallel()
+
+	// The ♠A is buried with the Joker. The Mighty and the ♠K would be safe for
+	// the declarer, who buried it, but p2 cannot tell the ♠A is not in an
+	// opponent's hand.
+	hands := claimHands([]Card{{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}})
+	hands[2] = []Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: King}}
+	g := claimFixture(2, hands...)
+
+	if !slices.Contains(g.Kitty, Card{Suit: Spades, Rank: Ace}) {
+		t.Fatalf("expected the ♠A in the kitty, got %v", g.Kitty)
+	}
+
+	var rejected *ClaimRejectedError
+	if err := g.ValidateMove("p2", MoveClaim, ClaimMove{}); !errors.As(err, &rejected) {
+		t.Fatalf("expected ClaimRejectedError, got %v", err)
+	}
+
+	if !slices.ContainsFunc(rejected.Line, func(pc PlayedCard) bool { return pc.Card == Card{Suit: Spades, Rank: Ace} }) {
+		t.Fatalf("counter-line should have the ♠A beat the ♠K: %v", rejected.Line)
+	}
+
+	if err := g.ValidateMove("p2", MoveClaim, ClaimMove{Tricks: 1}); err != nil {
+		t.Fatalf("the Mighty alone is a trick: %v", err)
+	}
+}
+
+func TestPartialClaim(t *testing.T) {
+	t.Parallel()
+
+	g := claimFixture(0, claimHands([]Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Queen}})...)
+
+	if err := g.ValidateMove("p0", MoveClaim, ClaimMove{}); err == nil {
+		t.Fatal("claiming both tricks should fail against the ♠K")
+	}
+
+	if err := g.ValidateMove("p0", MoveClaim, ClaimMove{Tricks: 1}); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	if err := g.ApplyMove("p0", MoveClaim, ClaimMove{Tricks: 1}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Status != PhaseFinished || g.Claim == nil || g.Claim.Tricks != 1 || g.Claim.Remaining != 2 {
+		t.Fatalf("expected a finished hand with the claim recorded, got %s %+v", g.Status, g.Claim)
+	}
+
+	// The Mighty takes the first trick. The defence then holds back nothing:
+	// the friend, down to the ♥K, must drop it under the ♠K.
+	if n := len(g.Players[0].Points); n != 3 {
+		t.Fatalf("claimant takes ♦A, ♥10 and ♦K, got %v", g.Players[0].Points)
+	}
+
+	if n := len(g.Players[2].Points); n != 4 {
+		t.Fatalf("the defence takes ♠Q, ♥K, ♠K and ♣A, got %v", g.Players[2].Points)
+	}
+
+	assertClaimMatchesPlayOut(t, g, claimFixture(0, claimHands([]Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Queen}})...))
+
+	// A defender's partial claim is played out the same way: p2 cashes the
+	// Mighty and concedes the last trick to the declarer's trumps.
+	defenderClaim := func() *Game {
+		hands := claimHands([]Card{{Suit: Spades, Rank: Queen}, {Suit: Spades, Rank: Jack}})
+		hands[2] = []Card{{Suit: Diamonds, Rank: Ace}, {Suit: Clubs, Rank: Three}}
+
+		return claimFixture(2, hands...)
+	}
+
+	g = defenderClaim()
+	if err := g.ValidateMove("p2", MoveClaim, ClaimMove{Tricks: 1}); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	if err := g.ApplyMove("p2", MoveClaim, ClaimMove{Tricks: 1}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Tricks[8].Winner != 2 || g.Tricks[9].Winner != 0 {
+		t.Fatalf("expected p2 to take one trick and the declarer the other, got %+v", g.Tricks[8:])
+	}
+
+	assertClaimMatchesPlayOut(t, g, defenderClaim())
+
+}
+
+// assertClaimMatchesPlayOut plays the tricks a claim finished g with as
+// ordinary moves on fresh, the same position before the claim, and checks
+// the two hands score alike. The claim fixtures keep the Joker out of play,
+// so no lead needs a called suit.
+func assertClaimMatchesPlayOut(t *testing.T, g, fresh *Game) {
+	t.Helper()
+
+	won := 0
+	for _, trick := range g.Tricks[len(fresh.Tricks)-1:] {
+		if trick.Winner == g.Claim.Seat {
+			won++
+		}
+
+		for _, pc := range trick.Cards {
+			if err := fresh.ApplyMove(pc.PlayerID, MovePlayCard, PlayCardMove{Card: pc.Card}); err != nil {
+				t.Fatalf("play %s %s: %v", pc.PlayerID, pc.Card, err)
+			}
+		}
+	}
+
+	if won < g.Claim.Tricks {
+		t.Fatalf("the claimant won %d tricks of the %d claimed", won, g.Claim.Tricks)
+	}
+
+	if fresh.Status != PhaseFinished {
+		t.Fatalf("the play-out did not finish the hand, got %s", fresh.Status)
+	}
+
+	if fmt.Sprint(fresh.Scores) != fmt.Sprint(g.Scores) {
+		t.Fatalf("claim scored %v, playing it out scores %v", g.Scores, fresh.Scores)
+	}
+}
+
+func TestValidateClaimRejects(t *testing.T) {
+	t.Parallel()
+
+	top := []Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Ace}}
+
+	tests := []struct {
+		name    string
+		payload any
+		setup   func(g *Game)
+	}{
+		{name: "mid-trick", payload: ClaimMove{}, setup: func(g *Game) {
+			g.Tricks[8].Cards = []PlayedCard{{PlayerID: "p2", Seat: 2, Card: Card{Suit: Hearts, Rank: Ten}}}
+			g.Players[2].Hand = g.Players[2].Hand[:1]
+			g.CurrentTurn = 3
+		}},
+		{name: "more tricks than left", payload: ClaimMove{Tricks: 3}},
+		{name: "negative tricks", payload: ClaimMove{Tricks: -1}},
+		{name: "not playing", payload: ClaimMove{}, setup: func(g *Game) { g.Status = PhaseCalling }},
+		{name: "wrong payload", payload: Card{Suit: Spades, Rank: Ace}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := claimFixture(2, claimHands(slices.Clone(top))...)
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			err := g.ValidateMove("p0", MoveClaim, tt.payload)
+			if !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+
+			var rejected *ClaimRejectedError
+			if errors.As(err, &rejected) {
+				t.Fatalf("expected a plain rejection, got counter-line %v", rejected.Line)
+			}
+		})
+	}
+}
@@ -13,19 +13,63 @@ const (
 	FailTwoOneSplit FailDist = "two_one_split"
 )
 
-// GameConfig captures every difference between the four- and five-player games.
+// DealMissConfig sets when a player may throw in a weak hand. Each A, K, Q,
+// J and 10 counts PointWeight, except that the Mighty and the Joker count
+// their own weights; a hand valued at or below Threshold may be thrown in.
+type DealMissConfig struct {
+	Threshold    int `json:"threshold"`
+	PointWeight  int `json:"point_weight"`
+	MightyWeight int `json:"mighty_weight"`
+	JokerWeight  int `json:"joker_weight"`
+}
+
+// DefaultDealMiss is the common table rule: at most one scoring card, with
+// the Mighty worth nothing and the Joker offsetting one scoring card.
+func DefaultDealMiss() *DealMissConfig {
+	return &DealMissConfig{Threshold: 1, PointWeight: 1, MightyWeight: 0, JokerWeight: -1}
+}
+
+// ConcedeConfig prices a declarer concession before the first lead. The hand
+// is scored as a failed contract with S = Base + PerLevel×(bid − minimum
+// bid): each opponent collects S, a friend already called pays as for any
+// failure, and the declarer pays the rest.
+type ConcedeConfig struct {
+	Base     int `json:"base"`
+	PerLevel int `json:"per_level"`
+}
+
+// DefaultConcede charges one point per opponent at the minimum bid and one
+// more for every level above it, well under a played-out failure.
+func DefaultConcede() *ConcedeConfig {
+	return &ConcedeConfig{Base: 1, PerLevel: 1}
+}
+
+// GameConfig captures every difference between the four-, five- and
+// six-player games.
 type GameConfig struct {
-	NumPlayers        int      `json:"num_players"`
-	AllowJokerPartner bool     `json:"allow_joker_partner"`
-	FailDist          FailDist `json:"fail_dist"`
+	NumPlayers        int              `json:"num_players"`
+	AllowJokerPartner bool             `json:"allow_joker_partner"`
+	FailDist          FailDist         `json:"fail_dist"`
+	DealMiss          *DealMissConfig  `json:"deal_miss,omitempty"`  // nil disables deal-miss calls
+	BidOrder          BidOrder         `json:"bid_order,omitempty"`  // empty ranks by points only
+	Rules             RuleSet          `json:"rules"`                // special-card house rules
+	Match             MatchConfig      `json:"match"`                // when the match ends; zero plays open-ended
+	Concede           *ConcedeConfig   `json:"concede,omitempty"`    // nil disables declarer concessions
+	Forfeit           *ForfeitConfig   `json:"forfeit,omitempty"`    // nil voids a hand a player leaves
+	Timers            *TurnTimers      `json:"timers,omitempty"`     // nil never times out a turn
+	Presence          *PresenceConfig  `json:"presence,omitempty"`   // nil leaves a disconnected player's seat alone
+	Takeover          TakeoverPolicy   `json:"takeover,omitempty"`   // empty hands a taken-over seat's total to the newcomer
+	Spectators        *SpectatorConfig `json:"spectators,omitempty"` // nil closes the game to spectators
+	Scoring           Scoring          `json:"scoring,omitempty"`    // empty scores officially
+	Practice          bool             `json:"practice,omitempty"`   // practice tables offer bid hints; ranked ones do not
 }
 
 // DefaultConfig returns the standard five-player configuration.
 func DefaultConfig() GameConfig {
-	return GameConfig{NumPlayers: 5, AllowJokerPartner: true, FailDist: FailEqualSplit}
+	return GameConfig{NumPlayers: 5, AllowJokerPartner: true, FailDist: FailEqualSplit, DealMiss: DefaultDealMiss(), Concede: DefaultConcede(), Forfeit: DefaultForfeit(), Timers: DefaultTurnTimers(), Presence: DefaultPresence(), Spectators: DefaultSpectators(), Scoring: ScoringOfficial, Rules: CampusRules()}
 }
 
-// numSeats is the number of players this game seats (4 or 5).
+// numSeats is the number of players this game seats (4, 5 or 6).
 func (g *Game) numSeats() int {
 	if g.Config.NumPlayers == 0 {
 		return 5
@@ -36,6 +80,46 @@ func (g *Game) numSeats() int {
 // NumSeatsPublic exposes the seat count to other packages.
 func (g *Game) NumSeatsPublic() int { return g.numSeats() }
 
+// seatSlots is the length of Players for a game seating numPlayers. Four-player
+// games keep an empty fifth slot so stored games keep their original shape.
+func seatSlots(numPlayers int) int {
+	return max(numPlayers, 5)
+}
+
+// ensureSeats grows Players to fit the configured seat count; seats are never
+// dropped, so a shrinking config leaves the extra slots in place.
+func (g *Game) ensureSeats() {
+	if n := seatSlots(g.numSeats()); len(g.Players) < n {
+		g.Players = append(g.Players, make([]*Player, n-len(g.Players))...)
+	}
+}
+
+// isEliminated reports whether seat is sitting out the current six-player
+// hand. Only six-player games eliminate, so a zero-valued Eliminated on an
+// older stored game never hides seat 0.
+func (g *Game) isEliminated(seat int) bool {
+	return g.numSeats() == 6 && g.Eliminated >= 0 && seat == g.Eliminated
+}
+
+// numActive is the number of seats that play tricks: a six-player hand is
+// played by five once the declarer eliminates a seat.
+func (g *Game) numActive() int {
+	if g.isEliminated(g.Eliminated) {
+		return g.numSeats() - 1
+	}
+	return g.numSeats()
+}
+
+// nextSeat returns the seat after seat in play order, skipping the seat
+// sitting out a six-player hand.
+func (g *Game) nextSeat(seat int) int {
+	next := (seat + 1) % g.numSeats()
+	if g.isEliminated(next) {
+		next = (next + 1) % g.numSeats()
+	}
+	return next
+}
+
 // minBidPoints is the lowest legal bid on the 3-10 scale: 3 (target 13) for
 // five players, 4 (target 14) for four players.
 func (g *Game) minBidPoints() int {
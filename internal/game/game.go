@@ -11,14 +11,21 @@ const (
 	PhaseWaiting Phase = "waiting"
 	// PhaseBidding indicates players are currently bidding for the contract.
 	PhaseBidding Phase = "bidding"
+	// PhaseEliminating indicates the six-player declarer is choosing who sits out.
+	PhaseEliminating Phase = "eliminating"
 	// PhaseExchanging indicates the declarer is exchanging cards with the kitty.
 	PhaseExchanging Phase = "exchanging" // Declarer exchanges cards
 	// PhaseCalling indicates the declarer is calling a partner.
 	PhaseCalling Phase = "calling" // Declarer calls partner
 	// PhasePlaying indicates the trick-taking phase is in progress.
 	PhasePlaying Phase = "playing"
-	// PhaseFinished indicates the game has concluded.
+	// PhaseFinished indicates the round has concluded; the table may vote to play again.
 	PhaseFinished Phase = "finished"
+	// PhaseMatchOver indicates the match has reached its target and is final.
+	PhaseMatchOver Phase = "match_over"
+	// PhaseCorrupted indicates a move broke the game's invariants; the game
+	// was frozen at its last consistent state and takes no more moves.
+	PhaseCorrupted Phase = "corrupted"
 )
 
 // MoveType represents the type of action a player performs.
@@ -39,6 +46,20 @@ const (
 	MovePlayAgain MoveType = "play_again"
 	// MoveChangeConfig represents changing the game config (e.g. NumPlayers).
 	MoveChangeConfig MoveType = "change_config"
+	// MoveChangeTrump represents the declarer raising the contract to switch
+	// trump after taking the kitty.
+	MoveChangeTrump MoveType = "change_trump"
+	// MoveDealMiss represents a player showing a weak hand to force a redeal.
+	MoveDealMiss MoveType = "deal_miss"
+	// MoveEliminate represents the six-player declarer choosing a seat to sit
+	// out the hand.
+	MoveEliminate MoveType = "eliminate"
+	// MoveConcede represents the declarer giving up the contract before the
+	// first lead.
+	MoveConcede MoveType = "concede"
+	// MoveClaim represents a player showing their hand and claiming the
+	// remaining tricks, or a stated number of them.
+	MoveClaim MoveType = "claim"
 )
 
 // ChangeConfigMove represents the payload for changing game config.
@@ -46,6 +67,19 @@ type ChangeConfigMove struct {
 	NumPlayers int `json:"num_players"`
 }
 
+// EliminateMove represents the payload for a six-player elimination: the seat
+// that sits out the rest of the hand.
+type EliminateMove struct {
+	Seat int `json:"seat"`
+}
+
+// ChangeTrumpMove represents the payload for a declarer trump change: the new
+// trump suit, or is_no_trump with suit "none".
+type ChangeTrumpMove struct {
+	Suit      Suit `json:"suit"`
+	IsNoTrump bool `json:"is_no_trump"`
+}
+
 // PlayCardMove represents the payload for playing a card.
 type PlayCardMove struct {
 	Card       Card `json:"card"`
@@ -53,27 +87,52 @@ type PlayCardMove struct {
 	CalledSuit Suit `json:"called_suit,omitempty"` // required when leading the Joker
 }
 
-// CallPartnerMove represents the declarer's friend call: either a card
-// (whose holder becomes the secret partner) or no_friend to play alone.
-type CallPartnerMove struct {
-	Card     *Card `json:"card,omitempty"`
-	NoFriend bool  `json:"no_friend,omitempty"`
-}
+// FriendMode says how the declarer's friend is chosen.
+type FriendMode string
+
+const (
+	// FriendCard makes the holder of a named card the friend.
+	FriendCard FriendMode = "card"
+	// FriendFirstTrick makes the winner of the first trick the friend.
+	FriendFirstTrick FriendMode = "first_trick"
+	// FriendMighty makes the holder of the Mighty the friend, whatever card
+	// the Mighty is under the final trump.
+	FriendMighty FriendMode = "mighty"
+	// FriendJoker makes the holder of the Joker the friend.
+	FriendJoker FriendMode = "joker"
+)
 
-// Config holds configuration for the game.
-type Config struct {
-	MaxPlayers   int `json:"max_players"`
-	WinningScore int `json:"winning_score"` // usually 3-10
+// CallPartnerMove represents the declarer's friend call: a card (whose holder
+// becomes the secret partner), one of the role modes, or no_friend to play
+// alone. An empty mode with a card is a card call.
+type CallPartnerMove struct {
+	Card     *Card      `json:"card,omitempty"`
+	Mode     FriendMode `json:"mode,omitempty"`
+	NoFriend bool       `json:"no_friend,omitempty"`
 }
 
 // Player represents a participant in the game.
 type Player struct {
 	ID          string `json:"id"`
 	Name        string `json:"name"`
-	Seat        int    `json:"seat"`             // 0-4
-	Hand        []Card `json:"hand,omitempty"`   // hidden from others in JSON
-	Points      []Card `json:"points,omitempty"` // point cards taken
+	Seat        int    `json:"seat"`                 // 0-5
+	Hand        []Card `json:"hand,omitempty"`       // hidden from others in JSON
+	Points      []Card `json:"points,omitempty"`     // point cards taken
+	HandCount   int    `json:"hand_count,omitempty"` // set by View, where Hand may be withheld
 	IsConnected bool   `json:"is_connected"`
+	Bot         string `json:"bot,omitempty"` // difficulty of a bot seat; empty for a person
+
+	DisconnectedAt *time.Time `json:"disconnected_at,omitempty"` // when the player's last socket closed
+	Substituted    bool       `json:"substituted,omitempty"`     // the grace period ran out and Config.Presence's policy applies
+}
+
+// DealMiss records a successful deal-miss call: the hand shown to the table
+// and the value it was judged at.
+type DealMiss struct {
+	PlayerID string `json:"player_id"`
+	Seat     int    `json:"seat"`
+	Hand     []Card `json:"hand"`
+	Value    int    `json:"value"`
 }
 
 // Bid represents a player's bid.
@@ -89,44 +148,64 @@ type Game struct {
 	ID      string     `json:"id"`
 	Status  Phase      `json:"status"`
 	Config  GameConfig `json:"config"`
-	Players [5]*Player `json:"players"`
-	Kitty   []Card     `json:"kitty,omitempty"` // hidden usually
+	Players []*Player  `json:"players"`         // indexed by seat
+	Owner   string     `json:"owner,omitempty"` // first player seated; manages the table's bots
+	Kitty   []Card     `json:"kitty,omitempty"` // undealt cards, then the declarer's discards; hidden from everyone else
 
 	// Hand State
-	Deck        Deck `json:"-"`
-	CurrentTurn int  `json:"current_turn"` // Seat index 0-4
-	Dealer      int  `json:"dealer"`       // Seat index
+	Deck         Deck       `json:"-"`
+	DealSeed     *Seed      `json:"deal_seed,omitempty"`     // base seed in seeded mode; nil draws each hand from the CSPRNG
+	HandSeeds    []Seed     `json:"hand_seeds,omitempty"`    // seed that shuffled each hand dealt, in order
+	CurrentTurn  int        `json:"current_turn"`            // Seat index 0-5
+	TurnDeadline *time.Time `json:"turn_deadline,omitempty"` // when the player to move times out; nil when untimed
+	Dealer       int        `json:"dealer"`                  // Seat index
 
 	// Bidding
 	Bids          []Bid        `json:"bids"`
 	CurrentBid    *Bid         `json:"current_bid"`
 	Declarer      int          `json:"declarer"` // Seat index, -1 if none
 	PassedPlayers map[int]bool `json:"passed_players"`
+	LastDealMiss  *DealMiss    `json:"last_deal_miss,omitempty"` // hand shown by the last deal-miss call this round
 
 	// Contract
-	Contract *Bid `json:"contract"`
+	Contract   *Bid `json:"contract"`
+	Eliminated int  `json:"eliminated"` // Seat sitting out a six-player hand, -1 if none
 
 	// Partner
-	PartnerCard *Card `json:"partner_card"`
-	PartnerSeat int   `json:"partner_seat"` // -1 if unknown or alone
-	IsNoFriend  bool  `json:"is_no_friend"`
+	PartnerCard *Card      `json:"partner_card"`
+	FriendMode  FriendMode `json:"friend_mode,omitempty"` // how the friend was called; empty until the call or when alone
+	PartnerSeat int        `json:"partner_seat"`          // -1 if unknown or alone
+	IsNoFriend  bool       `json:"is_no_friend"`
 
 	// Play
-	Trump  Suit    `json:"trump"`
-	Tricks []Trick `json:"tricks"`
+	Trump        Suit    `json:"trump"`
+	TrumpChanged bool    `json:"trump_changed"` // declarer already raised to switch trump this hand
+	Tricks       []Trick `json:"tricks"`
+	Conceded     bool    `json:"conceded,omitempty"` // declarer conceded before the first lead
+	Claim        *Claim  `json:"claim,omitempty"`    // the verified claim that ended the hand, if any
 
 	// Scoring
-	Scores         map[string]int   `json:"scores"` // Final round scores: declarer full, revealed partner half, others 0. Card points live in Player.Points.
-	TotalScores    map[string]int   `json:"total_scores"` // Cumulative scores
-	ScoreHistory   []map[string]int `json:"score_history"` // History of round scores
-	PlayAgainVotes map[int]bool     `json:"play_again_votes"` // Seats that voted to play again
+	Scores         map[string]int `json:"scores"`              // Final round scores: declarer full, revealed partner half, others 0. Card points live in Player.Points.
+	TotalScores    map[string]int `json:"total_scores"`        // Cumulative scores
+	ScoreHistory   []RoundScore   `json:"score_history"`       // Breakdown of every scored round
+	PlayAgainVotes map[int]bool   `json:"play_again_votes"`    // Seats that voted to play again
+	Standings      []Standing     `json:"standings,omitempty"` // Final ranking once the match is over
+
+	// Seat takeovers
+	Takeover  *Takeover       `json:"takeover,omitempty"`   // pending request to take over an abandoned seat
+	Handovers []Handover      `json:"handovers,omitempty"`  // seats taken over mid-match, in order
+	LeftSeats map[int]*Player `json:"left_seats,omitempty"` // players who left their seat mid-match, which only a takeover refills
+
+	Spectators int `json:"spectators"` // open spectator sockets, counted when the game is served
 
 	Version   int64     `json:"version"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	replaySeeds []Seed // recorded hand seeds still to be dealt during Replay
 }
 
-// Trick represents a single round of 5 cards.
+// Trick represents a single round of one card per active seat.
 type Trick struct {
 	Cards       []PlayedCard `json:"cards"`
 	LeadSuit    Suit         `json:"lead_suit"`
@@ -147,7 +226,7 @@ func New(id string) *Game {
 }
 
 // NewWithConfig creates a new game with the given configuration.
-func NewWithConfig(id string, cfg GameConfig) *Game {
+func NewWithConfig(id string, cfg GameConfig, opts ...Option) *Game {
 	if cfg.NumPlayers == 0 {
 		cfg.NumPlayers = 5
 	}
@@ -155,20 +234,24 @@ func NewWithConfig(id string, cfg GameConfig) *Game {
 		cfg.FailDist = FailEqualSplit
 	}
 	g := &Game{
-		ID:            id,
-		Status:        PhaseWaiting,
-		Config:        cfg,
-		Players:       [5]*Player{},
-		PassedPlayers: make(map[int]bool),
-		Tricks:        make([]Trick, 0),
-		Scores:        make(map[string]int),
-		TotalScores:   make(map[string]int),
+		ID:             id,
+		Status:         PhaseWaiting,
+		Config:         cfg,
+		Players:        make([]*Player, seatSlots(cfg.NumPlayers)),
+		PassedPlayers:  make(map[int]bool),
+		Tricks:         make([]Trick, 0),
+		Scores:         make(map[string]int),
+		TotalScores:    make(map[string]int),
 		PlayAgainVotes: make(map[int]bool),
-		Declarer:      -1,
-		PartnerSeat:   -1,
-		Version:       1,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		Declarer:       -1,
+		PartnerSeat:    -1,
+		Eliminated:     -1,
+		Version:        1,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
 	return g
 }
@@ -186,9 +269,7 @@ func (g *Game) IsFull() bool {
 
 // Start deals the cards and starts the bidding phase.
 func (g *Game) Start() {
-	deck := NewDeckFor(g.numSeats())
-	deck.Shuffle()
-	hands, kitty := deck.Deal(g.numSeats())
+	hands, kitty := DealFromSeed(g.numSeats(), g.nextHandSeed())
 
 	for i, h := range hands {
 		if g.Players[i] != nil {
@@ -202,3 +283,40 @@ func (g *Game) Start() {
 
 	g.CurrentTurn = 0 // Seat 0 bids first
 }
+
+// RestoreKitty rebuilds the kitty of a game saved before the declarer's
+// discards were kept in it: once the exchange is over, every card of the
+// deck that no hand or trick holds was discarded. Games that already keep
+// their kitty are left alone.
+func (g *Game) RestoreKitty() {
+	if g.Kitty != nil || g.Contract == nil || !g.IsFull() {
+		return
+	}
+
+	switch g.Status {
+	case PhaseCalling, PhasePlaying, PhaseFinished, PhaseMatchOver:
+	default:
+		return
+	}
+
+	held := make(map[Card]bool)
+	for _, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		for _, c := range p.Hand {
+			held[c] = true
+		}
+	}
+	for _, t := range g.Tricks {
+		for _, pc := range t.Cards {
+			held[pc.Card] = true
+		}
+	}
+
+	for _, c := range NewDeckFor(g.numSeats()) {
+		if !held[c] {
+			g.Kitty = append(g.Kitty, c)
+		}
+	}
+}
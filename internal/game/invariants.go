@@ -0,0 +1,309 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// scoringCards is the number of point cards in every deck: the tens and
+// court cards and aces of the four suits.
+const scoringCards = 20
+
+// InvariantError lists every way a game's state has become inconsistent.
+type InvariantError struct {
+	Problems []string
+}
+
+func (e *InvariantError) Error() string {
+	return "game state is inconsistent: " + strings.Join(e.Problems, "; ")
+}
+
+// invariants collects the problems CheckInvariants finds.
+type invariants struct {
+	problems []string
+}
+
+func (iv *invariants) failf(format string, args ...any) {
+	iv.problems = append(iv.problems, fmt.Sprintf(format, args...))
+}
+
+// CheckInvariants reports whether the game is internally consistent after a
+// move made at version since: the version moved forward, every seat and turn
+// points at a real player, every card of the deck is in exactly one hand,
+// trick or the kitty, and every point pile holds scoring cards its owner won.
+// It returns an *InvariantError listing each problem found, or nil.
+func (g *Game) CheckInvariants(since int64) error {
+	iv := &invariants{}
+
+	if g.Version <= since {
+		iv.failf("version went from %d to %d", since, g.Version)
+	}
+
+	g.checkSeats(iv)
+
+	switch g.Status {
+	case PhaseBidding, PhaseEliminating, PhaseExchanging, PhaseCalling, PhasePlaying:
+		g.checkTurn(iv)
+		g.checkHand(iv)
+	case PhaseFinished, PhaseMatchOver:
+		// A player who left after the hand took their cards and points
+		// with them.
+		if g.IsFull() {
+			g.checkHand(iv)
+		}
+	}
+
+	g.checkScores(iv)
+
+	if len(iv.problems) > 0 {
+		return &InvariantError{Problems: iv.problems}
+	}
+
+	return nil
+}
+
+// checkSeats verifies that each player sits at the seat it is indexed by and
+// that no player is seated twice.
+func (g *Game) checkSeats(iv *invariants) {
+	if len(g.Players) < seatSlots(g.numSeats()) {
+		iv.failf("%d seat slots for %d seats", len(g.Players), g.numSeats())
+	}
+
+	ids := make(map[string]int)
+	for seat, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		if seat >= g.numSeats() {
+			iv.failf("player %s sits at seat %d of a %d-seat table", p.ID, seat, g.numSeats())
+		}
+		if p.Seat != seat {
+			iv.failf("player %s at seat %d says it sits at seat %d", p.ID, seat, p.Seat)
+		}
+		if other, ok := ids[p.ID]; ok {
+			iv.failf("player %s sits at seats %d and %d", p.ID, other, seat)
+		}
+		ids[p.ID] = seat
+	}
+}
+
+// occupied reports whether seat holds a player.
+func (g *Game) occupied(seat int) bool {
+	return seat >= 0 && seat < len(g.Players) && g.Players[seat] != nil
+}
+
+// checkHand verifies the current or last hand.
+func (g *Game) checkHand(iv *invariants) {
+	g.checkContract(iv)
+	g.checkTricks(iv)
+	g.checkCards(iv)
+	g.checkPoints(iv)
+}
+
+// checkTurn verifies that the turn is at a seat that plays this hand.
+func (g *Game) checkTurn(iv *invariants) {
+	if !g.occupied(g.CurrentTurn) {
+		iv.failf("current turn %d is not an occupied seat", g.CurrentTurn)
+	} else if g.isEliminated(g.CurrentTurn) {
+		iv.failf("current turn %d is sitting out this hand", g.CurrentTurn)
+	}
+}
+
+// checkContract verifies the declarer, eliminated seat and friend once
+// bidding has closed.
+func (g *Game) checkContract(iv *invariants) {
+	if g.Status == PhaseBidding || (g.Contract == nil && g.Declarer < 0) {
+		// Bidding, or a finished hand that was thrown in before a contract.
+		return
+	}
+
+	if g.Contract == nil {
+		iv.failf("declarer %d has no contract", g.Declarer)
+	}
+	if !g.occupied(g.Declarer) {
+		iv.failf("declarer %d is not an occupied seat", g.Declarer)
+	}
+	if g.Eliminated >= 0 && (g.numSeats() != 6 || g.Eliminated == g.Declarer || !(g.occupied(g.Eliminated) || g.SeatLeft(g.Eliminated))) {
+		iv.failf("seat %d cannot sit out this hand", g.Eliminated)
+	}
+	if g.PartnerSeat >= 0 && (!g.occupied(g.PartnerSeat) || g.isEliminated(g.PartnerSeat)) {
+		iv.failf("partner seat %d does not play this hand", g.PartnerSeat)
+	}
+}
+
+// complete reports whether every active seat has played to t.
+func (g *Game) complete(t Trick) bool {
+	return len(t.Cards) == g.numActive()
+}
+
+// checkTricks verifies that only the last trick is still open, that each seat
+// plays once to a trick, and that every completed trick has a winner.
+func (g *Game) checkTricks(iv *invariants) {
+	if len(g.Tricks) > 10 {
+		iv.failf("%d tricks in a 10-trick hand", len(g.Tricks))
+	}
+
+	for i, t := range g.Tricks {
+		if len(t.Cards) > g.numActive() {
+			iv.failf("trick %d holds %d cards for %d seats", i+1, len(t.Cards), g.numActive())
+		}
+		if i < len(g.Tricks)-1 && !g.complete(t) {
+			iv.failf("trick %d was left with %d cards", i+1, len(t.Cards))
+		}
+
+		played := make(map[int]bool)
+		for _, pc := range t.Cards {
+			switch {
+			case !g.occupied(pc.Seat) || g.Players[pc.Seat].ID != pc.PlayerID:
+				iv.failf("trick %d: %s was played by %s from seat %d", i+1, pc.Card, pc.PlayerID, pc.Seat)
+			case g.isEliminated(pc.Seat):
+				iv.failf("trick %d: seat %d played while sitting out", i+1, pc.Seat)
+			case played[pc.Seat]:
+				iv.failf("trick %d: seat %d played twice", i+1, pc.Seat)
+			}
+			played[pc.Seat] = true
+		}
+
+		if g.complete(t) && !g.occupied(t.Winner) {
+			iv.failf("trick %d was won by empty seat %d", i+1, t.Winner)
+		}
+	}
+}
+
+// checkCards verifies that every card of the deck is in exactly one hand,
+// trick or the kitty, and that the deck has its 20 scoring cards.
+func (g *Game) checkCards(iv *invariants) {
+	deck := NewDeckFor(g.numSeats())
+
+	points := 0
+	for _, c := range deck {
+		if c.IsPointCard() {
+			points++
+		}
+	}
+	if points != scoringCards {
+		iv.failf("the deck has %d scoring cards, not %d", points, scoringCards)
+	}
+
+	where := make(map[Card][]string)
+	for seat, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		for _, c := range p.Hand {
+			where[c] = append(where[c], fmt.Sprintf("seat %d's hand", seat))
+		}
+	}
+	for _, c := range g.Kitty {
+		where[c] = append(where[c], "the kitty")
+	}
+	for i, t := range g.Tricks {
+		for _, pc := range t.Cards {
+			where[pc.Card] = append(where[pc.Card], fmt.Sprintf("trick %d", i+1))
+		}
+	}
+
+	inDeck := make(map[Card]bool, len(deck))
+	for _, c := range deck {
+		inDeck[c] = true
+
+		switch places := where[c]; len(places) {
+		case 0:
+			iv.failf("%s is missing", c)
+		case 1:
+		default:
+			iv.failf("%s is held %d times: %s", c, len(places), strings.Join(places, ", "))
+		}
+	}
+
+	for c, places := range where {
+		if !inDeck[c] {
+			iv.failf("%s is not in a %d-seat deck but is in %s", c, g.numSeats(), strings.Join(places, ", "))
+		}
+	}
+}
+
+// checkPoints verifies that the point piles hold only scoring cards, each
+// once, and that each was won: in a completed trick taken by the pile's
+// owner, in the declarer's discards, or from a hand shown with a claim.
+// Every scoring card in a completed trick or the discards must be in a pile.
+func (g *Game) checkPoints(iv *invariants) {
+	owner := make(map[Card]int)
+	for seat, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		for _, c := range p.Points {
+			if !c.IsPointCard() {
+				iv.failf("seat %d's points hold %s, which scores nothing", seat, c)
+			}
+			if other, ok := owner[c]; ok {
+				iv.failf("%s is in the points of seats %d and %d", c, other, seat)
+			}
+			owner[c] = seat
+		}
+	}
+
+	won := make(map[Card]int)
+	for _, t := range g.Tricks {
+		if !g.complete(t) {
+			continue
+		}
+		for _, pc := range t.Cards {
+			if pc.Card.IsPointCard() {
+				won[pc.Card] = t.Winner
+			}
+		}
+	}
+
+	// The kitty holds the declarer's discards from the call onwards.
+	switch g.Status {
+	case PhaseCalling, PhasePlaying, PhaseFinished, PhaseMatchOver:
+		if g.Contract != nil {
+			for _, c := range g.Kitty {
+				if c.IsPointCard() {
+					won[c] = g.Declarer
+				}
+			}
+		}
+	}
+
+	for c, winner := range won {
+		if seat, ok := owner[c]; !ok {
+			iv.failf("%s was won by seat %d but is in no one's points", c, winner)
+		} else if seat != winner {
+			iv.failf("%s was won by seat %d but is in seat %d's points", c, winner, seat)
+		}
+	}
+
+	for c, seat := range owner {
+		if _, ok := won[c]; ok {
+			continue
+		}
+		if g.Claim == nil || !g.inHand(c) {
+			iv.failf("%s is in seat %d's points but was never won", c, seat)
+		}
+	}
+}
+
+// inHand reports whether a player holds c.
+func (g *Game) inHand(c Card) bool {
+	for _, p := range g.Players {
+		if p != nil && slices.Contains(p.Hand, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkScores verifies that the running totals are zero-sum.
+func (g *Game) checkScores(iv *invariants) {
+	total := 0
+	for _, score := range g.TotalScores {
+		total += score
+	}
+	if total != 0 {
+		iv.failf("total scores sum to %d, not zero", total)
+	}
+}
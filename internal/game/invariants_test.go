@@ -0,0 +1,189 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// randomHand seats n players and plays random legal moves, deal misses,
+// concessions and claims included, until the hand is scored, checking the
+// invariants after every move.
+func randomHand(t *testing.T, n int, seed uint64) *Game {
+	t.Helper()
+
+	rng := rand.New(rand.NewPCG(seed, 19))
+	cfg := DefaultConfig()
+	cfg.NumPlayers = n
+	g := NewWithConfig("invariants", cfg)
+
+	for seat := range n {
+		g.SeatPlayer(seat, fmt.Sprintf("p%d", seat), "player")
+	}
+
+	for step := 0; g.Status != PhaseFinished && g.Status != PhaseMatchOver; step++ {
+		if step == 500 {
+			t.Fatalf("seed %d: hand did not end", seed)
+		}
+
+		p := g.Players[g.CurrentTurn]
+		moves := g.LegalMovesExceptClaims(p.ID)
+		if len(moves) == 0 {
+			t.Fatalf("seed %d: no legal move for %s in %s", seed, p.ID, g.Status)
+		}
+
+		m := moves[rng.IntN(len(moves))]
+		if g.Status == PhasePlaying && len(p.Hand) <= 3 && g.ValidateMove(p.ID, MoveClaim, ClaimMove{}) == nil {
+			m = LegalMove{Type: MoveClaim, Payload: ClaimMove{}}
+		}
+		if opt, ok := m.Payload.(DiscardOption); ok {
+			m.Payload = opt.From[:opt.Count]
+		}
+
+		before := g.Version
+		if err := g.ApplyMove(p.ID, m.Type, m.Payload); err != nil {
+			t.Fatalf("seed %d: apply %s: %v", seed, m.Type, err)
+		}
+
+		if err := g.CheckInvariants(before); err != nil {
+			t.Fatalf("seed %d: after %s's %s: %v", seed, p.ID, m.Type, err)
+		}
+	}
+
+	return g
+}
+
+func TestCheckInvariantsHoldThroughRandomHands(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{4, 5, 6} {
+		for seed := range uint64(30) {
+			randomHand(t, n, seed)
+		}
+	}
+}
+
+func TestCheckInvariantsReportsEachProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		corrupt func(g *Game)
+		want    string
+	}{
+		{"stale version", func(g *Game) { g.Version = 0 }, "version went from"},
+		{"duplicated card", func(g *Game) { g.Players[1].Hand[0] = g.Players[0].Hand[0] }, "held 2 times"},
+		{"lost card", func(g *Game) { g.Kitty = g.Kitty[1:] }, "is missing"},
+		{"foreign card", func(g *Game) { g.Kitty[0] = Card{Suit: "stars", Rank: Ace} }, "not in a 5-seat deck"},
+		{"empty turn", func(g *Game) { g.CurrentTurn = 7 }, "current turn 7"},
+		{"wrong seat", func(g *Game) { g.Players[2].Seat = 3 }, "says it sits at seat 3"},
+		{"stolen points", func(g *Game) { g.Players[3].Points = append(g.Players[3].Points, Card{Suit: Spades, Rank: Ace}) }, "never won"},
+		{"uneven totals", func(g *Game) { g.TotalScores = map[string]int{"p0": 3} }, "sum to 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := NewWithConfig("broken", DefaultConfig())
+			for seat := range 5 {
+				g.SeatPlayer(seat, fmt.Sprintf("p%d", seat), "player")
+			}
+
+			if err := g.CheckInvariants(0); err != nil {
+				t.Fatalf("fresh deal: %v", err)
+			}
+
+			tt.corrupt(g)
+
+			err := g.CheckInvariants(0)
+
+			var inv *InvariantError
+			if !errors.As(err, &inv) || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCheckInvariantsFollowsPointsToTheirWinner(t *testing.T) {
+	t.Parallel()
+
+	for seed := range uint64(50) {
+		g := randomHand(t, 5, seed)
+		if g.Conceded || g.Claim != nil {
+			continue
+		}
+
+		// Hand a scoring card from the last trick to the wrong seat.
+		last := g.Tricks[len(g.Tricks)-1]
+		for _, pc := range last.Cards {
+			if !pc.Card.IsPointCard() {
+				continue
+			}
+
+			winner := g.Players[last.Winner]
+			winner.Points = removeCard(winner.Points, pc.Card)
+			other := g.Players[(last.Winner+1)%5]
+			other.Points = append(other.Points, pc.Card)
+
+			want := fmt.Sprintf("%s was won by seat %d but is in seat %d's points", pc.Card, last.Winner, other.Seat)
+			if err := g.CheckInvariants(0); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("expected %q, got %v", want, err)
+			}
+
+			return
+		}
+	}
+
+	t.Fatal("no seed played out a hand with a scoring card in the last trick")
+}
+
+func TestRestoreKittyForLegacyGames(t *testing.T) {
+	t.Parallel()
+
+	// A game saved before discards were kept has a nil kitty once the
+	// exchange is over; the discards are the cards no one else holds.
+	rng := rand.New(rand.NewPCG(3, 19))
+	g := NewWithConfig("legacy", DefaultConfig())
+	for seat := range 5 {
+		g.SeatPlayer(seat, fmt.Sprintf("p%d", seat), "player")
+	}
+	for g.Status != PhaseCalling {
+		p := g.Players[g.CurrentTurn]
+		moves := g.LegalMovesExceptClaims(p.ID)
+		m := moves[rng.IntN(len(moves))]
+		if opt, ok := m.Payload.(DiscardOption); ok {
+			m.Payload = opt.From[:opt.Count]
+		}
+		if err := g.ApplyMove(p.ID, m.Type, m.Payload); err != nil {
+			t.Fatalf("apply %s: %v", m.Type, err)
+		}
+	}
+
+	for _, g := range []*Game{g, randomHand(t, 5, 3)} {
+		kitty := g.Kitty
+		g.Kitty = nil
+
+		if err := g.CheckInvariants(g.Version - 1); err == nil {
+			t.Fatalf("%s: a missing kitty must be reported", g.Status)
+		}
+
+		g.RestoreKitty()
+
+		if len(g.Kitty) != len(kitty) || slices.ContainsFunc(kitty, func(c Card) bool { return !slices.Contains(g.Kitty, c) }) {
+			t.Fatalf("%s: restored kitty %v, want %v", g.Status, g.Kitty, kitty)
+		}
+		if err := g.CheckInvariants(g.Version - 1); err != nil {
+			t.Fatalf("%s: restored game: %v", g.Status, err)
+		}
+
+		g.RestoreKitty()
+		if len(g.Kitty) != len(kitty) {
+			t.Fatalf("%s: restoring twice changed the kitty to %v", g.Status, g.Kitty)
+		}
+	}
+}
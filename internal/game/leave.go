@@ -0,0 +1,105 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// ForfeitConfig prices a player leaving in the middle of a hand: the hand
+// ends at once, and the leaver pays Penalty to every other seat in it.
+type ForfeitConfig struct {
+	Penalty int `json:"penalty"`
+}
+
+// DefaultForfeit charges the leaver more than most played-out failures, so
+// walking away never beats playing a bad hand to the end.
+func DefaultForfeit() *ForfeitConfig {
+	return &ForfeitConfig{Penalty: 10}
+}
+
+// Leave takes a player out of the game. Before the deal and between hands
+// the seat is simply freed, and any play-again votes are withdrawn so the
+// table waits for the seat to be filled. A player leaving mid-hand forfeits
+// it: the hand is scored under Config.Forfeit before the seat is freed. A
+// six-player seat sitting out the hand holds no cards, so it is freed and
+// the hand goes on without it. A seat freed once the match is under way keeps its player, with the cards
+// and points of the hand just ended, in LeftSeats, so it is refilled by
+// takeover, under the table's vote and takeover policy.
+func (g *Game) Leave(playerID string) error {
+	p := g.GetPlayer(playerID)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated", ErrInvalidMove, playerID)
+	}
+
+	switch g.Status {
+	case PhaseWaiting, PhaseFinished, PhaseMatchOver:
+	case PhaseBidding, PhaseEliminating, PhaseExchanging, PhaseCalling, PhasePlaying:
+		if !g.isEliminated(p.Seat) {
+			g.finishRound(g.forfeitScores(p.Seat))
+		}
+	default:
+		return fmt.Errorf("%w: cannot leave a %s game", ErrInvalidMove, g.Status)
+	}
+
+	g.vacate(p.Seat)
+	if g.Status != PhaseWaiting && g.Status != PhaseMatchOver {
+		if g.LeftSeats == nil {
+			g.LeftSeats = make(map[int]*Player)
+		}
+		g.LeftSeats[p.Seat] = p
+	}
+	g.PlayAgainVotes = make(map[int]bool)
+	g.dropTakeover(p.Seat)
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// vacate empties seat, handing the table to the next person seated if its
+// owner left.
+func (g *Game) vacate(seat int) {
+	left := g.Players[seat]
+	g.Players[seat] = nil
+
+	if left.ID != g.Owner {
+		return
+	}
+
+	g.Owner = ""
+	for _, p := range g.Players {
+		if p != nil && p.Bot == "" {
+			g.Owner = p.ID
+			return
+		}
+	}
+}
+
+// forfeitScores prices a forfeit under Config.Forfeit: every other seat in
+// the hand collects the penalty from the leaver. Without a forfeit config
+// the hand is void and nobody scores.
+func (g *Game) forfeitScores(leaver int) (RoundScore, map[int]int) {
+	scores := make(map[int]int)
+	rs := RoundScore{Scoring: g.scoring(), ForfeitedBy: g.Players[leaver].ID}
+	if g.Contract != nil {
+		rs.Bid, rs.Target = g.Contract.Points, g.Contract.Points+10
+		rs.P = g.teamPoints(g.Declarer)
+	}
+
+	f := g.Config.Forfeit
+	if f == nil {
+		return rs, scores
+	}
+
+	rs.Base, rs.S = f.Penalty, f.Penalty
+	for seat, player := range g.Players {
+		if player == nil || seat == leaver || g.isEliminated(seat) {
+			continue
+		}
+		scores[seat] = f.Penalty
+		scores[leaver] -= f.Penalty
+	}
+
+	return rs, scores
+}
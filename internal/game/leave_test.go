@@ -0,0 +1,225 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// seatFive seats p0 to p4 at a five-player table, dealing the first hand.
+func seatFive(cfg GameConfig) *Game {
+	g := NewWithConfig("leave", cfg)
+	for seat := range 5 {
+		g.SeatPlayer(seat, fmt.Sprintf("p%d", seat), fmt.Sprintf("P%d", seat))
+	}
+
+	return g
+}
+
+func TestLeaveBeforeTheDealFreesTheSeat(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithConfig("leave", DefaultConfig())
+	g.SeatPlayer(0, "p0", "P0")
+	g.SeatPlayer(1, "p1", "P1")
+
+	if err := g.Leave("p0"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	if g.Players[0] != nil || g.Status != PhaseWaiting {
+		t.Fatalf("expected seat 0 freed in a waiting game, got %s", g.Status)
+	}
+
+	if g.Owner != "p1" {
+		t.Fatalf("the table should pass to p1, got owner %q", g.Owner)
+	}
+
+	if err := g.Leave("p0"); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("leaving twice must fail, got %v", err)
+	}
+}
+
+func TestLeaveBetweenHandsWithdrawsVotes(t *testing.T) {
+	t.Parallel()
+
+	g := randomHand(t, 5, 1)
+	totals := fmt.Sprint(g.TotalScores)
+
+	for _, id := range []string{"p0", "p1"} {
+		if err := g.ApplyMove(id, MovePlayAgain, nil); err != nil {
+			t.Fatalf("play_again: %v", err)
+		}
+	}
+
+	before := g.Version
+	if err := g.Leave("p3"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	if err := g.CheckInvariants(before); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Players[3] != nil || len(g.PlayAgainVotes) != 0 || fmt.Sprint(g.TotalScores) != totals {
+		t.Fatalf("expected seat 3 freed, votes cleared and scores kept, got votes %v scores %v", g.PlayAgainVotes, g.TotalScores)
+	}
+
+	// A newcomer takes the seat, but the next hand waits for every vote.
+	g.SeatPlayer(3, "p5", "P5")
+	if g.Status != PhaseFinished {
+		t.Fatalf("filling the seat must not deal, got %s", g.Status)
+	}
+
+	for seat := range 5 {
+		if err := g.ApplyMove(g.Players[seat].ID, MovePlayAgain, nil); err != nil {
+			t.Fatalf("play_again: %v", err)
+		}
+	}
+
+	if g.Status != PhaseBidding {
+		t.Fatalf("expected the next hand dealt, got %s", g.Status)
+	}
+}
+
+func TestLeaveMidHandForfeits(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Forfeit = &ForfeitConfig{Penalty: 6}
+	g := seatFive(cfg)
+
+	before := g.Version
+	if err := g.Leave("p2"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	if err := g.CheckInvariants(before); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Status != PhaseFinished || g.Players[2] != nil {
+		t.Fatalf("expected the hand over and seat 2 freed, got %s", g.Status)
+	}
+
+	rs := g.ScoreHistory[len(g.ScoreHistory)-1]
+	if rs.ForfeitedBy != "p2" {
+		t.Fatalf("expected the round forfeited by p2, got %q", rs.ForfeitedBy)
+	}
+
+	want := map[string]int{"p0": 6, "p1": 6, "p2": -24, "p3": 6, "p4": 6}
+	if fmt.Sprint(g.TotalScores) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, g.TotalScores)
+	}
+}
+
+func TestLeaveWhileSittingOutKeepsTheHandGoing(t *testing.T) {
+	t.Parallel()
+
+	g := sixPlayerEliminating(t)
+	if err := g.ApplyMove("p0", MoveEliminate, EliminateMove{Seat: 3}); err != nil {
+		t.Fatalf("eliminate: %v", err)
+	}
+
+	status := g.Status
+	before := g.Version
+	if err := g.Leave("p3"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	if err := g.CheckInvariants(before); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.Status != status || len(g.ScoreHistory) != 0 {
+		t.Fatalf("the hand must go on in %s unscored, got %s with %d rounds", status, g.Status, len(g.ScoreHistory))
+	}
+
+	if g.Players[3] != nil || !g.SeatLeft(3) {
+		t.Fatal("expected seat 3 left, to be refilled by takeover")
+	}
+
+	for step := 0; g.Status != PhaseFinished; step++ {
+		if step == 100 {
+			t.Fatalf("the hand did not finish, stuck in %s", g.Status)
+		}
+
+		playerID, m, ok := g.TimeoutMove()
+		if !ok {
+			t.Fatalf("no move in %s", g.Status)
+		}
+		if err := g.ApplyMove(playerID, m.Type, m.Payload); err != nil {
+			t.Fatalf("%s %s: %v", playerID, m.Type, err)
+		}
+	}
+
+	rs := g.ScoreHistory[len(g.ScoreHistory)-1]
+	if rs.ForfeitedBy != "" || g.TotalScores["p3"] != 0 {
+		t.Fatalf("sitting out costs nothing, got forfeit %q and p3 at %d", rs.ForfeitedBy, g.TotalScores["p3"])
+	}
+}
+
+func TestLeaveMidHandWithoutForfeitVoidsTheHand(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Forfeit = nil
+	g := seatFive(cfg)
+
+	if err := g.Leave("p4"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	for id, score := range g.TotalScores {
+		if score != 0 {
+			t.Fatalf("a void hand scores nothing, but %s has %d", id, score)
+		}
+	}
+}
+
+func TestReplayMidHandLeave(t *testing.T) {
+	t.Parallel()
+
+	want, ledger := recordGame(t, 5, 7, 8)
+	if want.Status == PhaseFinished {
+		t.Fatal("game finished too early to leave mid-hand")
+	}
+
+	if err := want.Leave("p1"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	ledger.Moves = append(ledger.Moves, LedgerMove{Version: want.Version, PlayerID: "p1", Seat: 1, Type: MoveLeave, Payload: json.RawMessage(`null`)})
+
+	got, err := Replay(ledger)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatal("replayed forfeit differs from the recorded one")
+	}
+
+	rec, err := NewRecord(ledger)
+	if err != nil {
+		t.Fatalf("NewRecord: %v", err)
+	}
+
+	hand := rec.Hands[len(rec.Hands)-1]
+	if hand.Forfeit == nil || *hand.Forfeit != 1 || hand.Result == nil || hand.Result.Outcome != OutcomeForfeited {
+		t.Fatalf("expected the record to show seat 1's forfeit, got %+v", hand)
+	}
+
+	if len(hand.Result.Scores) != 5 || hand.Result.Scores[1] != (SeatScore{Seat: 1, Score: -40}) {
+		t.Fatalf("expected the leaver's penalty in the result, got %+v", hand.Result.Scores)
+	}
+
+	back, err := ParseRecord(rec.String())
+	if err != nil {
+		t.Fatalf("ParseRecord: %v", err)
+	}
+
+	if back.String() != rec.String() {
+		t.Fatalf("forfeit did not round-trip:\n%s\nvs\n%s", back, rec)
+	}
+}
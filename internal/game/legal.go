@@ -0,0 +1,127 @@
+package game
+
+// LegalMove is one move a player may make right now, with the payload
+// ValidateMove expects for it. Moves that take no payload leave it nil.
+type LegalMove struct {
+	Type    MoveType `json:"move_type"`
+	Payload any      `json:"payload,omitempty"`
+}
+
+// DiscardOption is the payload of a legal discard: any Count distinct cards
+// drawn from From.
+type DiscardOption struct {
+	Count int    `json:"count"`
+	From  []Card `json:"from"`
+}
+
+// discardCount is the number of cards the declarer returns after taking the
+// kitty.
+const discardCount = 3
+
+var bidSuits = []Suit{Spades, Diamonds, Hearts, Clubs}
+
+// LegalMoves lists every move playerID may currently make. Each candidate is
+// checked with ValidateMove, so the list never disagrees with the validator.
+// A discard is listed once, as a DiscardOption, rather than once per
+// combination of cards. Only a claim of every remaining trick is listed:
+// one that fails is refuted within a few plays, while checking a partial
+// claim often means searching the rest of the hand.
+func (g *Game) LegalMoves(playerID string) []LegalMove {
+	moves := g.LegalMovesExceptClaims(playerID)
+	if moves == nil {
+		return nil
+	}
+
+	if claim := (LegalMove{Type: MoveClaim, Payload: ClaimMove{}}); g.ValidateMove(playerID, claim.Type, claim.Payload) == nil {
+		moves = append(moves, claim)
+	}
+
+	return moves
+}
+
+// LegalMovesExceptClaims is LegalMoves without the claim, for callers that
+// never claim, such as bots and the turn clock, and so need not search the
+// rest of the hand.
+func (g *Game) LegalMovesExceptClaims(playerID string) []LegalMove {
+	p := g.GetPlayer(playerID)
+	if p == nil {
+		return nil
+	}
+
+	moves := []LegalMove{}
+	for _, m := range g.candidateMoves(p) {
+		if g.ValidateMove(playerID, m.Type, m.Payload) == nil {
+			moves = append(moves, m)
+		}
+	}
+
+	if len(p.Hand) >= discardCount && g.ValidateMove(playerID, MoveDiscard, p.Hand[:discardCount]) == nil {
+		moves = append(moves, LegalMove{
+			Type:    MoveDiscard,
+			Payload: DiscardOption{Count: discardCount, From: cloneCards(p.Hand)},
+		})
+	}
+
+	return moves
+}
+
+// candidateMoves enumerates every payload that could be legal for p in the
+// current phase; LegalMoves filters them through ValidateMove.
+func (g *Game) candidateMoves(p *Player) []LegalMove {
+	var c []LegalMove
+
+	switch g.Status {
+	case PhaseBidding:
+		for points := g.minBidPoints(); points <= 10; points++ {
+			for _, s := range bidSuits {
+				c = append(c, LegalMove{Type: MoveBid, Payload: Bid{Points: points, Suit: s}})
+			}
+			c = append(c, LegalMove{Type: MoveBid, Payload: Bid{Points: points, Suit: None, IsNoTrump: true}})
+		}
+		c = append(c, LegalMove{Type: MovePass}, LegalMove{Type: MoveDealMiss})
+
+	case PhaseEliminating:
+		for seat := range g.numSeats() {
+			c = append(c, LegalMove{Type: MoveEliminate, Payload: EliminateMove{Seat: seat}})
+		}
+
+	case PhaseExchanging:
+		for _, s := range bidSuits {
+			c = append(c, LegalMove{Type: MoveChangeTrump, Payload: ChangeTrumpMove{Suit: s}})
+		}
+		c = append(c, LegalMove{Type: MoveChangeTrump, Payload: ChangeTrumpMove{Suit: None, IsNoTrump: true}})
+
+	case PhaseCalling:
+		for _, card := range NewDeckFor(g.numSeats()) {
+			c = append(c, LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Card: &card}})
+		}
+		for _, mode := range []FriendMode{FriendFirstTrick, FriendMighty, FriendJoker} {
+			c = append(c, LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Mode: mode}})
+		}
+		c = append(c, LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{NoFriend: true}}, LegalMove{Type: MoveConcede})
+
+	case PhasePlaying:
+		c = append(c, LegalMove{Type: MoveConcede})
+		for _, card := range p.Hand {
+			c = append(c, LegalMove{Type: MovePlayCard, Payload: PlayCardMove{Card: card}})
+			if card.Rank == Joker {
+				for _, s := range bidSuits {
+					c = append(c, LegalMove{Type: MovePlayCard, Payload: PlayCardMove{Card: card, CalledSuit: s}})
+				}
+			}
+			if g.IsJokerCaller(card) {
+				c = append(c, LegalMove{Type: MovePlayCard, Payload: PlayCardMove{Card: card, CallJoker: true}})
+			}
+		}
+
+	case PhaseFinished:
+		c = append(c, LegalMove{Type: MovePlayAgain})
+		for n := 4; n <= 6; n++ {
+			if n != g.numSeats() {
+				c = append(c, LegalMove{Type: MoveChangeConfig, Payload: ChangeConfigMove{NumPlayers: n}})
+			}
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,248 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// moveKey identifies a move by type and payload. Discards are keyed by their
+// sorted cards, since the validator ignores their order.
+func moveKey(t MoveType, payload any) string {
+	if cards, ok := payload.([]Card); ok {
+		names := make([]string, len(cards))
+		for i, c := range cards {
+			names[i] = c.String()
+		}
+		slices.Sort(names)
+		payload = names
+	}
+
+	data, _ := json.Marshal(payload)
+
+	return string(t) + string(data)
+}
+
+// expandLegal turns the generated moves into concrete ones, listing every
+// discard a DiscardOption allows.
+func expandLegal(moves []LegalMove) []LegalMove {
+	var out []LegalMove
+	for _, m := range moves {
+		opt, ok := m.Payload.(DiscardOption)
+		if !ok {
+			out = append(out, m)
+			continue
+		}
+
+		for i := range opt.From {
+			for j := i + 1; j < len(opt.From); j++ {
+				for k := j + 1; k < len(opt.From); k++ {
+					out = append(out, LegalMove{Type: MoveDiscard, Payload: []Card{opt.From[i], opt.From[j], opt.From[k]}})
+				}
+			}
+		}
+	}
+
+	return out
+}
+
+// moveDomain is a superset of every move p could attempt, legal or not.
+func moveDomain(p *Player) []LegalMove {
+	suits := []Suit{Spades, Diamonds, Hearts, Clubs, None, "stars"}
+	var d []LegalMove
+
+	d = append(d, LegalMove{Type: MovePass}, LegalMove{Type: MoveDealMiss}, LegalMove{Type: MoveConcede})
+
+	for points := 0; points <= 11; points++ {
+		for _, s := range suits {
+			d = append(d,
+				LegalMove{Type: MoveBid, Payload: Bid{Points: points, Suit: s}},
+				LegalMove{Type: MoveBid, Payload: Bid{Points: points, Suit: s, IsNoTrump: true}})
+		}
+	}
+
+	for seat := -1; seat <= 6; seat++ {
+		d = append(d, LegalMove{Type: MoveEliminate, Payload: EliminateMove{Seat: seat}})
+	}
+
+	for _, s := range suits {
+		d = append(d,
+			LegalMove{Type: MoveChangeTrump, Payload: ChangeTrumpMove{Suit: s}},
+			LegalMove{Type: MoveChangeTrump, Payload: ChangeTrumpMove{Suit: s, IsNoTrump: true}})
+	}
+
+	// Partial claims are left out, as LegalMoves does not list them.
+	for _, n := range []int{-1, 0, 11} {
+		d = append(d, LegalMove{Type: MoveClaim, Payload: ClaimMove{Tricks: n}})
+	}
+
+	cards := append(NewDeck(), Card{Suit: "stars", Rank: Ace})
+	d = append(d,
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{NoFriend: true}},
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Card: &cards[0], NoFriend: true}},
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Card: &cards[0], Mode: FriendMighty}},
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Mode: FriendCard}},
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Mode: FriendJoker, NoFriend: true}},
+		LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Mode: "anyone"}})
+	for _, mode := range []FriendMode{FriendCard, FriendFirstTrick, FriendMighty, FriendJoker} {
+		d = append(d, LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Mode: mode}})
+	}
+
+	for _, c := range cards {
+		d = append(d, LegalMove{Type: MoveCallPartner, Payload: CallPartnerMove{Card: &c}})
+		for _, called := range []Suit{"", Spades, Diamonds, Hearts, Clubs} {
+			d = append(d,
+				LegalMove{Type: MovePlayCard, Payload: PlayCardMove{Card: c, CalledSuit: called}},
+				LegalMove{Type: MovePlayCard, Payload: PlayCardMove{Card: c, CalledSuit: called, CallJoker: true}})
+		}
+	}
+
+	// Every multiset of three held cards, plus one discard of a card not held.
+	h := p.Hand
+	for i := range h {
+		for j := i; j < len(h); j++ {
+			for k := j; k < len(h); k++ {
+				d = append(d, LegalMove{Type: MoveDiscard, Payload: []Card{h[i], h[j], h[k]}})
+			}
+		}
+	}
+	if len(h) >= 2 {
+		d = append(d, LegalMove{Type: MoveDiscard, Payload: []Card{{Suit: "stars", Rank: Ace}, h[0], h[1]}})
+	}
+
+	return d
+}
+
+// checkLegalMoves asserts that for every player the generated moves are
+// exactly the moves in the domain that ValidateMove accepts.
+func checkLegalMoves(t *testing.T, g *Game) {
+	t.Helper()
+
+	for _, p := range g.Players {
+		if p == nil {
+			continue
+		}
+
+		legal := map[string]bool{}
+		for _, m := range expandLegal(g.LegalMoves(p.ID)) {
+			if err := g.ValidateMove(p.ID, m.Type, m.Payload); err != nil {
+				t.Fatalf("%s: generated %s %+v rejected: %v", g.Status, m.Type, m.Payload, err)
+			}
+			legal[moveKey(m.Type, m.Payload)] = true
+		}
+
+		for _, m := range moveDomain(p) {
+			valid := g.ValidateMove(p.ID, m.Type, m.Payload) == nil
+			if valid && !legal[moveKey(m.Type, m.Payload)] {
+				t.Fatalf("%s: %s %+v passes ValidateMove but was not generated for %s", g.Status, m.Type, m.Payload, p.ID)
+			}
+		}
+	}
+}
+
+func TestLegalMovesMatchValidateMove(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{4, 5, 6} {
+		t.Run(fmt.Sprintf("%d players", n), func(t *testing.T) {
+			t.Parallel()
+
+			rng := rand.New(rand.NewPCG(uint64(n), 7))
+			cfg := DefaultConfig()
+			cfg.NumPlayers = n
+			g := NewWithConfig("legal", cfg)
+			for i := range n {
+				g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+			}
+			g.Start()
+
+			for step := 0; step < 300 && g.Status != PhaseFinished; step++ {
+				checkLegalMoves(t, g)
+
+				var options []LegalMove
+				var mover string
+				for _, p := range g.Players {
+					if p == nil {
+						continue
+					}
+					if moves := g.LegalMoves(p.ID); len(moves) > 0 {
+						options, mover = moves, p.ID
+						break
+					}
+				}
+				if len(options) == 0 {
+					t.Fatalf("%s: nobody has a legal move", g.Status)
+				}
+
+				m := options[rng.IntN(len(options))]
+				if opt, ok := m.Payload.(DiscardOption); ok {
+					m.Payload = opt.From[:opt.Count]
+				}
+				if err := g.ApplyMove(mover, m.Type, m.Payload); err != nil {
+					t.Fatalf("apply %s: %v", m.Type, err)
+				}
+			}
+
+			if g.Status != PhaseFinished {
+				t.Fatalf("random game did not finish, stuck in %s", g.Status)
+			}
+
+			moves := g.LegalMoves("p0")
+			if len(moves) == 0 || moves[0].Type != MovePlayAgain {
+				t.Fatalf("finished game must offer play_again, got %+v", moves)
+			}
+		})
+	}
+}
+
+func TestLegalMovesUnknownPlayer(t *testing.T) {
+	t.Parallel()
+
+	if moves := New("legal").LegalMoves("nobody"); moves != nil {
+		t.Fatalf("expected no moves, got %+v", moves)
+	}
+}
+
+func TestLegalMovesListClaims(t *testing.T) {
+	t.Parallel()
+
+	// p0 holds the Mighty and the ♠A, so may claim both tricks out of turn;
+	// p3 cannot claim.
+	g := claimFixture(2, claimHands([]Card{{Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Ace}})...)
+	claim := moveKey(MoveClaim, ClaimMove{})
+
+	has := func(moves []LegalMove) bool {
+		return slices.ContainsFunc(moves, func(m LegalMove) bool { return moveKey(m.Type, m.Payload) == claim })
+	}
+
+	if !has(g.LegalMoves("p0")) {
+		t.Fatalf("p0 must be offered a claim, got %+v", g.LegalMoves("p0"))
+	}
+	if has(g.LegalMoves("p3")) {
+		t.Fatal("p3 cannot claim every trick")
+	}
+	if has(g.LegalMovesExceptClaims("p0")) {
+		t.Fatal("LegalMovesExceptClaims must leave claims out")
+	}
+}
+
+func TestLegalMovesCallOnlyCardsInTheDeck(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame()
+	g.Config.NumPlayers = 4
+	g.Players = g.Players[:4]
+
+	for _, m := range g.LegalMoves(g.Players[g.Declarer].ID) {
+		if call, ok := m.Payload.(CallPartnerMove); ok && call.Card != nil && !slices.Contains(NewDeckFor(4), *call.Card) {
+			t.Fatalf("%s is not in a four-player deck", *call.Card)
+		}
+	}
+
+	if err := g.ValidateMove(g.Players[g.Declarer].ID, MoveCallPartner, CallPartnerMove{Card: &Card{Suit: Hearts, Rank: Two}}); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("calling ♥2 in a four-player game must be rejected, got %v", err)
+	}
+}
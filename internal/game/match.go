@@ -0,0 +1,99 @@
+package game
+
+import (
+	"cmp"
+	"slices"
+)
+
+// MatchConfig decides when a table stops dealing new rounds. Either limit
+// ends the match, whichever is reached first; with both zero the table plays
+// rounds for as long as everyone votes play_again.
+type MatchConfig struct {
+	TargetScore int `json:"target_score,omitempty"` // first total to reach this ends the match
+	Rounds      int `json:"rounds,omitempty"`       // number of scored rounds in the match
+}
+
+// Standing is one player's place in a completed match. Players are ranked by
+// total score, then by rounds won (a positive round score), then by their
+// best single round; players equal on all three share a rank.
+type Standing struct {
+	Rank      int    `json:"rank"`
+	PlayerID  string `json:"player_id"`
+	Name      string `json:"name"`
+	Seat      int    `json:"seat"` // -1 if the player is no longer seated
+	Total     int    `json:"total"`
+	RoundsWon int    `json:"rounds_won"`
+	BestRound int    `json:"best_round"`
+}
+
+// matchOver reports whether the round just scored ends the match.
+func (g *Game) matchOver() bool {
+	m := g.Config.Match
+	if m.Rounds > 0 && len(g.ScoreHistory) >= m.Rounds {
+		return true
+	}
+
+	if m.TargetScore > 0 {
+		for _, total := range g.matchTotals() {
+			if total >= m.TargetScore {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// standings ranks every player who has scored in this match. A seat taken
+// over under the split policy is ranked once, by its current occupant.
+func (g *Game) standings() []Standing {
+	into := g.splitSeats()
+	totals := g.matchTotals()
+
+	out := make([]Standing, 0, len(totals))
+	for id, total := range totals {
+		s := Standing{PlayerID: id, Seat: -1, Total: total}
+		if p := g.GetPlayer(id); p != nil {
+			s.Name, s.Seat = p.Name, p.Seat
+		}
+
+		played := false
+		for _, round := range g.ScoreHistory {
+			score, ok := round.Scores[id]
+			for from, to := range into {
+				if inherited, replaced := round.Scores[from]; replaced && to == id {
+					score, ok = score+inherited, true
+				}
+			}
+			if !ok {
+				continue
+			}
+			if score > 0 {
+				s.RoundsWon++
+			}
+			if !played || score > s.BestRound {
+				s.BestRound = score
+			}
+			played = true
+		}
+
+		out = append(out, s)
+	}
+
+	rank := func(a, b Standing) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), cmp.Compare(b.RoundsWon, a.RoundsWon), cmp.Compare(b.BestRound, a.BestRound))
+	}
+
+	slices.SortFunc(out, func(a, b Standing) int {
+		return cmp.Or(rank(a, b), cmp.Compare(a.Seat, b.Seat), cmp.Compare(a.PlayerID, b.PlayerID))
+	})
+
+	for i := range out {
+		out[i].Rank = i + 1
+		if i > 0 && rank(out[i-1], out[i]) == 0 {
+			out[i].Rank = out[i-1].Rank
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,160 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// playRound plays random legal moves until the round is scored.
+func playRound(t *testing.T, g *Game, rng *rand.Rand) {
+	t.Helper()
+
+	for step := 0; step < 300; step++ {
+		if g.Status == PhaseFinished || g.Status == PhaseMatchOver {
+			return
+		}
+
+		for _, p := range g.Players {
+			if p == nil {
+				continue
+			}
+
+			moves := g.LegalMovesExceptClaims(p.ID)
+			if len(moves) == 0 {
+				continue
+			}
+
+			m := moves[rng.IntN(len(moves))]
+			if opt, ok := m.Payload.(DiscardOption); ok {
+				m.Payload = opt.From[:opt.Count]
+			}
+
+			if err := g.ApplyMove(p.ID, m.Type, m.Payload); err != nil {
+				t.Fatalf("apply %s: %v", m.Type, err)
+			}
+
+			break
+		}
+	}
+
+	t.Fatalf("round did not finish, stuck in %s", g.Status)
+}
+
+func TestMatchEndsAfterRounds(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Match = MatchConfig{Rounds: 2}
+	g := NewWithConfig("match", cfg)
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("player %d", i))
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	playRound(t, g, rng)
+
+	if g.Status != PhaseFinished || g.Standings != nil {
+		t.Fatalf("first of two rounds must not end the match, got %s", g.Status)
+	}
+
+	for i := range 5 {
+		if err := g.ApplyMove(fmt.Sprintf("p%d", i), MovePlayAgain, nil); err != nil {
+			t.Fatalf("play_again: %v", err)
+		}
+	}
+
+	playRound(t, g, rng)
+
+	if g.Status != PhaseMatchOver {
+		t.Fatalf("expected match_over after two rounds, got %s", g.Status)
+	}
+
+	if len(g.Standings) != 5 || g.Standings[0].Rank != 1 {
+		t.Fatalf("expected five ranked standings, got %+v", g.Standings)
+	}
+
+	for i := 1; i < len(g.Standings); i++ {
+		if g.Standings[i].Total > g.Standings[i-1].Total {
+			t.Fatalf("standings out of order: %+v", g.Standings)
+		}
+	}
+
+	if err := g.ValidateMove("p0", MovePlayAgain, nil); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("play_again after the match must be rejected, got %v", err)
+	}
+
+	if moves := g.LegalMoves("p0"); len(moves) != 0 {
+		t.Fatalf("a finished match offers no moves, got %+v", moves)
+	}
+
+	if len(g.View("p0").HandSeeds) != len(g.HandSeeds) {
+		t.Fatal("every seed must be shown once the match is over")
+	}
+}
+
+func TestMatchOver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		match  MatchConfig
+		totals map[string]int
+		rounds int
+		want   bool
+	}{
+		{name: "open-ended", totals: map[string]int{"a": 99}, rounds: 50, want: false},
+		{name: "target reached", match: MatchConfig{TargetScore: 20}, totals: map[string]int{"a": 20, "b": -20}, rounds: 1, want: true},
+		{name: "target not reached", match: MatchConfig{TargetScore: 20}, totals: map[string]int{"a": 19, "b": -19}, rounds: 1, want: false},
+		{name: "rounds reached", match: MatchConfig{Rounds: 3}, totals: map[string]int{"a": 1}, rounds: 3, want: true},
+		{name: "rounds first", match: MatchConfig{TargetScore: 100, Rounds: 2}, totals: map[string]int{"a": 5}, rounds: 2, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := New("m")
+			g.Config.Match = tt.match
+			g.TotalScores = tt.totals
+			g.ScoreHistory = make([]RoundScore, tt.rounds)
+
+			if got := g.matchOver(); got != tt.want {
+				t.Fatalf("matchOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandingsTieBreaks(t *testing.T) {
+	t.Parallel()
+
+	g := New("standings")
+	for i, id := range []string{"a", "b", "c", "d", "e"} {
+		g.Players[i] = &Player{ID: id, Name: id, Seat: i}
+	}
+
+	g.ScoreHistory = []RoundScore{
+		{Scores: map[string]int{"a": 5, "b": -2, "c": 2, "d": -2, "e": -3}},
+		{Scores: map[string]int{"a": -5, "b": 6, "c": 2, "d": -2, "e": -2}},
+		{Scores: map[string]int{"a": 0, "b": -4, "c": -4, "d": 4, "e": 4}},
+	}
+	g.TotalScores = map[string]int{"a": 0, "b": 0, "c": 0, "d": 0, "e": 0}
+
+	got := g.standings()
+	want := []struct {
+		id   string
+		rank int
+	}{
+		// c won two rounds; b, a, d and e one each. b's best round beats
+		// a's, and d and e are equal on everything so they share a rank.
+		{"c", 1}, {"b", 2}, {"a", 3}, {"d", 4}, {"e", 4},
+	}
+
+	for i, w := range want {
+		if got[i].PlayerID != w.id || got[i].Rank != w.rank {
+			t.Fatalf("standing %d: got %s rank %d, want %s rank %d (%+v)", i, got[i].PlayerID, got[i].Rank, w.id, w.rank, got)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrNotation is returned for text that is not valid game notation. See
+// docs/notation.md for the format.
+var ErrNotation = errors.New("invalid notation")
+
+// suitLetters maps each suit to its one-letter notation, the first letter
+// Card.String uses.
+var suitLetters = map[Suit]string{Spades: "s", Diamonds: "d", Hearts: "h", Clubs: "c"}
+
+// ranks lists every rank a card token may carry.
+var ranks = []Rank{Ace, King, Queen, Jack, Ten, Nine, Eight, Seven, Six, Five, Four, Three, Two}
+
+// ParseSuit reads a suit letter: s, d, h or c.
+func ParseSuit(s string) (Suit, error) {
+	for suit, letter := range suitLetters {
+		if strings.EqualFold(s, letter) {
+			return suit, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: unknown suit %q", ErrNotation, s)
+}
+
+// ParseCard reads a card token as written by Card.String: a suit letter and
+// a rank, such as sA, d10 or c3, or Joker.
+func ParseCard(s string) (Card, error) {
+	if strings.EqualFold(s, string(Joker)) {
+		return Card{Suit: None, Rank: Joker}, nil
+	}
+
+	if len(s) < 2 {
+		return Card{}, fmt.Errorf("%w: unknown card %q", ErrNotation, s)
+	}
+
+	suit, err := ParseSuit(s[:1])
+	if err != nil {
+		return Card{}, fmt.Errorf("%w: unknown card %q", ErrNotation, s)
+	}
+
+	rank := Rank(strings.ToUpper(s[1:]))
+	if !slices.Contains(ranks, rank) {
+		return Card{}, fmt.Errorf("%w: unknown card %q", ErrNotation, s)
+	}
+
+	return Card{Suit: suit, Rank: rank}, nil
+}
+
+// ParseCards reads a space-separated list of card tokens, such as a hand.
+func ParseCards(s string) ([]Card, error) {
+	var cards []Card
+	for _, tok := range strings.Fields(s) {
+		c, err := ParseCard(tok)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+// FormatCards writes cards as space-separated tokens.
+func FormatCards(cards []Card) string {
+	toks := make([]string, len(cards))
+	for i, c := range cards {
+		toks[i] = c.String()
+	}
+
+	return strings.Join(toks, " ")
+}
+
+// formatBid writes a bid as its suit letter, or nt, and its points: s15,
+// nt14.
+func formatBid(b Bid) string {
+	if b.IsNoTrump {
+		return "nt" + strconv.Itoa(b.Points)
+	}
+
+	return suitLetters[b.Suit] + strconv.Itoa(b.Points)
+}
+
+// parseBid reads a bid written by formatBid.
+func parseBid(s string) (Bid, error) {
+	if rest, ok := strings.CutPrefix(s, "nt"); ok {
+		points, err := strconv.Atoi(rest)
+		if err != nil {
+			return Bid{}, fmt.Errorf("%w: bad bid %q", ErrNotation, s)
+		}
+
+		return Bid{Suit: None, Points: points, IsNoTrump: true}, nil
+	}
+
+	if len(s) < 2 {
+		return Bid{}, fmt.Errorf("%w: bad bid %q", ErrNotation, s)
+	}
+
+	suit, err := ParseSuit(s[:1])
+	if err != nil {
+		return Bid{}, err
+	}
+
+	points, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return Bid{}, fmt.Errorf("%w: bad bid %q", ErrNotation, s)
+	}
+
+	return Bid{Suit: suit, Points: points}, nil
+}
+
+// formatCall writes a friend call: the called card, or mighty, joker, first
+// or alone.
+func formatCall(m CallPartnerMove) string {
+	switch {
+	case m.NoFriend:
+		return "alone"
+	case m.Card != nil:
+		return m.Card.String()
+	case m.Mode == FriendFirstTrick:
+		return "first"
+	default:
+		return string(m.Mode)
+	}
+}
+
+// parseCall reads a friend call written by formatCall.
+func parseCall(s string) (CallPartnerMove, error) {
+	switch s {
+	case "alone":
+		return CallPartnerMove{NoFriend: true}, nil
+	case "first":
+		return CallPartnerMove{Mode: FriendFirstTrick}, nil
+	case string(FriendMighty):
+		return CallPartnerMove{Mode: FriendMighty}, nil
+	case "joker":
+		return CallPartnerMove{Mode: FriendJoker}, nil
+	}
+
+	c, err := ParseCard(s)
+	if err != nil {
+		return CallPartnerMove{}, fmt.Errorf("%w: bad friend call %q", ErrNotation, s)
+	}
+
+	return CallPartnerMove{Card: &c}, nil
+}
+
+// formatPlay writes a card played to a trick: the card, then ! for a Joker
+// call and @ with a suit letter for the suit called when leading the Joker.
+func formatPlay(m PlayCardMove) string {
+	s := m.Card.String()
+	if m.CallJoker {
+		s += "!"
+	}
+	if m.CalledSuit != "" && m.CalledSuit != None {
+		s += "@" + suitLetters[m.CalledSuit]
+	}
+
+	return s
+}
+
+// parsePlay reads a play written by formatPlay.
+func parsePlay(s string) (PlayCardMove, error) {
+	var m PlayCardMove
+
+	if card, suit, ok := strings.Cut(s, "@"); ok {
+		called, err := ParseSuit(suit)
+		if err != nil {
+			return m, err
+		}
+		m.CalledSuit = called
+		s = card
+	}
+
+	if card, ok := strings.CutSuffix(s, "!"); ok {
+		m.CallJoker = true
+		s = card
+	}
+
+	c, err := ParseCard(s)
+	if err != nil {
+		return m, err
+	}
+	m.Card = c
+
+	return m, nil
+}
@@ -0,0 +1,69 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCardReadsCardString(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range NewDeck() {
+		got, err := ParseCard(c.String())
+		if err != nil || got != c {
+			t.Fatalf("ParseCard(%q) = %v, %v; want %v", c.String(), got, err, c)
+		}
+	}
+
+	if c, err := ParseCard("SQ"); err != nil || c != (Card{Suit: Spades, Rank: Queen}) {
+		t.Fatalf("suit letters are case-insensitive, got %v, %v", c, err)
+	}
+
+	for _, bad := range []string{"", "s", "x10", "s1", "s11", "Jokers"} {
+		if _, err := ParseCard(bad); !errors.Is(err, ErrNotation) {
+			t.Fatalf("ParseCard(%q) should fail, got %v", bad, err)
+		}
+	}
+}
+
+func TestPlayTokensRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	plays := map[string]PlayCardMove{
+		"sA":      {Card: Card{Suit: Spades, Rank: Ace}},
+		"c3!":     {Card: Card{Suit: Clubs, Rank: Three}, CallJoker: true},
+		"Joker@h": {Card: Card{Suit: None, Rank: Joker}, CalledSuit: Hearts},
+	}
+
+	for tok, want := range plays {
+		got, err := parsePlay(tok)
+		if err != nil || got != want {
+			t.Fatalf("parsePlay(%q) = %+v, %v; want %+v", tok, got, err, want)
+		}
+
+		if s := formatPlay(want); s != tok {
+			t.Fatalf("formatPlay(%+v) = %q, want %q", want, s, tok)
+		}
+	}
+}
+
+func TestBidAndCallTokensRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for tok, want := range map[string]Bid{
+		"s13":  {Suit: Spades, Points: 13},
+		"nt15": {Suit: None, Points: 15, IsNoTrump: true},
+	} {
+		got, err := parseBid(tok)
+		if err != nil || got != want || formatBid(want) != tok {
+			t.Fatalf("bid %q: parsed %+v, %v; formats as %q", tok, got, err, formatBid(want))
+		}
+	}
+
+	for _, tok := range []string{"hK", "mighty", "joker", "first", "alone"} {
+		call, err := parseCall(tok)
+		if err != nil || formatCall(call) != tok {
+			t.Fatalf("call %q: parsed %+v, %v", tok, call, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// DecodePayload turns a move's JSON payload into the type ValidateMove and
+// ApplyMove expect. It accepts the legacy shapes clients still send, and is
+// shared by the API and by ledger replay.
+func DecodePayload(moveType MoveType, data []byte) (any, error) {
+	switch moveType {
+	case MoveBid:
+		var lastBid Bid
+		if err := json.Unmarshal(data, &lastBid); err != nil {
+			return nil, err
+		}
+
+		return lastBid, nil
+	case MoveDiscard:
+		var cards []Card
+		if err := json.Unmarshal(data, &cards); err != nil {
+			return nil, err
+		}
+
+		return cards, nil
+	case MoveChangeTrump:
+		var move ChangeTrumpMove
+		if err := json.Unmarshal(data, &move); err != nil {
+			return nil, err
+		}
+
+		return move, nil
+	case MoveEliminate:
+		var move EliminateMove
+		if err := json.Unmarshal(data, &move); err != nil {
+			return nil, err
+		}
+
+		return move, nil
+	case MoveCallPartner:
+		var move CallPartnerMove
+		if err := json.Unmarshal(data, &move); err != nil {
+			return nil, err
+		}
+
+		if move.Card == nil && move.Mode == "" && !move.NoFriend {
+			// Legacy shape: the payload is the card itself.
+			var card Card
+			if err := json.Unmarshal(data, &card); err == nil && card.Rank != "" {
+				return CallPartnerMove{Card: &card}, nil
+			}
+
+			return nil, errors.New("call_partner requires a card, a mode or no_friend")
+		}
+
+		return move, nil
+	case MovePlayCard:
+		// Attempt to unmarshal as PlayCardMove first
+		var playMove PlayCardMove
+		if err := json.Unmarshal(data, &playMove); err == nil && playMove.Card.Rank != "" {
+			return playMove, nil
+		}
+		// Fallback for raw Card payload
+		var card Card
+		if err := json.Unmarshal(data, &card); err == nil && card.Rank != "" {
+			return PlayCardMove{Card: card}, nil
+		}
+
+		return nil, errors.New("invalid play card payload: expected card or play_card_move object")
+	case MoveClaim:
+		var move ClaimMove
+		if len(data) > 0 && string(data) != "null" {
+			if err := json.Unmarshal(data, &move); err != nil {
+				return nil, err
+			}
+		}
+
+		return move, nil
+	case MoveChangeConfig:
+		var cm ChangeConfigMove
+		if err := json.Unmarshal(data, &cm); err != nil {
+			return nil, err
+		}
+		return cm, nil
+	case MovePass, MovePlayAgain, MoveDealMiss, MoveConcede:
+		return nil, nil // No payload needed for pass, play_again, deal_miss or concede
+	default:
+		var raw any
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+
+		return raw, nil
+	}
+}
@@ -0,0 +1,99 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubstitutePolicy is what happens to a seat whose player stays
+// disconnected past the grace period.
+type SubstitutePolicy string
+
+const (
+	// SubstitutePause stops the player's clock: the table waits for them on
+	// their turns until they come back.
+	SubstitutePause SubstitutePolicy = "pause"
+	// SubstituteAutoPlay makes the player's timeout move as soon as it is
+	// their turn.
+	SubstituteAutoPlay SubstitutePolicy = "auto_play"
+	// SubstituteForfeit takes the player out of the game as if they had
+	// left, forfeiting any hand in progress.
+	SubstituteForfeit SubstitutePolicy = "forfeit"
+)
+
+// PresenceConfig sets how long a disconnected player has to come back, in
+// seconds, and what happens to their seat if they do not.
+type PresenceConfig struct {
+	Grace  int              `json:"grace"`
+	Policy SubstitutePolicy `json:"policy"`
+}
+
+// DefaultPresence gives a dropped player a minute to reconnect before their
+// moves are made for them, so the table keeps playing without losing the
+// seat.
+func DefaultPresence() *PresenceConfig {
+	return &PresenceConfig{Grace: 60, Policy: SubstituteAutoPlay}
+}
+
+// Disconnect marks a player as having lost their last connection at now.
+func (g *Game) Disconnect(playerID string, now time.Time) error {
+	p := g.GetPlayer(playerID)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated", ErrInvalidMove, playerID)
+	}
+
+	p.IsConnected = false
+	p.DisconnectedAt = &now
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Reconnect marks a player as connected again, lifting any substitute
+// policy applied to their seat.
+func (g *Game) Reconnect(playerID string) error {
+	p := g.GetPlayer(playerID)
+	if p == nil {
+		return fmt.Errorf("%w: %s is not seated", ErrInvalidMove, playerID)
+	}
+
+	p.IsConnected = true
+	p.DisconnectedAt = nil
+	p.Substituted = false
+	g.dropTakeover(p.Seat)
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GraceDeadline is when a disconnected player's grace period runs out. It
+// reports false for a connected player, or when the game has no presence
+// config.
+func (g *Game) GraceDeadline(playerID string) (time.Time, bool) {
+	p := g.GetPlayer(playerID)
+	if p == nil || p.DisconnectedAt == nil || g.Config.Presence == nil {
+		return time.Time{}, false
+	}
+
+	return p.DisconnectedAt.Add(time.Duration(g.Config.Presence.Grace) * time.Second), true
+}
+
+// Substitute applies the pause or auto-play policy to a disconnected
+// player's seat. The forfeit policy is a Leave instead.
+func (g *Game) Substitute(playerID string) error {
+	p := g.GetPlayer(playerID)
+	if p == nil || p.IsConnected {
+		return fmt.Errorf("%w: %s is not a disconnected player", ErrInvalidMove, playerID)
+	}
+
+	p.Substituted = true
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubstitutePolicyDrivesTheClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		policy SubstitutePolicy
+		want   *time.Time
+	}{
+		{SubstitutePause, nil},
+		{SubstituteAutoPlay, &now},
+	} {
+		cfg := DefaultConfig()
+		cfg.Presence = &PresenceConfig{Grace: 30, Policy: tc.policy}
+		g := seatFive(cfg)
+		gone := g.Players[g.CurrentTurn].ID
+
+		if err := g.Substitute(gone); !errors.Is(err, ErrInvalidMove) {
+			t.Fatalf("a connected player cannot be substituted, got %v", err)
+		}
+
+		if err := g.Disconnect(gone, now); err != nil {
+			t.Fatalf("Disconnect: %v", err)
+		}
+
+		if grace, ok := g.GraceDeadline(gone); !ok || !grace.Equal(now.Add(30*time.Second)) {
+			t.Fatalf("expected the grace period to end 30s out, got %v", grace)
+		}
+
+		if err := g.Substitute(gone); err != nil {
+			t.Fatalf("Substitute: %v", err)
+		}
+
+		g.ArmTurnTimer(now)
+		if (g.TurnDeadline == nil) != (tc.want == nil) || (tc.want != nil && !g.TurnDeadline.Equal(*tc.want)) {
+			t.Fatalf("%s: expected deadline %v, got %v", tc.policy, tc.want, g.TurnDeadline)
+		}
+
+		if err := g.Reconnect(gone); err != nil {
+			t.Fatalf("Reconnect: %v", err)
+		}
+
+		g.ArmTurnTimer(now)
+		if p := g.GetPlayer(gone); p.Substituted || p.DisconnectedAt != nil || g.TurnDeadline == nil || !g.TurnDeadline.Equal(now.Add(30*time.Second)) {
+			t.Fatalf("%s: expected a reconnect to restore the clock, got %v", tc.policy, g.TurnDeadline)
+		}
+	}
+}
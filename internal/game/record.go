@@ -0,0 +1,698 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Record is a game written out move by move in the text notation of
+// docs/notation.md: headers describing the table and the result, then every
+// hand dealt, from the deal to its score.
+type Record struct {
+	Headers []Header
+	Hands   []HandRecord
+}
+
+// Header is one [Name "value"] line of a record.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// HandRecord is one dealt hand. A hand thrown in by a deal miss ends with
+// its bidding; the redeal is the next hand.
+type HandRecord struct {
+	Number    int
+	Dealer    int
+	Deal      []SeatCards // each seat's cards as dealt
+	Kitty     []Card      // the undealt cards
+	Bidding   []BidCall
+	Eliminate *Elimination
+	Trump     *ChangeTrumpMove // the declarer's trump change, if any
+	Discard   []Card
+	Call      *CallPartnerMove
+	Conceded  bool
+	Tricks    []TrickRecord
+	Claim     *ClaimRecord
+	Forfeit   *int // seat of a player who left mid-hand, ending it
+	Result    *HandResult
+}
+
+// SeatCards is the cards one seat was dealt or drew.
+type SeatCards struct {
+	Seat  int
+	Cards []Card
+}
+
+// BidCall is one turn of bidding: a bid, a pass or a deal miss.
+type BidCall struct {
+	Seat     int
+	Bid      *Bid // nil for a pass or a deal miss
+	DealMiss bool
+}
+
+// Elimination records a six-player declarer's elimination: the seat that sat
+// out, the two cards each other seat drew, and the kitty the declarer took.
+type Elimination struct {
+	Seat  int
+	Draws []SeatCards
+	Kitty []Card
+}
+
+// TrickRecord is one trick's plays in order and, once complete, its winner.
+type TrickRecord struct {
+	Plays  []PlayRecord
+	Winner int // -1 while the trick is open
+}
+
+// PlayRecord is one card played to a trick.
+type PlayRecord struct {
+	Seat int
+	Move PlayCardMove
+}
+
+// ClaimRecord is a claim that ended the hand.
+type ClaimRecord struct {
+	Seat   int
+	Tricks int
+}
+
+// HandResult is how a scored hand came out: made, failed, conceded or
+// forfeited, the
+// scoring cards the declarer's side took, and each seat's score.
+type HandResult struct {
+	Outcome string
+	Points  int
+	Scores  []SeatScore
+}
+
+// SeatScore is one seat's score.
+type SeatScore struct {
+	Seat  int
+	Score int
+}
+
+// Hand outcomes in a HandResult.
+const (
+	OutcomeMade      = "made"
+	OutcomeFailed    = "failed"
+	OutcomeConceded  = "conceded"
+	OutcomeForfeited = "forfeited"
+)
+
+// Header returns the value of the named header, or "" if it is absent.
+func (r *Record) Header(name string) string {
+	for _, h := range r.Headers {
+		if h.Name == name {
+			return h.Value
+		}
+	}
+
+	return ""
+}
+
+// String writes r in the text notation.
+func (r *Record) String() string {
+	var b strings.Builder
+
+	for _, h := range r.Headers {
+		fmt.Fprintf(&b, "[%s %s]\n", h.Name, strconv.Quote(h.Value))
+	}
+
+	for _, h := range r.Hands {
+		b.WriteString("\n")
+		h.write(&b)
+	}
+
+	return b.String()
+}
+
+func (h *HandRecord) write(b *strings.Builder) {
+	fmt.Fprintf(b, "hand %d dealer %d\n", h.Number, h.Dealer)
+
+	for _, sc := range h.Deal {
+		fmt.Fprintf(b, "seat %d: %s\n", sc.Seat, FormatCards(sc.Cards))
+	}
+	fmt.Fprintf(b, "kitty: %s\n", FormatCards(h.Kitty))
+
+	if len(h.Bidding) > 0 {
+		calls := make([]string, len(h.Bidding))
+		for i, c := range h.Bidding {
+			switch {
+			case c.DealMiss:
+				calls[i] = fmt.Sprintf("%d miss", c.Seat)
+			case c.Bid == nil:
+				calls[i] = fmt.Sprintf("%d pass", c.Seat)
+			default:
+				calls[i] = fmt.Sprintf("%d %s", c.Seat, formatBid(*c.Bid))
+			}
+		}
+		fmt.Fprintf(b, "bidding: %s\n", strings.Join(calls, ", "))
+	}
+
+	if e := h.Eliminate; e != nil {
+		fmt.Fprintf(b, "eliminate: %d\n", e.Seat)
+		for _, sc := range e.Draws {
+			fmt.Fprintf(b, "draw %d: %s\n", sc.Seat, FormatCards(sc.Cards))
+		}
+		fmt.Fprintf(b, "draw kitty: %s\n", FormatCards(e.Kitty))
+	}
+
+	if h.Trump != nil {
+		fmt.Fprintf(b, "trump: %s\n", formatSuitOrNT(*h.Trump))
+	}
+	if h.Discard != nil {
+		fmt.Fprintf(b, "discard: %s\n", FormatCards(h.Discard))
+	}
+	if h.Call != nil {
+		fmt.Fprintf(b, "call: %s\n", formatCall(*h.Call))
+	}
+	if h.Conceded {
+		b.WriteString("concede\n")
+	}
+
+	for i, t := range h.Tricks {
+		plays := make([]string, len(t.Plays))
+		for j, p := range t.Plays {
+			plays[j] = fmt.Sprintf("%d %s", p.Seat, formatPlay(p.Move))
+		}
+
+		fmt.Fprintf(b, "trick %d: %s", i+1, strings.Join(plays, ", "))
+		if t.Winner >= 0 {
+			fmt.Fprintf(b, " > %d", t.Winner)
+		}
+		b.WriteString("\n")
+	}
+
+	if c := h.Claim; c != nil {
+		fmt.Fprintf(b, "claim: %d %d\n", c.Seat, c.Tricks)
+	}
+
+	if h.Forfeit != nil {
+		fmt.Fprintf(b, "forfeit: %d\n", *h.Forfeit)
+	}
+
+	if res := h.Result; res != nil {
+		fmt.Fprintf(b, "result: %s %d; %s\n", res.Outcome, res.Points, formatSeatScores(res.Scores))
+	}
+}
+
+func formatSuitOrNT(m ChangeTrumpMove) string {
+	if m.IsNoTrump {
+		return "nt"
+	}
+
+	return suitLetters[m.Suit]
+}
+
+// formatSeatScores writes scores as "0 +8, 1 -2".
+func formatSeatScores(scores []SeatScore) string {
+	parts := make([]string, len(scores))
+	for i, s := range scores {
+		parts[i] = fmt.Sprintf("%d %+d", s.Seat, s.Score)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// ParseRecord reads a record in the text notation. Blank lines and lines
+// starting with ; are ignored.
+func ParseRecord(text string) (*Record, error) {
+	r := &Record{}
+	var h *HandRecord
+
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "["):
+			if h != nil {
+				err = fmt.Errorf("%w: header after the first hand", ErrNotation)
+				break
+			}
+			var hdr Header
+			hdr, err = parseHeader(line)
+			r.Headers = append(r.Headers, hdr)
+
+		case strings.HasPrefix(line, "hand "):
+			var number, dealer int
+			if _, serr := fmt.Sscanf(line, "hand %d dealer %d", &number, &dealer); serr != nil {
+				err = fmt.Errorf("%w: bad hand line", ErrNotation)
+				break
+			}
+			r.Hands = append(r.Hands, HandRecord{Number: number, Dealer: dealer})
+			h = &r.Hands[len(r.Hands)-1]
+
+		case h == nil:
+			err = fmt.Errorf("%w: %q before the first hand", ErrNotation, line)
+
+		default:
+			err = h.parseLine(line)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+
+	return r, nil
+}
+
+func parseHeader(line string) (Header, error) {
+	inner, ok := strings.CutSuffix(strings.TrimPrefix(line, "["), "]")
+	name, value, found := strings.Cut(inner, " ")
+	if !ok || !found || name == "" {
+		return Header{}, fmt.Errorf("%w: bad header %q", ErrNotation, line)
+	}
+
+	v, err := strconv.Unquote(value)
+	if err != nil {
+		return Header{}, fmt.Errorf("%w: bad header value %s", ErrNotation, value)
+	}
+
+	return Header{Name: name, Value: v}, nil
+}
+
+// parseLine reads one line of a hand's body.
+func (h *HandRecord) parseLine(line string) error {
+	if line == "concede" {
+		h.Conceded = true
+		return nil
+	}
+
+	label, rest, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("%w: unknown line %q", ErrNotation, line)
+	}
+	rest = strings.TrimSpace(rest)
+	fields := strings.Fields(label)
+
+	switch {
+	case len(fields) == 2 && fields[0] == "seat":
+		seat, err := parseSeat(fields[1])
+		if err != nil {
+			return err
+		}
+		cards, err := ParseCards(rest)
+		h.Deal = append(h.Deal, SeatCards{Seat: seat, Cards: cards})
+		return err
+
+	case label == "kitty":
+		cards, err := ParseCards(rest)
+		h.Kitty = cards
+		return err
+
+	case label == "bidding":
+		return h.parseBidding(rest)
+
+	case label == "eliminate":
+		seat, err := parseSeat(rest)
+		h.Eliminate = &Elimination{Seat: seat}
+		return err
+
+	case len(fields) == 2 && fields[0] == "draw":
+		if h.Eliminate == nil {
+			return fmt.Errorf("%w: draw without an elimination", ErrNotation)
+		}
+		cards, err := ParseCards(rest)
+		if err != nil {
+			return err
+		}
+		if fields[1] == "kitty" {
+			h.Eliminate.Kitty = cards
+			return nil
+		}
+		seat, err := parseSeat(fields[1])
+		h.Eliminate.Draws = append(h.Eliminate.Draws, SeatCards{Seat: seat, Cards: cards})
+		return err
+
+	case label == "trump":
+		if rest == "nt" {
+			h.Trump = &ChangeTrumpMove{Suit: None, IsNoTrump: true}
+			return nil
+		}
+		suit, err := ParseSuit(rest)
+		h.Trump = &ChangeTrumpMove{Suit: suit}
+		return err
+
+	case label == "discard":
+		cards, err := ParseCards(rest)
+		h.Discard = cards
+		return err
+
+	case label == "call":
+		call, err := parseCall(rest)
+		h.Call = &call
+		return err
+
+	case len(fields) == 2 && fields[0] == "trick":
+		if fields[1] != strconv.Itoa(len(h.Tricks)+1) {
+			return fmt.Errorf("%w: trick %s out of order", ErrNotation, fields[1])
+		}
+		t, err := parseTrick(rest)
+		h.Tricks = append(h.Tricks, t)
+		return err
+
+	case label == "claim":
+		var c ClaimRecord
+		if _, err := fmt.Sscanf(rest, "%d %d", &c.Seat, &c.Tricks); err != nil {
+			return fmt.Errorf("%w: bad claim %q", ErrNotation, rest)
+		}
+		h.Claim = &c
+		return nil
+
+	case label == "forfeit":
+		seat, err := parseSeat(rest)
+		h.Forfeit = &seat
+		return err
+
+	case label == "result":
+		res, err := parseResult(rest)
+		h.Result = res
+		return err
+	}
+
+	return fmt.Errorf("%w: unknown line %q", ErrNotation, line)
+}
+
+func parseSeat(s string) (int, error) {
+	seat, err := strconv.Atoi(s)
+	if err != nil || seat < 0 || seat > 5 {
+		return 0, fmt.Errorf("%w: bad seat %q", ErrNotation, s)
+	}
+
+	return seat, nil
+}
+
+// parseBidding reads calls such as "1 s13, 2 pass, 3 miss".
+func (h *HandRecord) parseBidding(s string) error {
+	for _, call := range strings.Split(s, ",") {
+		seatTok, what, ok := strings.Cut(strings.TrimSpace(call), " ")
+		if !ok {
+			return fmt.Errorf("%w: bad bid call %q", ErrNotation, call)
+		}
+
+		seat, err := parseSeat(seatTok)
+		if err != nil {
+			return err
+		}
+
+		c := BidCall{Seat: seat}
+		switch what {
+		case "pass":
+		case "miss":
+			c.DealMiss = true
+		default:
+			bid, err := parseBid(what)
+			if err != nil {
+				return err
+			}
+			c.Bid = &bid
+		}
+
+		h.Bidding = append(h.Bidding, c)
+	}
+
+	return nil
+}
+
+// parseTrick reads plays such as "0 sA, 1 s3 > 0".
+func parseTrick(s string) (TrickRecord, error) {
+	t := TrickRecord{Winner: -1}
+
+	plays, winner, won := strings.Cut(s, ">")
+	if won {
+		seat, err := parseSeat(strings.TrimSpace(winner))
+		if err != nil {
+			return t, err
+		}
+		t.Winner = seat
+	}
+
+	for _, play := range strings.Split(plays, ",") {
+		seatTok, card, ok := strings.Cut(strings.TrimSpace(play), " ")
+		if !ok {
+			return t, fmt.Errorf("%w: bad play %q", ErrNotation, play)
+		}
+
+		seat, err := parseSeat(seatTok)
+		if err != nil {
+			return t, err
+		}
+
+		m, err := parsePlay(card)
+		if err != nil {
+			return t, err
+		}
+
+		t.Plays = append(t.Plays, PlayRecord{Seat: seat, Move: m})
+	}
+
+	return t, nil
+}
+
+// parseResult reads "made 14; 0 +8, 1 +4, 2 -4".
+func parseResult(s string) (*HandResult, error) {
+	head, scores, ok := strings.Cut(s, ";")
+	res := &HandResult{}
+
+	if _, err := fmt.Sscanf(head, "%s %d", &res.Outcome, &res.Points); err != nil || !ok {
+		return nil, fmt.Errorf("%w: bad result %q", ErrNotation, s)
+	}
+
+	switch res.Outcome {
+	case OutcomeMade, OutcomeFailed, OutcomeConceded, OutcomeForfeited:
+	default:
+		return nil, fmt.Errorf("%w: unknown outcome %q", ErrNotation, res.Outcome)
+	}
+
+	for _, part := range strings.Split(scores, ",") {
+		var sc SeatScore
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d %d", &sc.Seat, &sc.Score); err != nil {
+			return nil, fmt.Errorf("%w: bad score %q", ErrNotation, part)
+		}
+		res.Scores = append(res.Scores, sc)
+	}
+
+	return res, nil
+}
+
+// NewRecord writes up the game a ledger records. It replays the ledger,
+// noting each hand as it is dealt and each move as it is applied.
+func NewRecord(l Ledger) (*Record, error) {
+	rec := &recorder{r: &Record{}, seats: make(map[string]int)}
+
+	g, err := replay(l, rec.observe)
+	if err != nil {
+		return nil, err
+	}
+
+	r := rec.r
+	scoring := g.Config.Scoring
+	if scoring == "" {
+		scoring = ScoringOfficial
+	}
+
+	r.Headers = append(r.Headers,
+		Header{Name: "Game", Value: g.ID},
+		Header{Name: "Date", Value: g.CreatedAt.UTC().Format("2006-01-02")},
+		Header{Name: "Players", Value: strconv.Itoa(g.numSeats())},
+		Header{Name: "RuleSet", Value: g.Config.Rules.Name},
+		Header{Name: "Scoring", Value: string(scoring)},
+	)
+
+	for seat, p := range g.Players {
+		if p != nil {
+			r.Headers = append(r.Headers, Header{Name: fmt.Sprintf("Seat%d", seat), Value: p.Name})
+		}
+	}
+
+	if len(g.ScoreHistory) > 0 {
+		r.Headers = append(r.Headers, Header{Name: "Result", Value: formatSeatScores(g.seatScores(g.TotalScores))})
+	}
+
+	return r, nil
+}
+
+// seatScores lists scores keyed by player ID by seat.
+func (g *Game) seatScores(byID map[string]int) []SeatScore {
+	var scores []SeatScore
+	for seat, p := range g.Players {
+		if p == nil {
+			continue
+		}
+		if score, ok := byID[p.ID]; ok {
+			scores = append(scores, SeatScore{Seat: seat, Score: score})
+		}
+	}
+
+	return scores
+}
+
+// recorder builds a Record while a ledger replays.
+type recorder struct {
+	r      *Record
+	hands  int            // hands dealt so far
+	rounds int            // rounds scored so far
+	seats  map[string]int // the last seat each player sat at
+}
+
+// hand is the hand being played, or nil before the first deal.
+func (rec *recorder) hand() *HandRecord {
+	if len(rec.r.Hands) == 0 {
+		return nil
+	}
+
+	return &rec.r.Hands[len(rec.r.Hands)-1]
+}
+
+func (rec *recorder) observe(g *Game, m LedgerMove, payload any) {
+	if h := rec.hand(); h != nil {
+		h.add(g, m, payload)
+	}
+
+	if len(g.ScoreHistory) > rec.rounds {
+		rec.rounds = len(g.ScoreHistory)
+		rec.hand().score(g, rec.seats)
+	}
+
+	// A player who forfeits has left by the time the hand is scored, so
+	// their seat is remembered from before.
+	for seat, p := range g.Players {
+		if p != nil {
+			rec.seats[p.ID] = seat
+		}
+	}
+
+	if len(g.HandSeeds) > rec.hands {
+		rec.hands = len(g.HandSeeds)
+		rec.deal(g)
+	}
+}
+
+// deal starts a hand from the cards g has just dealt.
+func (rec *recorder) deal(g *Game) {
+	h := HandRecord{Number: rec.hands, Dealer: g.Dealer, Kitty: cloneCards(g.Kitty)}
+	for seat, p := range g.Players {
+		if p != nil {
+			h.Deal = append(h.Deal, SeatCards{Seat: seat, Cards: cloneCards(p.Hand)})
+		}
+	}
+
+	rec.r.Hands = append(rec.r.Hands, h)
+}
+
+// add notes a move just applied to g.
+func (h *HandRecord) add(g *Game, m LedgerMove, payload any) {
+	switch m.Type {
+	case MoveBid:
+		bid := payload.(Bid)
+		bid.PlayerID = ""
+		if bid.IsNoTrump {
+			bid.Suit = None
+		}
+		h.Bidding = append(h.Bidding, BidCall{Seat: m.Seat, Bid: &bid})
+
+	case MovePass:
+		h.Bidding = append(h.Bidding, BidCall{Seat: m.Seat})
+
+	case MoveDealMiss:
+		h.Bidding = append(h.Bidding, BidCall{Seat: m.Seat, DealMiss: true})
+
+	case MoveEliminate:
+		// Each seat drew the last two cards of its hand, and the declarer
+		// took the new kitty onto the end of theirs.
+		e := &Elimination{Seat: payload.(EliminateMove).Seat}
+		for seat := g.nextSeat(g.Declarer); seat != g.Declarer; seat = g.nextSeat(seat) {
+			hand := g.Players[seat].Hand
+			e.Draws = append(e.Draws, SeatCards{Seat: seat, Cards: cloneCards(hand[len(hand)-2:])})
+		}
+		hand := g.Players[g.Declarer].Hand
+		e.Kitty = cloneCards(hand[len(hand)-kittySizeFor(5):])
+		h.Eliminate = e
+
+	case MoveChangeTrump:
+		move := payload.(ChangeTrumpMove)
+		if move.IsNoTrump {
+			move.Suit = None
+		}
+		h.Trump = &move
+
+	case MoveDiscard:
+		h.Discard = cloneCards(payload.([]Card))
+
+	case MoveCallPartner:
+		move := payload.(CallPartnerMove)
+		switch {
+		case move.NoFriend:
+			move = CallPartnerMove{NoFriend: true}
+		case move.Card != nil:
+			move = CallPartnerMove{Card: move.Card}
+		}
+		h.Call = &move
+
+	case MoveConcede:
+		h.Conceded = true
+
+	case MovePlayCard:
+		if n := len(h.Tricks); n == 0 || h.Tricks[n-1].Winner >= 0 {
+			h.Tricks = append(h.Tricks, TrickRecord{Winner: -1})
+		}
+
+		idx := len(h.Tricks) - 1
+		t := &h.Tricks[idx]
+		move := payload.(PlayCardMove)
+		if move.CalledSuit == None {
+			move.CalledSuit = ""
+		}
+		t.Plays = append(t.Plays, PlayRecord{Seat: m.Seat, Move: move})
+
+		if len(t.Plays) == g.numActive() {
+			t.Winner = g.Tricks[idx].Winner
+		}
+
+	case MoveClaim:
+		h.Claim = &ClaimRecord{Seat: m.Seat, Tricks: g.Claim.Tricks}
+
+	case MoveLeave:
+		// Leaving between hands changes no hand; leaving during one
+		// forfeits it.
+		if h.Result == nil {
+			seat := m.Seat
+			h.Forfeit = &seat
+		}
+	}
+}
+
+// score notes the result of the hand g has just scored.
+func (h *HandRecord) score(g *Game, seats map[string]int) {
+	rs := g.ScoreHistory[len(g.ScoreHistory)-1]
+
+	var scores []SeatScore
+	for id, score := range rs.Scores {
+		if p := g.GetPlayer(id); p != nil {
+			scores = append(scores, SeatScore{Seat: p.Seat, Score: score})
+		} else if seat, ok := seats[id]; ok {
+			scores = append(scores, SeatScore{Seat: seat, Score: score})
+		}
+	}
+	slices.SortFunc(scores, func(a, b SeatScore) int { return a.Seat - b.Seat })
+
+	res := &HandResult{Outcome: OutcomeFailed, Points: rs.P, Scores: scores}
+	switch {
+	case rs.ForfeitedBy != "":
+		res.Outcome = OutcomeForfeited
+	case rs.Conceded:
+		res.Outcome = OutcomeConceded
+	case rs.Success:
+		res.Outcome = OutcomeMade
+	}
+
+	h.Result = res
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordRoundTripsThroughText(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{4, 5, 6} {
+		for seed := range uint64(10) {
+			g, l := recordGame(t, n, seed, 500)
+
+			rec, err := NewRecord(l)
+			if err != nil {
+				t.Fatalf("%d players, seed %d: %v", n, seed, err)
+			}
+
+			if len(rec.Hands) != len(g.HandSeeds) {
+				t.Fatalf("%d hands recorded, %d dealt", len(rec.Hands), len(g.HandSeeds))
+			}
+
+			text := rec.String()
+			parsed, err := ParseRecord(text)
+			if err != nil {
+				t.Fatalf("%d players, seed %d: parse: %v\n%s", n, seed, err, text)
+			}
+
+			if !reflect.DeepEqual(parsed, rec) {
+				t.Fatalf("%d players, seed %d: record changed through text:\n%s\n%s", n, seed, text, parsed)
+			}
+
+			if again := parsed.String(); again != text {
+				t.Fatalf("formatting is not stable:\n%s\n%s", text, again)
+			}
+		}
+	}
+}
+
+func TestRecordFollowsTheHand(t *testing.T) {
+	t.Parallel()
+
+	for seed := range uint64(20) {
+		g, l := recordGame(t, 5, seed, 500)
+		if g.Status != PhaseFinished || g.Conceded || g.Claim != nil {
+			continue
+		}
+
+		rec, err := NewRecord(l)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+
+		h := rec.Hands[len(rec.Hands)-1]
+		if len(h.Tricks) != 10 || h.Discard == nil || h.Call == nil || h.Result == nil {
+			t.Fatalf("seed %d: incomplete hand: %+v", seed, h)
+		}
+
+		for i, tr := range h.Tricks {
+			if tr.Winner != g.Tricks[i].Winner || len(tr.Plays) != 5 {
+				t.Fatalf("seed %d: trick %d recorded as %+v", seed, i+1, tr)
+			}
+		}
+
+		if rec.Header("Players") != "5" || rec.Header("Seat0") != "player p0" || rec.Header("Result") == "" {
+			t.Fatalf("seed %d: headers %+v", seed, rec.Headers)
+		}
+
+		return
+	}
+
+	t.Fatal("no seed played a hand out")
+}
+
+func TestParseRecordReportsTheLine(t *testing.T) {
+	t.Parallel()
+
+	const text = `[Game "g1"]
+[Seat0 "Kim \"Ace\" Lee"]
+
+hand 1 dealer 0
+seat 0: sA sK
+kitty: c2 c3 Joker
+bidding: 1 s13, 2 pass, 3 nt14
+trick 1: 0 sA, 1 sX > 0
+`
+
+	_, err := ParseRecord(text)
+	if !errors.Is(err, ErrNotation) || !strings.HasPrefix(err.Error(), "line 8:") {
+		t.Fatalf("expected an error on line 8, got %v", err)
+	}
+
+	rec, err := ParseRecord(strings.Replace(text, "sX", "s2", 1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if rec.Header("Seat0") != `Kim "Ace" Lee` {
+		t.Fatalf("quoted header read as %q", rec.Header("Seat0"))
+	}
+
+	h := rec.Hands[0]
+	if len(h.Bidding) != 3 || h.Bidding[1].Bid != nil || !h.Bidding[2].Bid.IsNoTrump || h.Tricks[0].Winner != 0 {
+		t.Fatalf("unexpected hand: %+v", h)
+	}
+}
+
+func TestEveryHandLineRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	const text = `[Game "g2"]
+[Result "0 +6, 1 -6"]
+
+hand 1 dealer 4
+seat 0: sA sK
+seat 1: c2 c3
+kitty: d2 d3 Joker
+bidding: 0 pass, 1 miss
+
+hand 2 dealer 4
+seat 0: sA c2
+seat 1: sK c3
+kitty: d2 d3 Joker
+bidding: 0 h14, 1 pass
+trump: nt
+discard: d2 d3 c2
+call: first
+concede
+result: conceded 0; 0 -6, 1 +6
+
+hand 3 dealer 0
+seat 0: sA c2
+seat 1: sK c3
+kitty: d2 d3 Joker
+bidding: 1 c13, 0 pass
+discard: d2 d3 c3
+call: alone
+trick 1: 1 c3!, 0 Joker > 1
+claim: 1 9
+result: made 16; 0 -6, 1 +6
+`
+
+	rec, err := ParseRecord(text)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if got := rec.String(); got != text {
+		t.Fatalf("round trip changed the text:\n%s", got)
+	}
+
+	if h := rec.Hands[1]; !h.Conceded || h.Trump == nil || !h.Trump.IsNoTrump || h.Call.Mode != FriendFirstTrick {
+		t.Fatalf("hand 2 read as %+v", h)
+	}
+
+	if h := rec.Hands[2]; h.Claim == nil || *h.Claim != (ClaimRecord{Seat: 1, Tricks: 9}) || !h.Tricks[0].Plays[0].Move.CallJoker {
+		t.Fatalf("hand 3 read as %+v", h)
+	}
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MoveJoin is the ledger entry for a player taking a seat. It is recorded
+// alongside moves but is not itself a game move.
+const MoveJoin MoveType = "join"
+
+// MoveLeave is the ledger entry for a seat given up: a player leaving, or a
+// bot the owner removed.
+const MoveLeave MoveType = "leave"
+
+// ErrReplayDiverged is returned when replaying a ledger does not reproduce
+// the recorded game.
+var ErrReplayDiverged = errors.New("replay diverged from ledger")
+
+// LedgerMove is one recorded join or move, in the order it was applied.
+type LedgerMove struct {
+	Version  int64           `json:"version"`
+	PlayerID string          `json:"player_id"`
+	Seat     int             `json:"seat"`
+	Type     MoveType        `json:"move_type"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// Ledger is the durable record of a game: how it was created, the seed of
+// every hand dealt, and every join and move. Status and Version are the last
+// recorded for the game, which only a quarantine sets apart from its moves.
+type Ledger struct {
+	ID        string
+	Config    GameConfig
+	DealSeed  *Seed
+	HandSeeds []Seed
+	CreatedAt time.Time
+	Moves     []LedgerMove
+	Status    Phase
+	Version   int64
+}
+
+// SeatPlayer puts a new player in seat and deals once every seat is taken.
+// The first player seated becomes the table's owner.
+func (g *Game) SeatPlayer(seat int, playerID, name string) {
+	g.seat(&Player{ID: playerID, Name: name, Seat: seat, IsConnected: true, Hand: []Card{}, Points: []Card{}})
+}
+
+// SeatBot puts a bot of the given difficulty in seat.
+func (g *Game) SeatBot(seat int, playerID, name, level string) {
+	g.seat(&Player{ID: playerID, Name: name, Seat: seat, IsConnected: true, Bot: level, Hand: []Card{}, Points: []Card{}})
+}
+
+// seat fills p's seat. A full table deals its first hand; a seat filled
+// between hands waits for the play-again vote to deal the next.
+func (g *Game) seat(p *Player) {
+	g.Players[p.Seat] = p
+	delete(g.LeftSeats, p.Seat)
+	if g.Owner == "" && p.Bot == "" {
+		g.Owner = p.ID
+	}
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	if g.Status == PhaseWaiting && g.IsFull() {
+		g.Start()
+	}
+}
+
+// UnseatPlayer frees seat while the table is still waiting for players.
+func (g *Game) UnseatPlayer(seat int) error {
+	if g.Status != PhaseWaiting {
+		return fmt.Errorf("%w: seats can only be given up before the deal", ErrInvalidMove)
+	}
+
+	if seat < 0 || seat >= len(g.Players) || g.Players[seat] == nil {
+		return fmt.Errorf("%w: seat %d is empty", ErrInvalidMove, seat)
+	}
+
+	g.vacate(seat)
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Replay rebuilds a game from its ledger. Every move goes back through
+// ValidateMove and ApplyMove, and each hand is dealt from its recorded seed,
+// so the result matches the game the ledger was written from.
+func Replay(l Ledger) (*Game, error) {
+	return replay(l, nil)
+}
+
+// replay is Replay, calling observe after each join, leave and move is
+// applied with the decoded payload, or nil for a join or leave.
+func replay(l Ledger, observe func(g *Game, m LedgerMove, payload any)) (*Game, error) {
+	var opts []Option
+	if l.DealSeed != nil {
+		opts = append(opts, WithSeed(*l.DealSeed))
+	}
+
+	g := NewWithConfig(l.ID, l.Config, opts...)
+	g.CreatedAt = l.CreatedAt
+	g.replaySeeds = append([]Seed(nil), l.HandSeeds...)
+
+	for _, m := range l.Moves {
+		switch m.Type {
+		case MoveJoin:
+			var join struct {
+				Name string `json:"name"`
+				Bot  string `json:"bot"`
+			}
+			_ = json.Unmarshal(m.Payload, &join)
+
+			if m.Seat < 0 || m.Seat >= g.numSeats() || g.Players[m.Seat] != nil {
+				return nil, fmt.Errorf("%w: version %d: seat %d unavailable", ErrReplayDiverged, m.Version, m.Seat)
+			}
+
+			if join.Bot != "" {
+				g.SeatBot(m.Seat, m.PlayerID, join.Name, join.Bot)
+			} else {
+				g.SeatPlayer(m.Seat, m.PlayerID, join.Name)
+			}
+
+			if observe != nil {
+				observe(g, m, nil)
+			}
+
+			continue
+
+		case MoveTakeover:
+			var takeover struct {
+				Name string `json:"name"`
+				From string `json:"from"`
+			}
+			_ = json.Unmarshal(m.Payload, &takeover)
+
+			if !g.heldBy(m.Seat, takeover.From) || g.GetPlayer(m.PlayerID) != nil {
+				return nil, fmt.Errorf("%w: version %d: %s cannot take over seat %d", ErrReplayDiverged, m.Version, m.PlayerID, m.Seat)
+			}
+
+			g.takeOver(m.Seat, m.PlayerID, takeover.Name)
+			g.Version++
+
+			if observe != nil {
+				observe(g, m, nil)
+			}
+
+			continue
+
+		case MoveSpectating:
+			var spectating struct {
+				Open bool `json:"open"`
+			}
+			_ = json.Unmarshal(m.Payload, &spectating)
+
+			g.SetSpectating(spectating.Open)
+
+			if observe != nil {
+				observe(g, m, nil)
+			}
+
+			continue
+
+		case MoveLeave:
+			if p := g.GetPlayer(m.PlayerID); p == nil || p.Seat != m.Seat {
+				return nil, fmt.Errorf("%w: version %d: %s is not in seat %d", ErrReplayDiverged, m.Version, m.PlayerID, m.Seat)
+			}
+
+			if err := g.Leave(m.PlayerID); err != nil {
+				return nil, fmt.Errorf("%w: version %d: %w", ErrReplayDiverged, m.Version, err)
+			}
+
+			if observe != nil {
+				observe(g, m, nil)
+			}
+
+			continue
+		}
+
+		payload, err := DecodePayload(m.Type, m.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("%w: version %d: %w", ErrReplayDiverged, m.Version, err)
+		}
+
+		if err := g.ValidateMove(m.PlayerID, m.Type, payload); err != nil {
+			return nil, fmt.Errorf("%w: version %d: %w", ErrReplayDiverged, m.Version, err)
+		}
+
+		if err := g.ApplyMove(m.PlayerID, m.Type, payload); err != nil {
+			return nil, fmt.Errorf("%w: version %d: %w", ErrReplayDiverged, m.Version, err)
+		}
+
+		if observe != nil {
+			observe(g, m, payload)
+		}
+	}
+
+	if len(g.replaySeeds) != 0 || len(g.HandSeeds) != len(l.HandSeeds) {
+		return nil, fmt.Errorf("%w: dealt %d hands, ledger records %d", ErrReplayDiverged, len(g.HandSeeds), len(l.HandSeeds))
+	}
+
+	// Rejoins bump the version without a ledger entry, so the recorded
+	// version can run ahead of the replayed one.
+	if n := len(l.Moves); n > 0 && l.Moves[n-1].Version > g.Version {
+		g.Version = l.Moves[n-1].Version
+	}
+
+	// A quarantined game was never given the move that broke it; it stays
+	// quarantined at the version the quarantine recorded.
+	if l.Status == PhaseCorrupted {
+		g.Status = PhaseCorrupted
+		g.Version = max(g.Version+1, l.Version)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,195 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+// snapshot serializes g without its wall-clock timestamps, which a replay
+// cannot reproduce.
+func snapshot(t *testing.T, g *Game) string {
+	t.Helper()
+
+	c := *g
+	c.UpdatedAt = time.Time{}
+
+	data, err := json.Marshal(&c)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	return string(data)
+}
+
+// recordGame seats n players and plays up to steps random legal moves,
+// writing each join and move to a ledger the way the service does.
+func recordGame(t *testing.T, n int, seed uint64, steps int, opts ...Option) (*Game, Ledger) {
+	t.Helper()
+
+	rng := rand.New(rand.NewPCG(seed, 11))
+	cfg := DefaultConfig()
+	cfg.NumPlayers = n
+	g := NewWithConfig("replay", cfg, opts...)
+	l := Ledger{ID: g.ID, Config: cfg, DealSeed: g.DealSeed, CreatedAt: g.CreatedAt}
+
+	for seat := range n {
+		id := fmt.Sprintf("p%d", seat)
+		g.SeatPlayer(seat, id, "player "+id)
+		l.Moves = append(l.Moves, LedgerMove{Version: g.Version, PlayerID: id, Seat: seat, Type: MoveJoin, Payload: json.RawMessage(`{"name":"player ` + id + `"}`)})
+	}
+
+	for step := 0; step < steps && g.Status != PhaseFinished; step++ {
+		for _, p := range g.Players {
+			if p == nil {
+				continue
+			}
+
+			moves := g.LegalMovesExceptClaims(p.ID)
+			if len(moves) == 0 {
+				continue
+			}
+
+			m := moves[rng.IntN(len(moves))]
+			if opt, ok := m.Payload.(DiscardOption); ok {
+				m.Payload = opt.From[:opt.Count]
+			}
+
+			var raw json.RawMessage
+			if m.Payload != nil {
+				data, err := json.Marshal(m.Payload)
+				if err != nil {
+					t.Fatalf("marshal payload: %v", err)
+				}
+				raw = data
+			}
+
+			if err := g.ApplyMove(p.ID, m.Type, m.Payload); err != nil {
+				t.Fatalf("apply %s: %v", m.Type, err)
+			}
+
+			l.Moves = append(l.Moves, LedgerMove{Version: g.Version, PlayerID: p.ID, Seat: p.Seat, Type: m.Type, Payload: raw})
+
+			break
+		}
+	}
+
+	l.HandSeeds = append([]Seed(nil), g.HandSeeds...)
+
+	return g, l
+}
+
+func TestReplayRebuildsGame(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{4, 5, 6} {
+		for _, seeded := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%d players seeded=%v", n, seeded), func(t *testing.T) {
+				t.Parallel()
+
+				var opts []Option
+				if seeded {
+					opts = append(opts, WithSeed(NewSeed()))
+				}
+
+				want, ledger := recordGame(t, n, uint64(n), 300, opts...)
+				if want.Status != PhaseFinished {
+					t.Fatalf("recorded game stuck in %s", want.Status)
+				}
+
+				got, err := Replay(ledger)
+				if err != nil {
+					t.Fatalf("Replay: %v", err)
+				}
+
+				if snapshot(t, got) != snapshot(t, want) {
+					t.Fatal("replayed game differs from the recorded one")
+				}
+			})
+		}
+	}
+}
+
+func TestReplayMidHand(t *testing.T) {
+	t.Parallel()
+
+	want, ledger := recordGame(t, 5, 3, 12)
+	if want.Status == PhaseFinished {
+		t.Fatal("game finished too early to test a partial replay")
+	}
+
+	got, err := Replay(ledger)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatalf("replay of a hand in progress differs: got %s at version %d, want %s at %d", got.Status, got.Version, want.Status, want.Version)
+	}
+}
+
+func TestReplaySeatsAndRemovesBots(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	want := NewWithConfig("bots", cfg)
+	l := Ledger{ID: want.ID, Config: cfg, CreatedAt: want.CreatedAt}
+
+	want.SeatPlayer(0, "p0", "P0")
+	l.Moves = append(l.Moves, LedgerMove{Version: want.Version, PlayerID: "p0", Seat: 0, Type: MoveJoin, Payload: json.RawMessage(`{"name":"P0"}`)})
+	want.SeatBot(1, "bot-1", "Bot 2 (easy)", "easy")
+	l.Moves = append(l.Moves, LedgerMove{Version: want.Version, PlayerID: "bot-1", Seat: 1, Type: MoveJoin, Payload: json.RawMessage(`{"name":"Bot 2 (easy)","bot":"easy"}`)})
+	want.SeatBot(2, "bot-2", "Bot 3 (hard)", "hard")
+	l.Moves = append(l.Moves, LedgerMove{Version: want.Version, PlayerID: "bot-2", Seat: 2, Type: MoveJoin, Payload: json.RawMessage(`{"name":"Bot 3 (hard)","bot":"hard"}`)})
+	if err := want.UnseatPlayer(1); err != nil {
+		t.Fatalf("unseat: %v", err)
+	}
+	l.Moves = append(l.Moves, LedgerMove{Version: want.Version, PlayerID: "bot-1", Seat: 1, Type: MoveLeave, Payload: json.RawMessage(`null`)})
+
+	got, err := Replay(l)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatal("replayed table differs from the recorded one")
+	}
+
+	if got.Owner != "p0" || got.Players[1] != nil || got.Players[2].Bot != "hard" {
+		t.Fatalf("unexpected table: owner %q, seats %v %+v", got.Owner, got.Players[1], got.Players[2])
+	}
+}
+
+func TestReplayRejectsTamperedLedger(t *testing.T) {
+	t.Parallel()
+
+	_, ledger := recordGame(t, 5, 5, 300)
+
+	tests := []struct {
+		name   string
+		tamper func(l *Ledger)
+	}{
+		{name: "wrong seed", tamper: func(l *Ledger) { l.HandSeeds[0] = NewSeed() }},
+		{name: "missing seed", tamper: func(l *Ledger) { l.HandSeeds = nil }},
+		{name: "out of turn", tamper: func(l *Ledger) { l.Moves[5].PlayerID = "p3" }},
+		{name: "seat taken twice", tamper: func(l *Ledger) { l.Moves[1].Seat = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := ledger
+			l.HandSeeds = append([]Seed(nil), ledger.HandSeeds...)
+			l.Moves = append([]LedgerMove(nil), ledger.Moves...)
+			tt.tamper(&l)
+
+			if _, err := Replay(l); !errors.Is(err, ErrReplayDiverged) {
+				t.Fatalf("expected ErrReplayDiverged, got %v", err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 )
 
@@ -37,6 +38,13 @@ const (
 	PowerBase = 0
 )
 
+// Contract raises charged for a declarer trump change: one level between two
+// suits, two when switching to or from no-trump.
+const (
+	TrumpChangeRaise        = 1
+	TrumpChangeRaiseNoTrump = 2
+)
+
 // ValidateMove checks if a move is valid for the current game state.
 func (g *Game) ValidateMove(playerID string, moveType MoveType, payload any) error {
 	// 1. Check if player is in the game
@@ -45,8 +53,12 @@ func (g *Game) ValidateMove(playerID string, moveType MoveType, payload any) err
 		return fmt.Errorf("%w: player not in game", ErrInvalidMove)
 	}
 
+	if g.Status == PhaseMatchOver {
+		return fmt.Errorf("%w: match is over", ErrInvalidMove)
+	}
+
 	// 2. Check turn
-	if g.Status == PhasePlaying && g.Players[g.CurrentTurn].ID != playerID {
+	if g.Status == PhasePlaying && moveType != MoveClaim && g.Players[g.CurrentTurn].ID != playerID {
 		return fmt.Errorf("%w: not your turn", ErrInvalidMove)
 	}
 	// For other phases, checking turn depends on the phase logic (e.g. bidding is rotational)
@@ -56,8 +68,18 @@ func (g *Game) ValidateMove(playerID string, moveType MoveType, payload any) err
 		return g.validateBid(p, payload)
 	case MovePass:
 		return g.validatePass(p)
+	case MoveEliminate:
+		return g.validateEliminate(p, payload)
 	case MoveDiscard:
 		return g.validateDiscard(p, payload)
+	case MoveChangeTrump:
+		return g.validateChangeTrump(p, payload)
+	case MoveDealMiss:
+		return g.validateDealMiss(p)
+	case MoveConcede:
+		return g.validateConcede(p)
+	case MoveClaim:
+		return g.validateClaim(p, payload)
 	case MoveCallPartner:
 		return g.validateCallPartner(p, payload)
 	case MovePlayCard:
@@ -131,13 +153,80 @@ func (g *Game) validateBid(p *Player, payload any) error {
 		}
 	}
 
-	// Must be higher than current bid
+	// Must outrank the current bid under the configured bid order
 	if g.CurrentBid != nil {
-		if bid.Points <= g.CurrentBid.Points {
-			return fmt.Errorf("%w: bid must be strictly higher than current bid", ErrInvalidMove)
+		if g.CompareBids(bid, *g.CurrentBid) <= 0 {
+			return fmt.Errorf("%w: bid must outrank the current bid", ErrInvalidMove)
+		}
+	}
+
+	return nil
+}
+
+// DealMissValue scores a hand for a deal-miss call under the configured
+// weights. Trump is not chosen yet, so the Mighty is the no-trump one (♠A).
+func (g *Game) DealMissValue(hand []Card) int {
+	cfg := g.Config.DealMiss
+	if cfg == nil {
+		return 0
+	}
+
+	value := 0
+	for _, c := range hand {
+		switch {
+		case c.Rank == Joker:
+			value += cfg.JokerWeight
+		case c == g.rules().mightyUnder(""):
+			value += cfg.MightyWeight
+		case c.IsPointCard():
+			value += cfg.PointWeight
+		}
+	}
+
+	return value
+}
+
+// validateDealMiss
+// Payload: none. A player may throw in their hand during bidding, before they
+// have bid or passed, when it is valued at or below the configured threshold.
+func (g *Game) validateDealMiss(p *Player) error {
+	if g.Status != PhaseBidding {
+		return fmt.Errorf("%w: not in bidding phase", ErrInvalidMove)
+	}
+
+	if g.Config.DealMiss == nil {
+		return fmt.Errorf("%w: deal miss is not allowed in this game", ErrInvalidMove)
+	}
+
+	for _, b := range g.Bids {
+		if b.PlayerID == p.ID {
+			return fmt.Errorf("%w: deal miss must be called before bidding", ErrInvalidMove)
 		}
 	}
 
+	if v := g.DealMissValue(p.Hand); v > g.Config.DealMiss.Threshold {
+		return fmt.Errorf("%w: hand value %d is above the deal-miss threshold %d", ErrInvalidMove, v, g.Config.DealMiss.Threshold)
+	}
+
+	return nil
+}
+
+// validateConcede allows the declarer to give up once the contract is set,
+// up to the first card of trick 1.
+func (g *Game) validateConcede(p *Player) error {
+	beforeLead := g.Status == PhasePlaying && len(g.Tricks) == 1 && len(g.Tricks[0].Cards) == 0
+	if g.Status != PhaseCalling && !beforeLead {
+		return fmt.Errorf("%w: concede only before the first lead", ErrInvalidMove)
+	}
+
+	if g.Config.Concede == nil {
+		return fmt.Errorf("%w: concession is not allowed in this game", ErrInvalidMove)
+	}
+
+	if g.Players[g.Declarer].ID != p.ID {
+		return fmt.Errorf("%w: only the declarer can concede", ErrInvalidMove)
+	}
+
 	return nil
 }
 
@@ -175,10 +264,93 @@ func (g *Game) validateDiscard(p *Player, payload any) error {
 
 	// Verify player actually has these cards
 	// Note: At this point, player has 13 cards (Hand + Kitty)
+	seen := make(map[Card]bool, len(cards))
 	for _, c := range cards {
 		if !p.HasCard(c) {
 			return fmt.Errorf("%w: do not hold card %s", ErrInvalidMove, c)
 		}
+		if seen[c] {
+			return fmt.Errorf("%w: card %s discarded twice", ErrInvalidMove, c)
+		}
+		seen[c] = true
+	}
+
+	return nil
+}
+
+// trumpChangeRaise is the contract increase needed to move from the current
+// contract to the requested trump.
+func (g *Game) trumpChangeRaise(move ChangeTrumpMove) int {
+	if move.IsNoTrump != g.Contract.IsNoTrump {
+		return TrumpChangeRaiseNoTrump
+	}
+
+	return TrumpChangeRaise
+}
+
+// validateEliminate
+// Payload: EliminateMove. The six-player declarer names an occupied seat other
+// than their own to sit out the hand.
+func (g *Game) validateEliminate(p *Player, payload any) error {
+	if g.Status != PhaseEliminating {
+		return fmt.Errorf("%w: not in eliminating phase", ErrInvalidMove)
+	}
+
+	if g.Players[g.Declarer].ID != p.ID {
+		return fmt.Errorf("%w: only declarer can eliminate", ErrInvalidMove)
+	}
+
+	move, ok := payload.(EliminateMove)
+	if !ok {
+		return errors.New("invalid payload for eliminate")
+	}
+
+	if move.Seat < 0 || move.Seat >= g.numSeats() || g.Players[move.Seat] == nil {
+		return fmt.Errorf("%w: invalid seat %d", ErrInvalidMove, move.Seat)
+	}
+
+	if move.Seat == g.Declarer {
+		return fmt.Errorf("%w: declarer cannot eliminate themselves", ErrInvalidMove)
+	}
+
+	return nil
+}
+
+// validateChangeTrump
+// Payload: ChangeTrumpMove. Legal once per hand, for the declarer, after the
+// kitty is taken and before the discard.
+func (g *Game) validateChangeTrump(p *Player, payload any) error {
+	if g.Status != PhaseExchanging {
+		return fmt.Errorf("%w: trump can only change before the discard", ErrInvalidMove)
+	}
+
+	if g.Players[g.Declarer].ID != p.ID {
+		return fmt.Errorf("%w: only declarer can change trump", ErrInvalidMove)
+	}
+
+	move, ok := payload.(ChangeTrumpMove)
+	if !ok {
+		return errors.New("invalid payload for trump change")
+	}
+
+	if g.TrumpChanged {
+		return fmt.Errorf("%w: trump already changed this hand", ErrInvalidMove)
+	}
+
+	if move.IsNoTrump {
+		if move.Suit != None {
+			return fmt.Errorf("%w: no-trump must use suit 'none'", ErrInvalidMove)
+		}
+	} else if _, ok := suitRank[move.Suit]; !ok {
+		return fmt.Errorf("%w: invalid trump suit", ErrInvalidMove)
+	}
+
+	if move.IsNoTrump == g.Contract.IsNoTrump && move.Suit == g.Contract.Suit {
+		return fmt.Errorf("%w: trump is already %s", ErrInvalidMove, move.Suit)
+	}
+
+	if g.Contract.Points+g.trumpChangeRaise(move) > 10 {
+		return fmt.Errorf("%w: trump change would raise the contract above 10", ErrInvalidMove)
 	}
 
 	return nil
@@ -212,12 +384,25 @@ func (g *Game) validateCallPartner(p *Player, payload any) error {
 		return err
 	}
 
-	if move.Card != nil && move.NoFriend {
-		return fmt.Errorf("%w: choose a card or no_friend, not both", ErrInvalidMove)
+	switch move.Mode {
+	case "", FriendCard:
+		if move.Card != nil && move.NoFriend {
+			return fmt.Errorf("%w: choose a card or no_friend, not both", ErrInvalidMove)
+		}
+
+		if move.Card == nil && (move.Mode == FriendCard || !move.NoFriend) {
+			return fmt.Errorf("%w: call_partner requires a card, a mode or no_friend", ErrInvalidMove)
+		}
+	case FriendFirstTrick, FriendMighty, FriendJoker:
+		if move.Card != nil || move.NoFriend {
+			return fmt.Errorf("%w: %s friend takes no card or no_friend", ErrInvalidMove, move.Mode)
+		}
+	default:
+		return fmt.Errorf("%w: unknown friend mode %q", ErrInvalidMove, move.Mode)
 	}
 
-	if move.Card == nil && !move.NoFriend {
-		return fmt.Errorf("%w: call_partner requires a card or no_friend", ErrInvalidMove)
+	if move.Mode == FriendJoker && g.Config.NumPlayers == 4 && !g.Config.AllowJokerPartner {
+		return fmt.Errorf("%w: joker may not be called as partner in this game", ErrInvalidMove)
 	}
 
 	if move.Card != nil {
@@ -230,6 +415,9 @@ func (g *Game) validateCallPartner(p *Player, payload any) error {
 			if _, ok := suitRank[move.Card.Suit]; !ok || !validRanks[move.Card.Rank] {
 				return fmt.Errorf("%w: invalid partner card", ErrInvalidMove)
 			}
+			if !slices.Contains(NewDeckFor(g.numSeats()), *move.Card) {
+				return fmt.Errorf("%w: %s is not in a %d-player deck", ErrInvalidMove, *move.Card, g.numSeats())
+			}
 		}
 	}
 
@@ -276,19 +464,27 @@ func (g *Game) validatePlayCard(p *Player, payload any) error {
 	hasMighty := p.HasMighty(g)
 	hasJoker := p.HasRank(Joker)
 	isPlayingMightyOrJoker := g.IsMighty(card) || card.Rank == Joker
+	rs := g.rules()
 
-	if cardsLeft == 3 && hasMighty && hasJoker && !isPlayingMightyOrJoker {
-		return fmt.Errorf("%w: must play mighty or joker on 3rd to last trick", ErrInvalidMove)
-	}
-	if cardsLeft == 2 && (hasMighty || hasJoker) && !isPlayingMightyOrJoker {
-		return fmt.Errorf("%w: must play mighty or joker on 2nd to last trick", ErrInvalidMove)
+	if rs.ForceLateSpecials {
+		if cardsLeft == 3 && hasMighty && hasJoker && !isPlayingMightyOrJoker {
+			return fmt.Errorf("%w: must play mighty or joker on 3rd to last trick", ErrInvalidMove)
+		}
+		if cardsLeft == 2 && (hasMighty || hasJoker) && !isPlayingMightyOrJoker {
+			return fmt.Errorf("%w: must play mighty or joker on 2nd to last trick", ErrInvalidMove)
+		}
 	}
 
 	// 1. Forced Play (Joker Called)
-	if t.JokerCalled && p.HasRank(Joker) {
-		// "The only exception is that if the joker holder also has the mighty in which case she may choose to play the mighty"
-		if card.Rank != Joker && !g.IsMighty(card) {
-			return fmt.Errorf("%w: joker called, must play joker or mighty", ErrInvalidMove)
+	if t.JokerCalled && p.HasRank(Joker) && card.Rank != Joker {
+		switch rs.JokerCall {
+		case "", JokerCallJokerOrMighty:
+			// "The only exception is that if the joker holder also has the mighty in which case she may choose to play the mighty"
+			if !g.IsMighty(card) {
+				return fmt.Errorf("%w: joker called, must play joker or mighty", ErrInvalidMove)
+			}
+		case JokerCallJoker:
+			return fmt.Errorf("%w: joker called, must play joker", ErrInvalidMove)
 		}
 	}
 
@@ -296,13 +492,13 @@ func (g *Game) validatePlayCard(p *Player, payload any) error {
 	if len(t.Cards) == 0 {
 		// First trick lead rules
 		if len(g.Tricks) == 1 {
-			if card.Rank == Joker {
+			if card.Rank == Joker && !rs.FirstTrickJoker {
 				return fmt.Errorf("%w: cannot lead joker on first trick", ErrInvalidMove)
 			}
-			if g.IsMighty(card) {
+			if g.IsMighty(card) && !rs.FirstTrickMighty {
 				return fmt.Errorf("%w: cannot lead mighty on first trick", ErrInvalidMove)
 			}
-			if card.Suit == g.Trump && p.HasNonTrumpMightyJoker(g) {
+			if card.Suit == g.Trump && !rs.FirstTrickTrumpLead && p.HasNonTrumpMightyJoker(g) {
 				return fmt.Errorf("%w: cannot lead trump on first trick unless holding only trump/special cards", ErrInvalidMove)
 			}
 		}
@@ -321,9 +517,9 @@ func (g *Game) validatePlayCard(p *Player, payload any) error {
 		if move.CallJoker && !g.IsJokerCaller(card) {
 			return fmt.Errorf("%w: only joker caller can call joker", ErrInvalidMove)
 		}
-		// Joker Caller loses power/ability on first and last trick
-		if move.CallJoker && (len(g.Tricks) == 1 || len(g.Tricks) == 10) {
-			return fmt.Errorf("%w: cannot call joker on first or last trick", ErrInvalidMove)
+		// Joker Caller may lose its ability on the first and last trick
+		if move.CallJoker && !rs.jokerCallAllowed(len(g.Tricks)) {
+			return fmt.Errorf("%w: cannot call joker on trick %d", ErrInvalidMove, len(g.Tricks))
 		}
 
 		return nil
@@ -333,14 +529,18 @@ func (g *Game) validatePlayCard(p *Player, payload any) error {
 		return fmt.Errorf("%w: called_suit only valid when leading the joker", ErrInvalidMove)
 	}
 
+	if move.CallJoker {
+		return fmt.Errorf("%w: call_joker only valid when leading the joker caller", ErrInvalidMove)
+	}
+
 	// 3. Following Suit
 	lead := t.LeadSuit
 	// Special Rule: First Hand Restrictions
 	if len(g.Tricks) == 1 {
-		if card.Rank == Joker {
+		if card.Rank == Joker && !rs.FirstTrickJoker {
 			return fmt.Errorf("%w: cannot play joker on first trick", ErrInvalidMove)
 		}
-		if g.IsMighty(card) {
+		if g.IsMighty(card) && !rs.FirstTrickMighty {
 			// Mighty can only be played if the led suit matches its base suit AND it is the only card of that suit
 			if lead != card.Suit || p.GetSuitCount(lead) > 1 {
 				return fmt.Errorf("%w: cannot play mighty on first trick unless it is your only card of the led suit", ErrInvalidMove)
@@ -367,45 +567,56 @@ func (g *Game) validatePlayCard(p *Player, payload any) error {
 
 // IsMighty checks if a card is the Mighty card given the current trump suit.
 func (g *Game) IsMighty(c Card) bool {
-	// Usually Ace of Spades.
-	// If Spades is Trump, Ace of Diamonds is Mighty.
-	if g.Trump == Spades {
-		return c.Suit == Diamonds && c.Rank == Ace
-	}
-
-	return c.Suit == Spades && c.Rank == Ace
+	return c == g.rules().mightyUnder(g.Trump)
 }
 
 // IsJokerCaller checks if a card is the Joker Caller card given the current trump suit.
 func (g *Game) IsJokerCaller(c Card) bool {
-	// Usually Three of Clubs.
-	// If Clubs is Trump, Three of Spades is Joker Caller.
-	if g.Trump == Clubs {
-		return c.Suit == Spades && c.Rank == Three
-	}
-
-	return c.Suit == Clubs && c.Rank == Three
+	return c == g.rules().jokerCallerUnder(g.Trump)
 }
 
 // friendSeat returns the seat of the mystery friend (the holder of the called
-// partner card), or -1 when there is no friend or the card is unheld (e.g. the
-// declarer discarded it into the kitty before calling). It scans current hands
-// and every played trick card, so it is correct at any point after the friend
-// is called and needs no stored field — it survives Redis reloads for free.
+// partner card, the Mighty or the Joker, or the first trick's winner), or -1
+// when there is no friend yet or the card is unheld (e.g. the declarer
+// discarded it into the kitty before calling). It scans current hands and
+// every played trick card, so it is correct at any point after the friend is
+// called and needs no stored field — it survives Redis reloads for free.
 func (g *Game) friendSeat() int {
-	if g.IsNoFriend || g.PartnerCard == nil {
+	if g.IsNoFriend {
+		return -1
+	}
+
+	switch g.FriendMode {
+	case FriendFirstTrick:
+		if len(g.Tricks) == 0 || len(g.Tricks[0].Cards) < g.numActive() {
+			return -1
+		}
+		return g.Tricks[0].Winner
+	case FriendMighty:
+		return g.holderOf(g.IsMighty)
+	case FriendJoker:
+		return g.holderOf(func(c Card) bool { return c.Rank == Joker })
+	}
+
+	if g.PartnerCard == nil {
 		return -1
 	}
 
 	pc := *g.PartnerCard
 
+	return g.holderOf(func(c Card) bool { return c.Suit == pc.Suit && c.Rank == pc.Rank })
+}
+
+// holderOf returns the seat holding or having played the card matching
+// match, or -1 if no seat has it.
+func (g *Game) holderOf(match func(Card) bool) int {
 	for _, p := range g.Players {
 		if p == nil {
 			continue
 		}
 
 		for _, c := range p.Hand {
-			if c.Suit == pc.Suit && c.Rank == pc.Rank {
+			if match(c) {
 				return p.Seat
 			}
 		}
@@ -413,7 +624,7 @@ func (g *Game) friendSeat() int {
 
 	for _, t := range g.Tricks {
 		for _, played := range t.Cards {
-			if played.Card.Suit == pc.Suit && played.Card.Rank == pc.Rank {
+			if match(played.Card) {
 				return played.Seat
 			}
 		}
@@ -511,6 +722,10 @@ func (p *Player) GetSuitCount(s Suit) int {
 func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error {
 	p := g.GetPlayer(playerID)
 
+	if g.Status == PhaseMatchOver {
+		return errors.New("match is over")
+	}
+
 	if g.Status == PhaseFinished {
 		if moveType == MoveChangeConfig {
 			if payload != nil {
@@ -522,9 +737,10 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 					data, _ := json.Marshal(payload)
 					_ = json.Unmarshal(data, &cm)
 				}
-				
-				if cm.NumPlayers == 4 || cm.NumPlayers == 5 {
+
+				if cm.NumPlayers >= 4 && cm.NumPlayers <= 6 {
 					g.Config.NumPlayers = cm.NumPlayers
+					g.ensureSeats()
 					g.PlayAgainVotes = make(map[int]bool) // Reset votes on config change
 				}
 			}
@@ -538,7 +754,7 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 				g.PlayAgainVotes = make(map[int]bool)
 			}
 			g.PlayAgainVotes[p.Seat] = true
-			
+
 			// Check if all active seats voted
 			if len(g.PlayAgainVotes) == g.Config.NumPlayers {
 				g.resetForNextRound()
@@ -547,7 +763,7 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 			g.UpdatedAt = time.Now()
 			return nil
 		}
-		
+
 		return errors.New("invalid move in finished phase")
 	}
 
@@ -561,19 +777,12 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 		bid.PlayerID = playerID // Ensure playerID is set
 
 		g.CurrentBid = &bid
-		g.Declarer = p.Seat                  // Potential declarer
+		g.Declarer = p.Seat // Potential declarer
 		g.Bids = append(g.Bids, bid)
 
-		if bid.Points == 10 || len(g.PassedPlayers) == g.numSeats()-1 {
-			// Auto-resolve if maximum bid is reached or all others passed
-			g.Status = PhaseExchanging
-			g.Contract = g.CurrentBid
-			g.Trump = g.Contract.Suit
-			g.CurrentTurn = g.Declarer
-
-			declarer := g.Players[g.Declarer]
-			declarer.Hand = append(declarer.Hand, g.Kitty...)
-			g.Kitty = nil
+		if g.isTopBid(bid) || len(g.PassedPlayers) == g.numSeats()-1 {
+			// Auto-resolve if no bid can overcall this one or all others passed
+			g.closeBidding()
 		} else {
 			g.advanceToNextBidder()
 		}
@@ -584,31 +793,62 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 		g.advanceToNextBidder()
 		// Check if bidding ended
 		if len(g.PassedPlayers) == g.numSeats()-1 && g.CurrentBid != nil {
-			g.Status = PhaseExchanging
-			// Set final declarer (should be already set by last bid)
-			g.Contract = g.CurrentBid
-			g.CurrentTurn = g.Declarer
-			g.Trump = g.Contract.Suit
-
-			// Give kitty to declarer
-			declarer := g.Players[g.Declarer]
-			declarer.Hand = append(declarer.Hand, g.Kitty...)
-			g.Kitty = nil // Empty kitty
+			// Final declarer is already set by the last bid
+			g.closeBidding()
 		} else if len(g.PassedPlayers) == g.numSeats() {
 			// Everyone passed: throw the hand in and redeal.
-			g.Bids = nil
-			g.CurrentBid = nil
-			g.Contract = nil
-			g.Declarer = -1
-			g.PassedPlayers = make(map[int]bool)
-			g.PartnerCard = nil
-			g.PartnerSeat = -1
-			g.IsNoFriend = false
-			g.Trump = ""
-			g.Tricks = make([]Trick, 0)
-			g.Start()
+			g.redeal()
 		}
 
+	case MoveDealMiss:
+		// Show the hand to the table, then throw it in exactly as an all-pass would.
+		shown := DealMiss{
+			PlayerID: playerID,
+			Seat:     p.Seat,
+			Hand:     append([]Card{}, p.Hand...),
+			Value:    g.DealMissValue(p.Hand),
+		}
+		g.redeal()
+		g.LastDealMiss = &shown
+
+	case MoveConcede:
+		g.Conceded = true
+		g.revealFriend()
+		g.finishRound(g.concessionScores())
+
+	case MoveClaim:
+		move, err := asClaimMove(payload)
+		if err != nil {
+			return err
+		}
+		g.applyClaim(p, move)
+
+	case MoveEliminate:
+		move, ok := payload.(EliminateMove)
+		if !ok {
+			return errors.New("invalid payload for eliminate")
+		}
+
+		// Pool the eliminated hand with the kitty, deal two to each remaining
+		// seat, and hand the remaining five to the declarer as the kitty.
+		out := g.Players[move.Seat]
+		pool := Deck(append(append([]Card{}, out.Hand...), g.Kitty...))
+		pool.ShuffleSeeded(g.handSeed().derive(1))
+		out.Hand = []Card{}
+		g.Eliminated = move.Seat
+
+		k := 0
+		for seat := g.nextSeat(g.Declarer); seat != g.Declarer; seat = g.nextSeat(seat) {
+			g.Players[seat].Hand = append(g.Players[seat].Hand, pool[k:k+2]...)
+			k += 2
+		}
+		g.Kitty = append([]Card{}, pool[k:]...)
+
+		g.Status = PhaseExchanging
+		declarer := g.Players[g.Declarer]
+		declarer.Hand = append(declarer.Hand, g.Kitty...)
+		g.Kitty = nil
+
 	case MoveDiscard:
 		cards, ok := payload.([]Card)
 		if !ok {
@@ -640,20 +880,43 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 		// Declarer gets points from discard?
 		// "May score points from discarded scoring cards"
 		p.Points = append(p.Points, discardPoints...)
+		// The discards stay out of play as the kitty, known to the declarer.
+		g.Kitty = cloneCards(cards)
 
 		g.Status = PhaseCalling
 
+	case MoveChangeTrump:
+		move, ok := payload.(ChangeTrumpMove)
+		if !ok {
+			return errors.New("invalid payload for trump change")
+		}
+
+		// The Mighty and Joker Caller follow automatically, since IsMighty
+		// and IsJokerCaller derive them from Trump on every call.
+		g.Contract.Points += g.trumpChangeRaise(move)
+		g.Contract.Suit = move.Suit
+		g.Contract.IsNoTrump = move.IsNoTrump
+		if g.CurrentBid != nil {
+			*g.CurrentBid = *g.Contract
+		}
+		g.Trump = move.Suit
+		g.TrumpChanged = true
+
 	case MoveCallPartner:
 		move, err := asCallPartnerMove(payload)
 		if err != nil {
 			return err
 		}
 
-		if move.NoFriend {
+		switch {
+		case move.NoFriend:
 			g.IsNoFriend = true
 			g.PartnerCard = nil
-		} else {
+		case move.Card != nil:
+			g.FriendMode = FriendCard
 			g.PartnerCard = move.Card
+		default:
+			g.FriendMode = move.Mode
 		}
 
 		g.Status = PhasePlaying
@@ -672,94 +935,93 @@ func (g *Game) ApplyMove(playerID string, moveType MoveType, payload any) error
 			move = PlayCardMove{Card: card}
 		}
 
-		card := move.Card
+		g.playCard(p, move)
+	}
 
-		// Remove from hand
-		newHand := []Card{}
+	g.Version++
+	g.UpdatedAt = time.Now()
 
-		for _, c := range p.Hand {
-			if c.Suit == card.Suit && c.Rank == card.Rank {
-				continue
-			}
+	return nil
+}
 
-			newHand = append(newHand, c)
+// playCard plays move from p's hand, resolving the trick once everyone has
+// played to it and ending the hand after the tenth.
+func (g *Game) playCard(p *Player, move PlayCardMove) {
+	card := move.Card
+
+	// Remove from hand
+	newHand := []Card{}
+
+	for _, c := range p.Hand {
+		if c.Suit == card.Suit && c.Rank == card.Rank {
+			continue
 		}
 
-		p.Hand = newHand
+		newHand = append(newHand, c)
+	}
 
-		// Add to trick
-		idx := len(g.Tricks) - 1
-		g.Tricks[idx].Cards = append(g.Tricks[idx].Cards, PlayedCard{
-			PlayerID: playerID,
-			Seat:     p.Seat,
-			Card:     card,
-		})
-
-		// Set Lead Suit if first card
-		if len(g.Tricks[idx].Cards) == 1 {
-			g.Tricks[idx].LeadSuit = card.Suit
-			if card.Rank == Joker {
-				g.Tricks[idx].LeadSuit = move.CalledSuit
-			}
+	p.Hand = newHand
 
-			// Handle Joker Caller
-			if move.CallJoker && g.IsJokerCaller(card) {
-				g.Tricks[idx].JokerCalled = true
-			}
-		}
+	// Add to trick
+	idx := len(g.Tricks) - 1
+	g.addToTrick(&g.Tricks[idx], PlayedCard{PlayerID: p.ID, Seat: p.Seat, Card: card}, move)
 
-		// Turn moves to next
-		g.CurrentTurn = (g.CurrentTurn + 1) % g.numSeats()
+	// Turn moves to next
+	g.CurrentTurn = g.nextSeat(g.CurrentTurn)
 
-		// Check if trick finished
-		if len(g.Tricks[idx].Cards) == g.numSeats() {
-			winnerSeat, points := g.ResolveTrick(g.Tricks[idx])
-			g.Tricks[idx].Winner = winnerSeat
+	// Check if trick finished
+	if len(g.Tricks[idx].Cards) == g.numActive() {
+		winnerSeat, points := g.ResolveTrick(g.Tricks[idx])
+		g.Tricks[idx].Winner = winnerSeat
 
-			// Reveal the friend once they defend: they win a trick that holds a
-			// scoring card, or take it with the joker. A pointless win stays
-			// ambiguous, so it does not reveal.
-			if g.PartnerSeat < 0 {
-				if fs := g.friendSeat(); fs >= 0 && winnerSeat == fs && trickRevealsFriend(g.Tricks[idx], fs) {
+		// Reveal the friend once they defend: they win a trick that holds a
+		// scoring card, or take it with the joker. A pointless win stays
+		// ambiguous, so it does not reveal. A first-trick friend is known
+		// the moment the first trick is won, unless the declarer won it
+		// and plays alone.
+		if g.PartnerSeat < 0 {
+			fs := g.friendSeat()
+			switch {
+			case g.FriendMode == FriendFirstTrick:
+				if idx == 0 && fs != g.Declarer {
 					g.PartnerSeat = fs
 				}
+			case fs >= 0 && winnerSeat == fs && trickRevealsFriend(g.Tricks[idx], fs):
+				g.PartnerSeat = fs
 			}
+		}
 
-			// Give points to winner
-			winner := g.Players[winnerSeat]
-			winner.Points = append(winner.Points, points...)
-
-			// Winner leads next
-			g.CurrentTurn = winnerSeat
+		// Give points to winner
+		winner := g.Players[winnerSeat]
+		winner.Points = append(winner.Points, points...)
 
-			if len(g.Tricks) == 10 {
-				g.Status = PhaseFinished
-				seatScores := g.CalculateFinalScore()
+		// Winner leads next
+		g.CurrentTurn = winnerSeat
 
-				// Zero-sum per-seat result for the round, keyed by player ID.
-				g.Scores = make(map[string]int, len(g.Players))
-				for seat, player := range g.Players {
-					if player != nil {
-						g.Scores[player.ID] = seatScores[seat]
-					}
-				}
-				if g.TotalScores == nil {
-					g.TotalScores = make(map[string]int)
-				}
-				for pID, score := range g.Scores {
-					g.TotalScores[pID] += score
-				}
-				g.ScoreHistory = append(g.ScoreHistory, g.Scores)
-			} else {
-				g.Tricks = append(g.Tricks, Trick{Cards: []PlayedCard{}})
-			}
+		if len(g.Tricks) == 10 {
+			g.finishRound(g.scoreHand())
+		} else {
+			g.Tricks = append(g.Tricks, Trick{Cards: []PlayedCard{}})
 		}
 	}
+}
 
-	g.Version++
-	g.UpdatedAt = time.Now()
+// addToTrick plays pc into t. The first card sets the lead suit (the called
+// suit for a Joker lead) and may call the Joker.
+func (g *Game) addToTrick(t *Trick, pc PlayedCard, move PlayCardMove) {
+	t.Cards = append(t.Cards, pc)
+	if len(t.Cards) > 1 {
+		return
+	}
 
-	return nil
+	t.LeadSuit = pc.Card.Suit
+	if pc.Card.Rank == Joker {
+		t.LeadSuit = move.CalledSuit
+	}
+
+	if move.CallJoker && g.IsJokerCaller(pc.Card) {
+		t.JokerCalled = true
+	}
 }
 
 // ResolveTrick determines the winner and points.
@@ -796,8 +1058,8 @@ func (g *Game) CalculatePower(c Card, t Trick, trickNum int) int {
 	// 2. Joker Logic
 	if c.Rank == Joker {
 		// Joker loses power if:
-		// - Played in first or last trick
-		if trickNum == 1 || trickNum == 10 {
+		// - Played in a trick where the rule set strips it (first or last)
+		if g.rules().jokerPowerless(trickNum) {
 			return PowerBase
 		}
 		// - Joker Caller led and called Joker
@@ -834,120 +1096,53 @@ func (g *Game) Beats(c1, c2 Card, t Trick) bool {
 	return g.CalculatePower(c1, t, trickNum) > g.CalculatePower(c2, t, trickNum)
 }
 
-// CalculateFinalScore computes each seat's signed round score under the
-// official Mighty regulations. The returned map is keyed by seat index and
-// always sums to zero. Bids are on the 3-10 scale; the scoring-card target is
-// bid + 10. P is the caller team's captured scoring cards.
-func (g *Game) CalculateFinalScore() map[int]int {
-	scores := make(map[int]int)
-	if g.Contract == nil {
-		return scores
-	}
-
-	declarer := g.Declarer
-	fs := g.friendSeat()
-	partnerPresent := fs >= 0 && fs != declarer
-	onTeam := func(seat int) bool {
-		return seat == declarer || (partnerPresent && seat == fs)
-	}
+// finishRound ends the hand with the given per-seat result: it records the
+// round's Scores, adds them to TotalScores, files rs with those scores in
+// ScoreHistory, and ends the match if this round reached its limit.
+func (g *Game) finishRound(rs RoundScore, seatScores map[int]int) {
+	g.Status = PhaseFinished
 
-	// P: the team's captured scoring cards. All 20 point cards are always
-	// distributed - trick points go to winners, kitty discards to the declarer.
-	p := 0
-	oppCount := 0
+	// Zero-sum per-seat result for the round, keyed by player ID.
+	g.Scores = make(map[string]int, len(g.Players))
 	for seat, player := range g.Players {
-		if player == nil {
-			continue
+		if player != nil {
+			g.Scores[player.ID] = seatScores[seat]
 		}
-		if onTeam(seat) {
-			p += len(player.Points)
-		} else {
-			oppCount++
-		}
-	}
-
-	target := g.Contract.Points + 10
-	success := p >= target
-
-	var s int
-	if success {
-		s = 2*(g.Contract.Points-g.minBidPoints()) + (p - target)
-	} else {
-		s = target - p
 	}
-
-	// Doublings, each multiplicative.
-	if p == 20 { // run
-		s *= 2
+	if g.TotalScores == nil {
+		g.TotalScores = make(map[string]int)
 	}
-	if 20-p >= 11 { // back run: defenders took >= 11 scoring cards
-		s *= 2
+	for pID, score := range g.Scores {
+		g.TotalScores[pID] += score
 	}
-	if g.Contract.IsNoTrump {
-		s *= 2
-	}
-	if g.IsNoFriend {
-		s *= 2
-	}
-
-	sign := 1
-	if !success {
-		sign = -1
-	}
-	partnerShare := 0
-	if partnerPresent {
-		partnerShare = s
-	}
-
-	// A configured four-player 2-vs-2 failure uses a special split; every other
-	// case (all wins, all alone games, and every five-player result) uses the
-	// standard formula where each opponent pays S, the partner collects S, and
-	// the declarer collects the remainder, with signs flipped on failure.
-	special := !success && partnerPresent && g.Config.NumPlayers == 4 &&
-		(g.Config.FailDist == FailDeclarerAlone || g.Config.FailDist == FailTwoOneSplit)
-
-	if special {
-		var declarerPay, partnerPay, oppGain int
-		switch g.Config.FailDist {
-		case FailDeclarerAlone:
-			declarerPay, partnerPay, oppGain = 2*s, 0, s
-		default: // FailTwoOneSplit
-			oppGain = (3*s + 1) / 2 // ceil(1.5*s)
-			partnerPay = s
-			declarerPay = 2*oppGain - partnerPay // 2s if s even, 2s+1 if s odd
-		}
-		for seat, player := range g.Players {
-			if player == nil {
-				continue
-			}
-			switch {
-			case seat == declarer:
-				scores[seat] = -declarerPay
-			case seat == fs:
-				scores[seat] = -partnerPay
-			default:
-				scores[seat] = oppGain
-			}
-		}
-		return scores
+	rs.Scores = cloneScores(g.Scores)
+	g.ScoreHistory = append(g.ScoreHistory, rs)
+
+	if g.matchOver() {
+		g.Status = PhaseMatchOver
+		g.Standings = g.standings()
 	}
+}
 
-	// Standard distribution (sums to zero).
-	for seat, player := range g.Players {
-		if player == nil {
-			continue
-		}
-		switch {
-		case seat == declarer:
-			scores[seat] = sign * (oppCount*s - partnerShare)
-		case partnerPresent && seat == fs:
-			scores[seat] = sign * s
-		default:
-			scores[seat] = -sign * s
-		}
+// concessionScores prices a concession under Config.Concede: S is shared
+// out as for a failed contract, so a friend already called pays their share
+// with the declarer, each opponent collects S and the result sums to zero. A
+// seat eliminated from a six-player hand scores zero.
+func (g *Game) concessionScores() (RoundScore, map[int]int) {
+	scores := make(map[int]int)
+	rs := RoundScore{Scoring: g.scoring(), Conceded: true}
+	c := g.Config.Concede
+	if g.Contract == nil || c == nil {
+		return rs, scores
 	}
 
-	return scores
+	fs := g.friendSeat()
+	rs.Bid, rs.Target = g.Contract.Points, g.Contract.Points+10
+	rs.P = g.teamPoints(fs)
+	rs.Base = c.Base + c.PerLevel*(g.Contract.Points-g.minBidPoints())
+	rs.S = rs.Base
+
+	return rs, g.distribute(rs.S, false, fs)
 }
 
 // RankValue returns the numerical value of a rank for comparison.
@@ -999,6 +1194,47 @@ func (g *Game) advanceToNextBidder() {
 	}
 }
 
+// closeBidding fixes the contract once bidding ends. The declarer takes the
+// kitty straight away, except in a six-player game, which first waits for the
+// declarer to eliminate a seat.
+func (g *Game) closeBidding() {
+	g.Contract = g.CurrentBid
+	g.Trump = g.Contract.Suit
+	g.CurrentTurn = g.Declarer
+
+	if g.numSeats() == 6 {
+		g.Status = PhaseEliminating
+		return
+	}
+
+	g.Status = PhaseExchanging
+	declarer := g.Players[g.Declarer]
+	declarer.Hand = append(declarer.Hand, g.Kitty...)
+	g.Kitty = nil
+}
+
+// redeal throws the current hand in and deals a fresh one without moving the
+// dealer, clearing everything bidding may have set.
+func (g *Game) redeal() {
+	g.Bids = nil
+	g.CurrentBid = nil
+	g.Contract = nil
+	g.Declarer = -1
+	g.PassedPlayers = make(map[int]bool)
+	g.PartnerCard = nil
+	g.FriendMode = ""
+	g.PartnerSeat = -1
+	g.IsNoFriend = false
+	g.Conceded = false
+	g.Claim = nil
+	g.LastDealMiss = nil
+	g.Trump = ""
+	g.TrumpChanged = false
+	g.Eliminated = -1
+	g.Tricks = make([]Trick, 0)
+	g.Start()
+}
+
 // resetForNextRound clears the board state and starts a new set of tricks.
 func (g *Game) resetForNextRound() {
 	g.PlayAgainVotes = make(map[int]bool)
@@ -1008,21 +1244,28 @@ func (g *Game) resetForNextRound() {
 	g.Contract = nil
 	g.Declarer = -1
 	g.PartnerCard = nil
+	g.FriendMode = ""
 	g.PartnerSeat = -1
 	g.PassedPlayers = make(map[int]bool)
 	g.Scores = make(map[string]int)
 	g.IsNoFriend = false
-	
+	g.Conceded = false
+	g.Claim = nil
+	g.Trump = ""
+	g.TrumpChanged = false
+	g.LastDealMiss = nil
+	g.Eliminated = -1
+
 	// Shift dealer clockwise, handling current config bounds
 	g.Dealer = (g.Dealer + 1) % g.Config.NumPlayers
-	
+
 	// Clear point cards from previous round
 	for i := range g.Players {
 		if g.Players[i] != nil {
 			g.Players[i].Points = []Card{}
 		}
 	}
-	
+
 	// Start re-deals cards and sets PhaseBidding
 	g.Start()
 	// Bidding starts with the new dealer
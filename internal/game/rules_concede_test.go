@@ -0,0 +1,141 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateConcede(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		player  string
+		setup   func(g *Game)
+		wantErr bool
+	}{
+		{name: "declarer while calling", player: "p0"},
+		{name: "declarer before the first lead", player: "p0", setup: func(g *Game) {
+			g.Status = PhasePlaying
+			g.Tricks = []Trick{{Cards: []PlayedCard{}}}
+		}},
+		{name: "after the first lead", player: "p0", wantErr: true, setup: func(g *Game) {
+			g.Status = PhasePlaying
+			g.Tricks = []Trick{{Cards: []PlayedCard{{PlayerID: "p0", Seat: 0, Card: Card{Suit: Clubs, Rank: Two}}}}}
+			g.CurrentTurn = 1
+		}},
+		{name: "not the declarer", player: "p1", wantErr: true},
+		{name: "during bidding", player: "p0", wantErr: true, setup: func(g *Game) { g.Status = PhaseBidding }},
+		{name: "disabled by config", player: "p0", wantErr: true, setup: func(g *Game) { g.Config.Concede = nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := callingGame()
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			err := g.ValidateMove(tt.player, MoveConcede, nil)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateMove() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConcedeScoresThroughRoundBookkeeping(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame() // bid 7, minimum 3: S = 1 + 1×4 = 5
+	g.TotalScores = map[string]int{"p0": 10, "p1": -10}
+
+	if err := g.ApplyMove("p0", MoveConcede, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Status != PhaseFinished || !g.Conceded {
+		t.Fatalf("expected a conceded, finished hand, got %s conceded=%v", g.Status, g.Conceded)
+	}
+
+	want := map[string]int{"p0": -20, "p1": 5, "p2": 5, "p3": 5, "p4": 5}
+	for id, score := range want {
+		if g.Scores[id] != score {
+			t.Fatalf("Scores[%s] = %d, want %d", id, g.Scores[id], score)
+		}
+	}
+
+	if g.TotalScores["p0"] != -10 || g.TotalScores["p1"] != -5 || g.TotalScores["p2"] != 5 {
+		t.Fatalf("totals not accumulated: %v", g.TotalScores)
+	}
+
+	if len(g.ScoreHistory) != 1 {
+		t.Fatalf("expected one round in history, got %d", len(g.ScoreHistory))
+	}
+}
+
+func TestConcedeFourPlayerUsesItsMinimumBid(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame()
+	g.Config.NumPlayers = 4
+	g.Players[4] = nil
+	g.Contract = &Bid{PlayerID: "p0", Points: 6, Suit: Spades}
+	g.Config.Concede = &ConcedeConfig{Base: 2, PerLevel: 3} // S = 2 + 3×(6−4) = 8
+
+	if err := g.ApplyMove("p0", MoveConcede, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Scores["p0"] != -24 || g.Scores["p1"] != 8 || g.Scores["p3"] != 8 {
+		t.Fatalf("unexpected four-player concession scores: %v", g.Scores)
+	}
+}
+
+func TestConcedeAfterTheCallChargesTheFriend(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame() // S = 5
+	g.Players[1].Hand = []Card{{Suit: Hearts, Rank: King}}
+	if err := g.ApplyMove("p0", MoveCallPartner, CallPartnerMove{Card: &Card{Suit: Hearts, Rank: King}}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+
+	if err := g.ApplyMove("p0", MoveConcede, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	want := map[string]int{"p0": -10, "p1": -5, "p2": 5, "p3": 5, "p4": 5}
+	for id, score := range want {
+		if g.Scores[id] != score {
+			t.Fatalf("Scores = %v, want %v", g.Scores, want)
+		}
+	}
+
+	if g.PartnerSeat != 1 {
+		t.Fatalf("the friend must be revealed, got seat %d", g.PartnerSeat)
+	}
+
+	// Under declarer_alone, a four-player friend pays nothing.
+	g = callingGame()
+	g.Config.NumPlayers = 4
+	g.Config.FailDist = FailDeclarerAlone
+	g.Players[4] = nil
+	g.Contract = &Bid{PlayerID: "p0", Points: 6, Suit: Spades} // S = 1 + 1×(6−4) = 3
+	g.Players[1].Hand = []Card{{Suit: Hearts, Rank: King}}
+	if err := g.ApplyMove("p0", MoveCallPartner, CallPartnerMove{Card: &Card{Suit: Hearts, Rank: King}}); err != nil {
+		t.Fatalf("call: %v", err)
+	}
+	if err := g.ApplyMove("p0", MoveConcede, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Scores["p0"] != -6 || g.Scores["p1"] != 0 || g.Scores["p2"] != 3 || g.Scores["p3"] != 3 {
+		t.Fatalf("unexpected declarer_alone concession scores: %v", g.Scores)
+	}
+}
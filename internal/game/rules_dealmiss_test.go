@@ -0,0 +1,181 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// dealMissGame returns a bidding-phase game with seat 1 holding hand.
+func dealMissGame(hand []Card) *Game {
+	g := New("deal-miss-test")
+	for i := range 5 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	g.Start()
+	g.Players[1].Hand = hand
+
+	return g
+}
+
+func weakHand(extra ...Card) []Card {
+	hand := []Card{
+		{Suit: Clubs, Rank: Two}, {Suit: Clubs, Rank: Four}, {Suit: Hearts, Rank: Five},
+		{Suit: Hearts, Rank: Six}, {Suit: Diamonds, Rank: Seven}, {Suit: Diamonds, Rank: Eight},
+		{Suit: Spades, Rank: Nine},
+	}
+
+	return append(hand, extra...)
+}
+
+func TestDealMissValueWeights(t *testing.T) {
+	t.Parallel()
+
+	g := New("weights")
+	hand := []Card{
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: Ace}, // Mighty before trump is set
+		{Suit: None, Rank: Joker},
+		{Suit: Clubs, Rank: Two},
+	}
+
+	if got := g.DealMissValue(hand); got != 0 {
+		t.Fatalf("default weights: got %d, want 0 (K=1, mighty=0, joker=-1)", got)
+	}
+
+	g.Config.DealMiss = &DealMissConfig{Threshold: 2, PointWeight: 2, MightyWeight: 3, JokerWeight: 1}
+	if got := g.DealMissValue(hand); got != 6 {
+		t.Fatalf("custom weights: got %d, want 6", got)
+	}
+}
+
+func TestDealMissValueIgnoresLastRoundsTrump(t *testing.T) {
+	t.Parallel()
+
+	g := New("second-round")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("player %d", i))
+	}
+
+	g.Config.DealMiss = &DealMissConfig{Threshold: 2, PointWeight: 2, MightyWeight: 3}
+	g.Status = PhaseFinished
+	g.Trump = Spades
+
+	for i := range 5 {
+		if err := g.ApplyMove(fmt.Sprintf("p%d", i), MovePlayAgain, nil); err != nil {
+			t.Fatalf("play_again: %v", err)
+		}
+	}
+
+	if g.Status != PhaseBidding || g.Trump != "" {
+		t.Fatalf("expected a fresh bidding round with no trump, got %s with trump %q", g.Status, g.Trump)
+	}
+
+	if got := g.DealMissValue([]Card{{Suit: Spades, Rank: Ace}}); got != 3 {
+		t.Fatalf("the spade ace is the Mighty before trump is chosen: got %d, want 3", got)
+	}
+
+	if got := g.DealMissValue([]Card{{Suit: Diamonds, Rank: Ace}}); got != 2 {
+		t.Fatalf("the diamond ace is only a point card: got %d, want 2", got)
+	}
+}
+
+func TestDealMissRedealsAndShowsHand(t *testing.T) {
+	t.Parallel()
+
+	hand := weakHand(Card{Suit: Hearts, Rank: Queen}, Card{Suit: Clubs, Rank: Three}, Card{Suit: Clubs, Rank: Five})
+	g := dealMissGame(hand)
+
+	// Seat 0 has already acted; seat 1 has not.
+	if err := g.ApplyMove("p0", MovePass, nil); err != nil {
+		t.Fatalf("setup pass: %v", err)
+	}
+
+	if err := g.ValidateMove("p1", MoveDealMiss, nil); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	versionBefore := g.Version
+	if err := g.ApplyMove("p1", MoveDealMiss, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Status != PhaseBidding || len(g.PassedPlayers) != 0 || g.Bids != nil {
+		t.Fatalf("bidding must restart from scratch: status=%s passed=%v bids=%v", g.Status, g.PassedPlayers, g.Bids)
+	}
+
+	if g.LastDealMiss == nil || g.LastDealMiss.Seat != 1 || g.LastDealMiss.Value != 1 {
+		t.Fatalf("deal miss not recorded: %+v", g.LastDealMiss)
+	}
+
+	if fmt.Sprint(g.LastDealMiss.Hand) != fmt.Sprint(hand) {
+		t.Fatalf("shown hand %v, want %v", g.LastDealMiss.Hand, hand)
+	}
+
+	if len(g.Players[1].Hand) != 10 || len(g.Kitty) != 3 {
+		t.Fatalf("redeal incomplete: hand=%d kitty=%d", len(g.Players[1].Hand), len(g.Kitty))
+	}
+
+	if g.Version <= versionBefore {
+		t.Fatal("version must advance")
+	}
+
+	// An all-pass redeal afterwards has no deal miss to show.
+	for range 5 {
+		if err := g.ApplyMove(g.Players[g.CurrentTurn].ID, MovePass, nil); err != nil {
+			t.Fatalf("pass: %v", err)
+		}
+	}
+
+	if g.LastDealMiss != nil {
+		t.Fatalf("the earlier deal miss outlived the next redeal: %+v", g.LastDealMiss)
+	}
+}
+
+func TestDealMissRejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		hand  []Card
+		setup func(g *Game)
+	}{
+		{
+			name: "over threshold",
+			hand: weakHand(Card{Suit: Hearts, Rank: Queen}, Card{Suit: Hearts, Rank: King}, Card{Suit: Clubs, Rank: Five}),
+		},
+		{
+			name: "already bid",
+			hand: weakHand(Card{Suit: Clubs, Rank: Three}, Card{Suit: Clubs, Rank: Five}, Card{Suit: Clubs, Rank: Six}),
+			setup: func(g *Game) {
+				g.Bids = append(g.Bids, Bid{PlayerID: "p1", Points: 0})
+			},
+		},
+		{
+			name:  "not bidding",
+			hand:  weakHand(Card{Suit: Clubs, Rank: Three}, Card{Suit: Clubs, Rank: Five}, Card{Suit: Clubs, Rank: Six}),
+			setup: func(g *Game) { g.Status = PhaseExchanging },
+		},
+		{
+			name:  "disabled",
+			hand:  weakHand(Card{Suit: Clubs, Rank: Three}, Card{Suit: Clubs, Rank: Five}, Card{Suit: Clubs, Rank: Six}),
+			setup: func(g *Game) { g.Config.DealMiss = nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := dealMissGame(tt.hand)
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			if err := g.ValidateMove("p1", MoveDealMiss, nil); !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+		})
+	}
+}
@@ -473,3 +473,152 @@ func TestFriendSeatNoFriendOrUnheld(t *testing.T) {
 		t.Fatalf("unheld friendSeat() = %d, want -1", got)
 	}
 }
+
+func TestCallPartnerFriendModes(t *testing.T) {
+	t.Parallel()
+
+	fourPlayer := func() *Game {
+		g := callingGame()
+		g.Config.NumPlayers = 4
+		g.Config.AllowJokerPartner = false
+		return g
+	}
+
+	tests := []struct {
+		name    string
+		game    func() *Game
+		move    CallPartnerMove
+		wantErr bool
+	}{
+		{name: "first trick", game: callingGame, move: CallPartnerMove{Mode: FriendFirstTrick}},
+		{name: "mighty", game: callingGame, move: CallPartnerMove{Mode: FriendMighty}},
+		{name: "joker", game: callingGame, move: CallPartnerMove{Mode: FriendJoker}},
+		{name: "explicit card mode", game: callingGame, move: CallPartnerMove{Mode: FriendCard, Card: &Card{Suit: Hearts, Rank: Ace}}},
+		{name: "card mode without card", game: callingGame, move: CallPartnerMove{Mode: FriendCard}, wantErr: true},
+		{name: "role mode with card", game: callingGame, move: CallPartnerMove{Mode: FriendMighty, Card: &Card{Suit: Hearts, Rank: Ace}}, wantErr: true},
+		{name: "role mode with no_friend", game: callingGame, move: CallPartnerMove{Mode: FriendFirstTrick, NoFriend: true}, wantErr: true},
+		{name: "unknown mode", game: callingGame, move: CallPartnerMove{Mode: "anyone"}, wantErr: true},
+		{name: "joker friend barred", game: fourPlayer, move: CallPartnerMove{Mode: FriendJoker}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.game().ValidateMove("p0", MoveCallPartner, tt.move)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateMove() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMightyFriendTracksTrump(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame() // spades trump, so the Mighty is ♦A
+	g.Players[1].Hand = []Card{{Suit: Spades, Rank: Ace}}
+	g.Players[3].Hand = []Card{{Suit: Diamonds, Rank: Ace}}
+
+	if err := g.ApplyMove("p0", MoveCallPartner, CallPartnerMove{Mode: FriendMighty}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.FriendMode != FriendMighty || g.PartnerCard != nil {
+		t.Fatalf("mighty call must be stored as a mode, got %q %+v", g.FriendMode, g.PartnerCard)
+	}
+
+	if got := g.friendSeat(); got != 3 {
+		t.Fatalf("friendSeat() = %d, want 3 (♦A under spades)", got)
+	}
+
+	g.Trump = Hearts
+	if got := g.friendSeat(); got != 1 {
+		t.Fatalf("friendSeat() = %d, want 1 (♠A under hearts)", got)
+	}
+}
+
+func TestJokerFriendIsJokerHolder(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame()
+	g.Players[4].Hand = []Card{{Suit: Clubs, Rank: Two}, {Suit: None, Rank: Joker}}
+
+	if err := g.ApplyMove("p0", MoveCallPartner, CallPartnerMove{Mode: FriendJoker}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if got := g.friendSeat(); got != 4 {
+		t.Fatalf("friendSeat() = %d, want 4", got)
+	}
+}
+
+// firstTrickFixture is a hearts-trump game on its first trick with the
+// first-trick friend called and every seat but turn already played.
+func firstTrickFixture(turn int, down []PlayedCard) *Game {
+	g := callingGame()
+	g.Trump = Hearts
+	g.Status = PhasePlaying
+	g.FriendMode = FriendFirstTrick
+	g.CurrentTurn = turn
+	g.Tricks = []Trick{{LeadSuit: Clubs, Cards: down}}
+
+	return g
+}
+
+func TestFirstTrickFriendRevealedImmediately(t *testing.T) {
+	t.Parallel()
+
+	g := firstTrickFixture(2, lowClubs())
+	g.Players[2].Hand = []Card{{Suit: Clubs, Rank: Nine}, {Suit: Diamonds, Rank: Two}}
+
+	if got := g.friendSeat(); got != -1 {
+		t.Fatalf("friend must be unknown before trick 1 ends, got %d", got)
+	}
+
+	// A pointless win still makes seat 2 the friend.
+	if err := g.ApplyMove("p2", MovePlayCard, PlayCardMove{Card: Card{Suit: Clubs, Rank: Nine}}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.PartnerSeat != 2 || g.friendSeat() != 2 {
+		t.Fatalf("expected seat 2 revealed as friend, got partner=%d friend=%d", g.PartnerSeat, g.friendSeat())
+	}
+}
+
+func TestFirstTrickWonByDeclarerIsSolo(t *testing.T) {
+	t.Parallel()
+
+	down := []PlayedCard{
+		{PlayerID: "p1", Seat: 1, Card: Card{Suit: Clubs, Rank: Two}},
+		{PlayerID: "p2", Seat: 2, Card: Card{Suit: Clubs, Rank: Three}},
+		{PlayerID: "p3", Seat: 3, Card: Card{Suit: Clubs, Rank: Four}},
+		{PlayerID: "p4", Seat: 4, Card: Card{Suit: Clubs, Rank: Five}},
+	}
+	g := firstTrickFixture(0, down)
+	g.Players[0].Hand = []Card{{Suit: Clubs, Rank: King}, {Suit: Diamonds, Rank: Two}}
+
+	if err := g.ApplyMove("p0", MovePlayCard, PlayCardMove{Card: Card{Suit: Clubs, Rank: King}}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.PartnerSeat != -1 {
+		t.Fatalf("declarer winning trick 1 must leave no partner, got %d", g.PartnerSeat)
+	}
+
+	// Scored as a solo without the announced no-friend double, like a
+	// secret solo: S = 2*(7-3) = 8, declarer 4S, each opponent -S.
+	g.Players[0].Points = make([]Card, 17)
+	scores := g.CalculateFinalScore()
+	if scores[0] != 32 {
+		t.Fatalf("expected declarer 32, got %d", scores[0])
+	}
+	for seat := 1; seat < 5; seat++ {
+		if scores[seat] != -8 {
+			t.Fatalf("expected opponent seat %d to be -8, got %d", seat, scores[seat])
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// sixPlayerEliminating returns a six-player game where seat 0 has won the
+// bidding with 5 hearts and must now eliminate a seat.
+func sixPlayerEliminating(t *testing.T) *Game {
+	t.Helper()
+
+	cfg := DefaultConfig()
+	cfg.NumPlayers = 6
+	g := NewWithConfig("six", cfg)
+	for i := range 6 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	if !g.IsFull() {
+		t.Fatal("six seated players should fill a six-player game")
+	}
+
+	g.Start()
+	if err := g.ApplyMove("p0", MoveBid, Bid{Points: 5, Suit: Hearts}); err != nil {
+		t.Fatalf("bid: %v", err)
+	}
+
+	for i := 1; i < 6; i++ {
+		if err := g.ApplyMove(fmt.Sprintf("p%d", i), MovePass, nil); err != nil {
+			t.Fatalf("pass %d: %v", i, err)
+		}
+	}
+
+	return g
+}
+
+func TestSixPlayerBiddingEndsInElimination(t *testing.T) {
+	t.Parallel()
+
+	g := sixPlayerEliminating(t)
+	if g.Status != PhaseEliminating {
+		t.Fatalf("status: got %s, want %s", g.Status, PhaseEliminating)
+	}
+
+	if len(g.Players[0].Hand) != 8 || len(g.Kitty) != 5 {
+		t.Fatalf("kitty must wait for the elimination: hand=%d kitty=%d", len(g.Players[0].Hand), len(g.Kitty))
+	}
+
+	if err := g.ValidateMove("p0", MoveDiscard, g.Players[0].Hand[:3]); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("discard before elimination: expected ErrInvalidMove, got %v", err)
+	}
+}
+
+func TestTimeoutEliminatesTheSeatAfterTheDeclarer(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.NumPlayers = 6
+	g := NewWithConfig("six", cfg)
+	for i := range 6 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	g.Start()
+	for _, id := range []string{"p0", "p1", "p2", "p3"} {
+		if err := g.ApplyMove(id, MovePass, nil); err != nil {
+			t.Fatalf("pass %s: %v", id, err)
+		}
+	}
+	if err := g.ApplyMove("p4", MoveBid, Bid{Points: 5, Suit: Hearts}); err != nil {
+		t.Fatalf("bid: %v", err)
+	}
+	if err := g.ApplyMove("p5", MovePass, nil); err != nil {
+		t.Fatalf("pass p5: %v", err)
+	}
+
+	if g.Status != PhaseEliminating || g.Declarer != 4 {
+		t.Fatalf("expected seat 4 to eliminate, got %s with declarer %d", g.Status, g.Declarer)
+	}
+
+	playerID, m, ok := g.TimeoutMove()
+	if !ok || playerID != "p4" || m.Type != MoveEliminate {
+		t.Fatalf("expected the declarer to eliminate, got %q %s (%v)", playerID, m.Type, ok)
+	}
+
+	if got := m.Payload.(EliminateMove).Seat; got != 5 {
+		t.Fatalf("expected the seat after the declarer's eliminated, got %d", got)
+	}
+}
+
+func TestSixPlayerEliminateRedealsToFive(t *testing.T) {
+	t.Parallel()
+
+	g := sixPlayerEliminating(t)
+	move := EliminateMove{Seat: 3}
+	if err := g.ValidateMove("p0", MoveEliminate, move); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+
+	if err := g.ApplyMove("p0", MoveEliminate, move); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Status != PhaseExchanging || g.Eliminated != 3 {
+		t.Fatalf("status=%s eliminated=%d", g.Status, g.Eliminated)
+	}
+
+	want := map[int]int{0: 13, 1: 10, 2: 10, 3: 0, 4: 10, 5: 10}
+	seen := map[Card]bool{}
+	for seat, p := range g.Players {
+		if len(p.Hand) != want[seat] {
+			t.Errorf("seat %d holds %d cards, want %d", seat, len(p.Hand), want[seat])
+		}
+
+		for _, c := range p.Hand {
+			if seen[c] {
+				t.Fatalf("card %v dealt twice", c)
+			}
+			seen[c] = true
+		}
+	}
+
+	if len(seen) != 53 || len(g.Kitty) != 0 {
+		t.Fatalf("all 53 cards must be in hand: got %d, kitty %d", len(seen), len(g.Kitty))
+	}
+}
+
+func TestSixPlayerPlaySkipsEliminatedSeat(t *testing.T) {
+	t.Parallel()
+
+	g := sixPlayerEliminating(t)
+	if err := g.ApplyMove("p0", MoveEliminate, EliminateMove{Seat: 1}); err != nil {
+		t.Fatalf("eliminate: %v", err)
+	}
+
+	if got := g.nextSeat(0); got != 2 {
+		t.Fatalf("seat after the declarer: got %d, want 2", got)
+	}
+
+	if got := g.nextSeat(5); got != 0 {
+		t.Fatalf("seat after 5: got %d, want 0", got)
+	}
+
+	if g.numActive() != 5 {
+		t.Fatalf("active seats: got %d, want 5", g.numActive())
+	}
+}
+
+func TestSixPlayerEliminatedSeatScoresZero(t *testing.T) {
+	t.Parallel()
+
+	g := sixPlayerEliminating(t)
+	g.Eliminated = 2
+	g.PartnerSeat = 1
+	g.PartnerCard = &Card{Suit: Diamonds, Rank: Ace}
+	for _, p := range g.Players {
+		p.Hand = nil
+	}
+	g.Players[1].Hand = []Card{*g.PartnerCard}
+	g.Players[0].Points = make([]Card, 15)
+	g.Players[3].Points = make([]Card, 5)
+
+	scores := g.CalculateFinalScore()
+	if scores[2] != 0 {
+		t.Fatalf("eliminated seat scored %d", scores[2])
+	}
+
+	// Success at 5 with 15 cards: S = 2*(5-3) + 0 = 4 against three opponents.
+	want := map[int]int{0: 8, 1: 4, 2: 0, 3: -4, 4: -4, 5: -4}
+	sum := 0
+	for seat, w := range want {
+		if scores[seat] != w {
+			t.Errorf("seat %d: got %d, want %d", seat, scores[seat], w)
+		}
+		sum += scores[seat]
+	}
+
+	if sum != 0 {
+		t.Fatalf("scores must be zero-sum, got %d", sum)
+	}
+}
+
+func TestEliminateRejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		player string
+		seat   int
+	}{
+		{name: "non-declarer", player: "p1", seat: 2},
+		{name: "self", player: "p0", seat: 0},
+		{name: "out of range", player: "p0", seat: 6},
+		{name: "negative", player: "p0", seat: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := sixPlayerEliminating(t)
+			if err := g.ValidateMove(tt.player, MoveEliminate, EliminateMove{Seat: tt.seat}); !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+		})
+	}
+}
+
+func TestChangeConfigToSixGrowsSeats(t *testing.T) {
+	t.Parallel()
+
+	g := New("grow")
+	g.Status = PhaseFinished
+	if err := g.ApplyMove("", MoveChangeConfig, ChangeConfigMove{NumPlayers: 6}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.Config.NumPlayers != 6 || len(g.Players) != 6 {
+		t.Fatalf("num_players=%d seats=%d, want 6", g.Config.NumPlayers, len(g.Players))
+	}
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// exchangingGame returns a game where seat 0 won the bidding with the given
+// contract and holds the kitty.
+func exchangingGame(contract Bid) *Game {
+	g := New("trump-change-test")
+	for i := range 5 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	g.Start()
+	g.Status = PhaseExchanging
+	g.Declarer = 0
+	g.CurrentTurn = 0
+	contract.PlayerID = "p0"
+	g.CurrentBid = &contract
+	g.Contract = g.CurrentBid
+	g.Trump = contract.Suit
+	g.Players[0].Hand = append(g.Players[0].Hand, g.Kitty...)
+	g.Kitty = nil
+
+	return g
+}
+
+func TestChangeTrumpRaisesContract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		contract   Bid
+		move       ChangeTrumpMove
+		wantPoints int
+	}{
+		{"suit to suit", Bid{Points: 5, Suit: Hearts}, ChangeTrumpMove{Suit: Spades}, 6},
+		{"suit to no-trump", Bid{Points: 5, Suit: Hearts}, ChangeTrumpMove{Suit: None, IsNoTrump: true}, 7},
+		{"no-trump to suit", Bid{Points: 5, Suit: None, IsNoTrump: true}, ChangeTrumpMove{Suit: Clubs}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := exchangingGame(tt.contract)
+			if err := g.ValidateMove("p0", MoveChangeTrump, tt.move); err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+
+			if err := g.ApplyMove("p0", MoveChangeTrump, tt.move); err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+
+			if g.Contract.Points != tt.wantPoints || g.Contract.Suit != tt.move.Suit || g.Contract.IsNoTrump != tt.move.IsNoTrump {
+				t.Fatalf("contract not raised: %+v", g.Contract)
+			}
+
+			if g.Trump != tt.move.Suit {
+				t.Fatalf("trump = %s, want %s", g.Trump, tt.move.Suit)
+			}
+
+			if g.Status != PhaseExchanging {
+				t.Fatalf("trump change must leave the discard pending, got %s", g.Status)
+			}
+		})
+	}
+}
+
+func TestChangeTrumpUpdatesTheCurrentBid(t *testing.T) {
+	t.Parallel()
+
+	// A game loaded from storage no longer shares one Bid between the two.
+	data, err := json.Marshal(exchangingGame(Bid{Points: 5, Suit: Hearts}))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var g Game
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if err := g.ApplyMove("p0", MoveChangeTrump, ChangeTrumpMove{Suit: Spades}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.CurrentBid == nil || *g.CurrentBid != *g.Contract {
+		t.Fatalf("current bid %+v must follow the contract %+v", g.CurrentBid, g.Contract)
+	}
+}
+
+func TestChangeTrumpRecomputesSpecialCards(t *testing.T) {
+	t.Parallel()
+
+	g := exchangingGame(Bid{Points: 5, Suit: Hearts})
+	spadeAce := Card{Suit: Spades, Rank: Ace}
+	clubThree := Card{Suit: Clubs, Rank: Three}
+
+	if !g.IsMighty(spadeAce) || !g.IsJokerCaller(clubThree) {
+		t.Fatal("precondition: hearts trump uses the standard mighty and joker caller")
+	}
+
+	if err := g.ApplyMove("p0", MoveChangeTrump, ChangeTrumpMove{Suit: Spades}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.IsMighty(spadeAce) || !g.IsMighty(Card{Suit: Diamonds, Rank: Ace}) {
+		t.Fatal("mighty must shift once spades become trump")
+	}
+
+	g = exchangingGame(Bid{Points: 5, Suit: Hearts})
+	if err := g.ApplyMove("p0", MoveChangeTrump, ChangeTrumpMove{Suit: Clubs}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if g.IsJokerCaller(clubThree) || !g.IsJokerCaller(Card{Suit: Spades, Rank: Three}) {
+		t.Fatal("joker caller must shift once clubs become trump")
+	}
+}
+
+func TestChangeTrumpRejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		contract Bid
+		player   string
+		move     ChangeTrumpMove
+		setup    func(g *Game)
+	}{
+		{name: "non-declarer", contract: Bid{Points: 5, Suit: Hearts}, player: "p1", move: ChangeTrumpMove{Suit: Spades}},
+		{name: "same trump", contract: Bid{Points: 5, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: Hearts}},
+		{name: "over 10", contract: Bid{Points: 10, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: Spades}},
+		{name: "no-trump over 10", contract: Bid{Points: 9, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: None, IsNoTrump: true}},
+		{name: "no-trump with suit", contract: Bid{Points: 5, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: Spades, IsNoTrump: true}},
+		{name: "bad suit", contract: Bid{Points: 5, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: "stars"}},
+		{
+			name: "after discard", contract: Bid{Points: 5, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: Spades},
+			setup: func(g *Game) { g.Status = PhaseCalling },
+		},
+		{
+			name: "second change", contract: Bid{Points: 5, Suit: Hearts}, player: "p0", move: ChangeTrumpMove{Suit: Spades},
+			setup: func(g *Game) { g.TrumpChanged = true },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := exchangingGame(tt.contract)
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			if err := g.ValidateMove(tt.player, MoveChangeTrump, tt.move); !errors.Is(err, ErrInvalidMove) {
+				t.Fatalf("expected ErrInvalidMove, got %v", err)
+			}
+		})
+	}
+}
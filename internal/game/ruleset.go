@@ -0,0 +1,172 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRuleSet is returned for a rule set whose special cards clash or
+// are not real cards.
+var ErrInvalidRuleSet = errors.New("invalid rule set")
+
+// JokerCallResponse is what a Joker call obliges the Joker's holder to play.
+type JokerCallResponse string
+
+const (
+	// JokerCallJokerOrMighty makes the holder play the Joker, or the Mighty
+	// if they hold it too.
+	JokerCallJokerOrMighty JokerCallResponse = "joker_or_mighty"
+	// JokerCallJoker makes the holder play the Joker.
+	JokerCallJoker JokerCallResponse = "joker"
+	// JokerCallFree obliges no one; the call only strips the Joker's power.
+	JokerCallFree JokerCallResponse = "free"
+)
+
+// RuleSet holds the house rules for the special cards: which cards are the
+// Mighty and the Joker Caller, when the Joker has power, when the Joker Caller
+// may call, and which plays of them are forced or banned. Every flag's zero
+// value is the standard rule, so rule sets stored before a flag existed keep
+// playing as they did.
+type RuleSet struct {
+	Name string `json:"name"`
+
+	Mighty         Card `json:"mighty"`           // the Mighty unless its suit is trump
+	AltMighty      Card `json:"alt_mighty"`       // the Mighty when Mighty's suit is trump
+	JokerCaller    Card `json:"joker_caller"`     // the Joker Caller unless its suit is trump
+	AltJokerCaller Card `json:"alt_joker_caller"` // the Joker Caller when JokerCaller's suit is trump
+
+	JokerPowerFirstTrick bool `json:"joker_power_first_trick"` // Joker keeps its power on trick 1
+	JokerPowerLastTrick  bool `json:"joker_power_last_trick"`  // Joker keeps its power on trick 10
+	JokerCallFirstTrick  bool `json:"joker_call_first_trick"`  // Joker Caller may call on trick 1
+	JokerCallLastTrick   bool `json:"joker_call_last_trick"`   // Joker Caller may call on trick 10
+
+	// ForceLateSpecials makes a player holding both the Mighty and the Joker
+	// with three cards left, or either with two left, play one of them.
+	ForceLateSpecials bool `json:"force_late_specials"`
+
+	// JokerCall is what the Joker's holder must play when the Joker is
+	// called; empty means JokerCallJokerOrMighty.
+	JokerCall JokerCallResponse `json:"joker_call,omitempty"`
+
+	// The first trick bans leading or playing the Joker and the Mighty
+	// (the Mighty may still follow as the last card of the led suit), and
+	// leading trump while holding anything else. Each flag lifts one ban.
+	FirstTrickJoker     bool `json:"first_trick_joker"`      // Joker may be led or played on trick 1
+	FirstTrickMighty    bool `json:"first_trick_mighty"`     // Mighty may be led or played on trick 1
+	FirstTrickTrumpLead bool `json:"first_trick_trump_lead"` // trump may be led on trick 1
+}
+
+// Rule set presets accepted by RuleSetByName.
+const (
+	RuleSetOfficial = "official"
+	RuleSetCampus   = "campus"
+)
+
+// RuleSetCustom names a preset whose rules a table has changed.
+const RuleSetCustom = "custom"
+
+// OfficialRules is the standard Korean rule set: ♠A Mighty (♦A when spades
+// are trump), ♣3 Joker Caller (♠3 when clubs are trump), a Joker with no power
+// and no Joker call on the first and last tricks, and no forced late play.
+func OfficialRules() RuleSet {
+	return RuleSet{
+		Name:           RuleSetOfficial,
+		Mighty:         Card{Suit: Spades, Rank: Ace},
+		AltMighty:      Card{Suit: Diamonds, Rank: Ace},
+		JokerCaller:    Card{Suit: Clubs, Rank: Three},
+		AltJokerCaller: Card{Suit: Spades, Rank: Three},
+	}
+}
+
+// CampusRules is the official rule set plus forced Mighty/Joker play on the
+// last tricks. It is the default.
+func CampusRules() RuleSet {
+	rs := OfficialRules()
+	rs.Name = RuleSetCampus
+	rs.ForceLateSpecials = true
+	return rs
+}
+
+// RuleSetByName returns the named preset.
+func RuleSetByName(name string) (RuleSet, bool) {
+	switch name {
+	case RuleSetOfficial:
+		return OfficialRules(), true
+	case RuleSetCampus:
+		return CampusRules(), true
+	default:
+		return RuleSet{}, false
+	}
+}
+
+// Validate checks that the special cards are real, distinct cards, with
+// each alternate in a different suit from the card it stands in for.
+func (rs RuleSet) Validate() error {
+	for _, c := range []Card{rs.Mighty, rs.AltMighty, rs.JokerCaller, rs.AltJokerCaller} {
+		if _, ok := suitRank[c.Suit]; !ok || !validRanks[c.Rank] {
+			return fmt.Errorf("%w: %s is not a special card candidate", ErrInvalidRuleSet, c)
+		}
+	}
+
+	switch {
+	case rs.AltMighty.Suit == rs.Mighty.Suit:
+		return fmt.Errorf("%w: the alternate Mighty must be in another suit", ErrInvalidRuleSet)
+	case rs.AltJokerCaller.Suit == rs.JokerCaller.Suit:
+		return fmt.Errorf("%w: the alternate Joker Caller must be in another suit", ErrInvalidRuleSet)
+	}
+
+	for _, m := range []Card{rs.Mighty, rs.AltMighty} {
+		if m == rs.JokerCaller || m == rs.AltJokerCaller {
+			return fmt.Errorf("%w: %s cannot be both the Mighty and the Joker Caller", ErrInvalidRuleSet, m)
+		}
+	}
+
+	switch rs.JokerCall {
+	case "", JokerCallJokerOrMighty, JokerCallJoker, JokerCallFree:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown joker_call %q", ErrInvalidRuleSet, rs.JokerCall)
+	}
+}
+
+// JokerCallForced reports whether a Joker call makes its holder play it.
+func (g *Game) JokerCallForced() bool {
+	return g.rules().JokerCall != JokerCallFree
+}
+
+// rules returns the game's rule set. Games stored before rule sets existed
+// carry a zero RuleSet and play by the campus rules they were created under.
+func (g *Game) rules() RuleSet {
+	if g.Config.Rules.Mighty.Rank == "" {
+		return CampusRules()
+	}
+	return g.Config.Rules
+}
+
+// mightyUnder returns the Mighty when trump is trump: the rule set's Mighty,
+// or its alternate when the Mighty's suit is trump.
+func (rs RuleSet) mightyUnder(trump Suit) Card {
+	if trump == rs.Mighty.Suit {
+		return rs.AltMighty
+	}
+	return rs.Mighty
+}
+
+// jokerCallerUnder returns the Joker Caller when trump is trump: the rule
+// set's Joker Caller, or its alternate when its suit is trump.
+func (rs RuleSet) jokerCallerUnder(trump Suit) Card {
+	if trump == rs.JokerCaller.Suit {
+		return rs.AltJokerCaller
+	}
+	return rs.JokerCaller
+}
+
+// jokerPowerless reports whether the Joker has no power on trickNum.
+func (rs RuleSet) jokerPowerless(trickNum int) bool {
+	return (trickNum == 1 && !rs.JokerPowerFirstTrick) || (trickNum == 10 && !rs.JokerPowerLastTrick)
+}
+
+// jokerCallAllowed reports whether the Joker Caller may call on trickNum.
+func (rs RuleSet) jokerCallAllowed(trickNum int) bool {
+	return (trickNum != 1 || rs.JokerCallFirstTrick) && (trickNum != 10 || rs.JokerCallLastTrick)
+}
@@ -0,0 +1,220 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRuleSetPresets(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{RuleSetOfficial, RuleSetCampus} {
+		rs, ok := RuleSetByName(name)
+		if !ok || rs.Name != name {
+			t.Fatalf("preset %q not found: %+v", name, rs)
+		}
+	}
+
+	if _, ok := RuleSetByName("tournament"); ok {
+		t.Fatal("unknown preset must not resolve")
+	}
+
+	if DefaultConfig().Rules != CampusRules() {
+		t.Fatal("default config must play campus rules")
+	}
+}
+
+func TestZeroRuleSetFallsBackToCampus(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithConfig("legacy", GameConfig{NumPlayers: 5})
+	if g.rules() != CampusRules() {
+		t.Fatalf("zero rule set must play campus rules, got %+v", g.rules())
+	}
+}
+
+func TestRuleSetSpecialCards(t *testing.T) {
+	t.Parallel()
+
+	g := New("custom")
+	g.Config.Rules.Mighty = Card{Suit: Hearts, Rank: Ace}
+	g.Config.Rules.AltMighty = Card{Suit: Clubs, Rank: Ace}
+	g.Config.Rules.JokerCaller = Card{Suit: Diamonds, Rank: Three}
+	g.Config.Rules.AltJokerCaller = Card{Suit: Hearts, Rank: Three}
+
+	g.Trump = Spades
+	if !g.IsMighty(Card{Suit: Hearts, Rank: Ace}) || g.IsMighty(Card{Suit: Spades, Rank: Ace}) {
+		t.Fatal("configured Mighty not honoured")
+	}
+
+	if !g.IsJokerCaller(Card{Suit: Diamonds, Rank: Three}) {
+		t.Fatal("configured Joker Caller not honoured")
+	}
+
+	g.Trump = Hearts
+	if !g.IsMighty(Card{Suit: Clubs, Rank: Ace}) || g.IsMighty(Card{Suit: Hearts, Rank: Ace}) {
+		t.Fatal("alternate Mighty must apply when the Mighty's suit is trump")
+	}
+
+	g.Trump = Diamonds
+	if !g.IsJokerCaller(Card{Suit: Hearts, Rank: Three}) {
+		t.Fatal("alternate Joker Caller must apply when its suit is trump")
+	}
+}
+
+func TestRuleSetJokerPower(t *testing.T) {
+	t.Parallel()
+
+	joker := Card{Suit: None, Rank: Joker}
+	g := New("power")
+	g.Trump = Hearts
+
+	if got := g.CalculatePower(joker, Trick{}, 1); got != PowerBase {
+		t.Fatalf("campus joker on trick 1: got %d, want %d", got, PowerBase)
+	}
+
+	g.Config.Rules.JokerPowerFirstTrick = true
+	if got := g.CalculatePower(joker, Trick{}, 1); got != PowerJoker {
+		t.Fatalf("joker with first-trick power: got %d, want %d", got, PowerJoker)
+	}
+
+	if got := g.CalculatePower(joker, Trick{}, 10); got != PowerBase {
+		t.Fatalf("joker on trick 10: got %d, want %d", got, PowerBase)
+	}
+}
+
+// lateGame returns a game on trick 9 where p0 leads holding the Mighty and a
+// low club (spades are trump, so the Mighty is ♦A).
+func lateGame(rules RuleSet) *Game {
+	g := jokerLeadGame()
+	g.Config.Rules = rules
+	g.Tricks = make([]Trick, 9)
+	g.Tricks[8] = Trick{Cards: []PlayedCard{}}
+	g.Players[0].Hand = []Card{{Suit: Diamonds, Rank: Ace}, {Suit: Clubs, Rank: Two}}
+
+	return g
+}
+
+func TestRuleSetLateForcing(t *testing.T) {
+	t.Parallel()
+
+	move := PlayCardMove{Card: Card{Suit: Clubs, Rank: Two}}
+
+	if err := lateGame(CampusRules()).ValidateMove("p0", MovePlayCard, move); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("campus must force the Mighty, got %v", err)
+	}
+
+	if err := lateGame(OfficialRules()).ValidateMove("p0", MovePlayCard, move); err != nil {
+		t.Fatalf("official must not force the Mighty, got %v", err)
+	}
+}
+
+func TestRuleSetJokerCallFirstTrick(t *testing.T) {
+	t.Parallel()
+
+	g := jokerLeadGame()
+	g.Tricks = []Trick{{Cards: []PlayedCard{}}}
+	g.Players[0].Hand = []Card{{Suit: Clubs, Rank: Three}, {Suit: Clubs, Rank: Two}}
+	move := PlayCardMove{Card: Card{Suit: Clubs, Rank: Three}, CallJoker: true}
+
+	if err := g.ValidateMove("p0", MovePlayCard, move); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("joker call on trick 1 must be rejected by default, got %v", err)
+	}
+
+	g.Config.Rules.JokerCallFirstTrick = true
+	if err := g.ValidateMove("p0", MovePlayCard, move); err != nil {
+		t.Fatalf("joker call on trick 1 allowed by rule set, got %v", err)
+	}
+}
+
+func TestRuleSetJokerCallResponse(t *testing.T) {
+	t.Parallel()
+
+	// p1 led the Joker Caller and called; p2 holds the Joker, the Mighty
+	// (♦A, as spades are trump) and a club.
+	g := jokerLeadGame()
+	g.Tricks[1] = Trick{Cards: []PlayedCard{{PlayerID: "p1", Seat: 1, Card: Card{Suit: Clubs, Rank: Three}}}, LeadSuit: Clubs, JokerCalled: true}
+	g.CurrentTurn = 2
+	g.Players[2].Hand = []Card{
+		{Suit: None, Rank: Joker}, {Suit: Diamonds, Rank: Ace}, {Suit: Clubs, Rank: Five},
+		{Suit: Hearts, Rank: Two}, {Suit: Hearts, Rank: Four}, {Suit: Hearts, Rank: Six},
+	}
+
+	club := PlayCardMove{Card: Card{Suit: Clubs, Rank: Five}}
+	mighty := PlayCardMove{Card: Card{Suit: Diamonds, Rank: Ace}}
+
+	for _, tc := range []struct {
+		call           JokerCallResponse
+		club, mightyOK bool
+	}{
+		{"", false, true},
+		{JokerCallJoker, false, false},
+		{JokerCallFree, true, true},
+	} {
+		g.Config.Rules.JokerCall = tc.call
+
+		if err := g.ValidateMove("p2", MovePlayCard, club); (err == nil) != tc.club {
+			t.Fatalf("%q: playing a club gave %v", tc.call, err)
+		}
+		if err := g.ValidateMove("p2", MovePlayCard, mighty); (err == nil) != tc.mightyOK {
+			t.Fatalf("%q: playing the Mighty gave %v", tc.call, err)
+		}
+	}
+}
+
+func TestRuleSetFirstTrickBans(t *testing.T) {
+	t.Parallel()
+
+	g := jokerLeadGame()
+	g.Tricks = []Trick{{Cards: []PlayedCard{}}}
+	g.Players[0].Hand = []Card{{Suit: None, Rank: Joker}, {Suit: Diamonds, Rank: Ace}, {Suit: Spades, Rank: Two}, {Suit: Clubs, Rank: Two}}
+
+	leads := []PlayCardMove{
+		{Card: Card{Suit: None, Rank: Joker}, CalledSuit: Clubs},
+		{Card: Card{Suit: Diamonds, Rank: Ace}},
+		{Card: Card{Suit: Spades, Rank: Two}},
+	}
+
+	for _, move := range leads {
+		if err := g.ValidateMove("p0", MovePlayCard, move); !errors.Is(err, ErrInvalidMove) {
+			t.Fatalf("leading %s on trick 1 must be banned by default, got %v", move.Card, err)
+		}
+	}
+
+	g.Config.Rules.FirstTrickJoker = true
+	g.Config.Rules.FirstTrickMighty = true
+	g.Config.Rules.FirstTrickTrumpLead = true
+
+	for _, move := range leads {
+		if err := g.ValidateMove("p0", MovePlayCard, move); err != nil {
+			t.Fatalf("leading %s on trick 1 allowed by rule set, got %v", move.Card, err)
+		}
+	}
+}
+
+func TestRuleSetValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := CampusRules().Validate(); err != nil {
+		t.Fatalf("campus rules: %v", err)
+	}
+
+	clubs := OfficialRules()
+	clubs.AltMighty = Card{Suit: Clubs, Rank: Ace}
+	if err := clubs.Validate(); err != nil {
+		t.Fatalf("a ♣A alternate Mighty is a valid house rule: %v", err)
+	}
+
+	for name, change := range map[string]func(*RuleSet){
+		"joker mighty":       func(rs *RuleSet) { rs.Mighty = Card{Suit: None, Rank: Joker} },
+		"same-suit alt":      func(rs *RuleSet) { rs.AltMighty = Card{Suit: Spades, Rank: King} },
+		"mighty is caller":   func(rs *RuleSet) { rs.JokerCaller = rs.Mighty; rs.AltJokerCaller = Card{Suit: Hearts, Rank: Three} },
+		"unknown joker call": func(rs *RuleSet) { rs.JokerCall = "sometimes" },
+	} {
+		rs := OfficialRules()
+		change(&rs)
+		if err := rs.Validate(); !errors.Is(err, ErrInvalidRuleSet) {
+			t.Fatalf("%s: expected ErrInvalidRuleSet, got %v", name, err)
+		}
+	}
+}
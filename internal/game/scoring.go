@@ -0,0 +1,270 @@
+package game
+
+import "encoding/json"
+
+// Scoring selects how a played hand is priced. Every strategy computes one
+// unit S and shares it out the same zero-sum way; they differ only in how S
+// is reached.
+type Scoring string
+
+const (
+	// ScoringOfficial is the Korean federation formula with run, back-run,
+	// no-trump and no-friend doublings. It is the default.
+	ScoringOfficial Scoring = "official"
+	// ScoringCampus prices the contract at 10 a level plus 5 a scoring card
+	// over or beyond the first under, doubles for no-trump, no-friend and a
+	// 10 bid, and caps S at campusScoreCap.
+	ScoringCampus Scoring = "campus"
+	// ScoringTrickPoints counts only the margin: one more than the scoring
+	// cards over the target on success, the cards short on failure, with no
+	// doublings.
+	ScoringTrickPoints Scoring = "trick_points"
+)
+
+// campusScoreCap is the largest S campus scoring pays.
+const campusScoreCap = 800
+
+// Doubling names one ×2 applied to a round's S.
+type Doubling string
+
+const (
+	DoubleRun      Doubling = "run"       // declarer's team took all 20 scoring cards
+	DoubleBackRun  Doubling = "back_run"  // defenders took 11 or more
+	DoubleNoTrump  Doubling = "no_trump"  // the contract was no-trump
+	DoubleNoFriend Doubling = "no_friend" // the declarer announced a solo
+	DoubleTenBid   Doubling = "ten_bid"   // campus only: a bid of 10
+)
+
+// Solo tells an announced solo (no_friend, doubled) from a secret one: the
+// declarer called a card they held themselves, or one nobody held.
+type Solo string
+
+const (
+	SoloAnnounced Solo = "announced"
+	SoloSecret    Solo = "secret"
+)
+
+// RoundScore is the breakdown of one scored round.
+type RoundScore struct {
+	Scoring     Scoring        `json:"scoring"`
+	Bid         int            `json:"bid"`
+	P           int            `json:"p"`      // scoring cards the declarer's team took
+	Target      int            `json:"target"` // bid + 10
+	Success     bool           `json:"success"`
+	Base        int            `json:"base"` // S before doublings
+	Doublings   []Doubling     `json:"doublings,omitempty"`
+	S           int            `json:"s"` // S after doublings and any cap
+	Solo        Solo           `json:"solo,omitempty"`
+	Conceded    bool           `json:"conceded,omitempty"`
+	ForfeitedBy string         `json:"forfeited_by,omitempty"` // player who left mid-hand, ending it
+	Scores      map[string]int `json:"scores"`                 // zero-sum result by player ID
+}
+
+// UnmarshalJSON also reads the bare player ID → score maps that games stored
+// before breakdowns kept in ScoreHistory.
+func (r *RoundScore) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	if _, ok := fields["scores"]; !ok {
+		*r = RoundScore{}
+		return json.Unmarshal(data, &r.Scores)
+	}
+
+	type plain RoundScore
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// scoring returns the game's scoring strategy; games stored before there was
+// a choice score officially.
+func (g *Game) scoring() Scoring {
+	if g.Config.Scoring == "" {
+		return ScoringOfficial
+	}
+	return g.Config.Scoring
+}
+
+// CalculateFinalScore computes each seat's signed round score under the
+// game's scoring strategy. The returned map is keyed by seat index and always
+// sums to zero.
+func (g *Game) CalculateFinalScore() map[int]int {
+	_, scores := g.scoreHand()
+	return scores
+}
+
+// scoreHand prices the hand and returns its breakdown with the per-seat
+// result. Bids are on the 3-10 scale; the scoring-card target is bid + 10.
+func (g *Game) scoreHand() (RoundScore, map[int]int) {
+	rs := RoundScore{Scoring: g.scoring()}
+	if g.Contract == nil {
+		return rs, make(map[int]int)
+	}
+
+	fs := g.friendSeat()
+	rs.Bid = g.Contract.Points
+	rs.Target = rs.Bid + 10
+	rs.P = g.teamPoints(fs)
+	rs.Success = rs.P >= rs.Target
+
+	switch {
+	case g.IsNoFriend:
+		rs.Solo = SoloAnnounced
+	case fs < 0 || fs == g.Declarer:
+		rs.Solo = SoloSecret
+	}
+
+	switch rs.Scoring {
+	case ScoringCampus:
+		g.scoreCampus(&rs)
+	case ScoringTrickPoints:
+		if rs.Success {
+			rs.Base = 1 + rs.P - rs.Target
+		} else {
+			rs.Base = rs.Target - rs.P
+		}
+	default:
+		g.scoreOfficial(&rs)
+	}
+
+	rs.S = rs.Base
+	for range rs.Doublings {
+		rs.S *= 2
+	}
+	if rs.Scoring == ScoringCampus {
+		rs.S = min(rs.S, campusScoreCap)
+	}
+
+	return rs, g.distribute(rs.S, rs.Success, fs)
+}
+
+// scoreOfficial sets S under the official regulations.
+func (g *Game) scoreOfficial(rs *RoundScore) {
+	if rs.Success {
+		rs.Base = 2*(rs.Bid-g.minBidPoints()) + (rs.P - rs.Target)
+	} else {
+		rs.Base = rs.Target - rs.P
+	}
+
+	if rs.P == 20 {
+		rs.Doublings = append(rs.Doublings, DoubleRun)
+	}
+	if 20-rs.P >= 11 {
+		rs.Doublings = append(rs.Doublings, DoubleBackRun)
+	}
+	if g.Contract.IsNoTrump {
+		rs.Doublings = append(rs.Doublings, DoubleNoTrump)
+	}
+	if g.IsNoFriend {
+		rs.Doublings = append(rs.Doublings, DoubleNoFriend)
+	}
+}
+
+// scoreCampus sets S under the campus formula.
+func (g *Game) scoreCampus(rs *RoundScore) {
+	if rs.Success {
+		rs.Base = 10*rs.Bid + 5*(rs.P-rs.Target)
+	} else {
+		rs.Base = 10*rs.Bid + 5*(rs.Target-rs.P-1)
+	}
+
+	if g.Contract.IsNoTrump {
+		rs.Doublings = append(rs.Doublings, DoubleNoTrump)
+	}
+	if g.IsNoFriend {
+		rs.Doublings = append(rs.Doublings, DoubleNoFriend)
+	}
+	if rs.Bid == 10 {
+		rs.Doublings = append(rs.Doublings, DoubleTenBid)
+	}
+}
+
+// teamPoints is P: the scoring cards captured by the declarer and the friend
+// in seat fs. All 20 point cards are always distributed - trick points go to
+// winners, kitty discards to the declarer.
+func (g *Game) teamPoints(fs int) int {
+	p := 0
+	for seat, player := range g.Players {
+		if player == nil || g.isEliminated(seat) {
+			continue
+		}
+		if seat == g.Declarer || seat == fs {
+			p += len(player.Points)
+		}
+	}
+	return p
+}
+
+// distribute shares S out so the result sums to zero. A configured
+// four-player 2-vs-2 failure uses a special split; every other case (all
+// wins, all alone games, and every five-player result) uses the standard
+// formula where each opponent pays S, the partner collects S, and the
+// declarer collects the remainder, with signs flipped on failure. A seat
+// eliminated from a six-player hand scores zero.
+func (g *Game) distribute(s int, success bool, fs int) map[int]int {
+	scores := make(map[int]int)
+	declarer := g.Declarer
+	partnerPresent := fs >= 0 && fs != declarer
+
+	oppCount := 0
+	for seat, player := range g.Players {
+		if player != nil && !g.isEliminated(seat) && seat != declarer && (!partnerPresent || seat != fs) {
+			oppCount++
+		}
+	}
+
+	sign := 1
+	if !success {
+		sign = -1
+	}
+	partnerShare := 0
+	if partnerPresent {
+		partnerShare = s
+	}
+
+	special := !success && partnerPresent && g.Config.NumPlayers == 4 &&
+		(g.Config.FailDist == FailDeclarerAlone || g.Config.FailDist == FailTwoOneSplit)
+
+	if special {
+		var declarerPay, partnerPay, oppGain int
+		switch g.Config.FailDist {
+		case FailDeclarerAlone:
+			declarerPay, partnerPay, oppGain = 2*s, 0, s
+		default: // FailTwoOneSplit
+			oppGain = (3*s + 1) / 2 // ceil(1.5*s)
+			partnerPay = s
+			declarerPay = 2*oppGain - partnerPay // 2s if s even, 2s+1 if s odd
+		}
+		for seat, player := range g.Players {
+			if player == nil || g.isEliminated(seat) {
+				continue
+			}
+			switch {
+			case seat == declarer:
+				scores[seat] = -declarerPay
+			case seat == fs:
+				scores[seat] = -partnerPay
+			default:
+				scores[seat] = oppGain
+			}
+		}
+		return scores
+	}
+
+	for seat, player := range g.Players {
+		if player == nil || g.isEliminated(seat) {
+			continue
+		}
+		switch {
+		case seat == declarer:
+			scores[seat] = sign * (oppCount*s - partnerShare)
+		case partnerPresent && seat == fs:
+			scores[seat] = sign * s
+		default:
+			scores[seat] = -sign * s
+		}
+	}
+
+	return scores
+}
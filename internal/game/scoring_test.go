@@ -0,0 +1,143 @@
+package game
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestScoringStrategies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		scoring           Scoring
+		bid               int
+		noTrump, noFriend bool
+		teamPoints        int
+		wantBase, wantS   int
+		wantDeclarer      int
+	}{
+		{"official success", ScoringOfficial, 5, false, false, 16, 5, 5, 10},
+		{"empty is official", "", 5, false, false, 16, 5, 5, 10},
+		// 10×5 + 5×1 over; partner and three opponents: 3×55 − 55.
+		{"campus success", ScoringCampus, 5, false, false, 16, 55, 55, 110},
+		// Two short: the first is free, the second costs 5.
+		{"campus failure", ScoringCampus, 5, false, false, 13, 55, 55, -110},
+		// No run doubling under campus; no-trump only.
+		{"campus no-trump run", ScoringCampus, 7, true, false, 20, 85, 170, 340},
+		// 10×10 + 5×9 = 145, ×8 for no-trump, no-friend and the 10 bid, capped.
+		{"campus cap", ScoringCampus, 10, true, true, 10, 145, 800, -3200},
+		{"trick points success", ScoringTrickPoints, 5, false, false, 16, 2, 2, 4},
+		{"trick points no doublings", ScoringTrickPoints, 6, true, true, 20, 5, 5, 20},
+		{"trick points failure", ScoringTrickPoints, 5, false, false, 13, 2, 2, -4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := scoringGame(tt.bid, tt.noTrump, tt.noFriend, false, tt.teamPoints)
+			g.Config.Scoring = tt.scoring
+
+			rs, scores := g.scoreHand()
+			if rs.Base != tt.wantBase || rs.S != tt.wantS {
+				t.Fatalf("base %d S %d, want base %d S %d (%+v)", rs.Base, rs.S, tt.wantBase, tt.wantS, rs)
+			}
+
+			if scores[0] != tt.wantDeclarer {
+				t.Fatalf("declarer: got %d, want %d", scores[0], tt.wantDeclarer)
+			}
+
+			sum := 0
+			for _, v := range scores {
+				sum += v
+			}
+			if sum != 0 {
+				t.Fatalf("scores must sum to zero, got %v", scores)
+			}
+		})
+	}
+}
+
+func TestScoreBreakdown(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                    string
+		bid                     int
+		noTrump, noFriend, solo bool
+		teamPoints              int
+		want                    RoundScore
+	}{
+		{name: "run", bid: 7, teamPoints: 20, want: RoundScore{
+			Bid: 7, P: 20, Target: 17, Success: true, Base: 11, S: 22, Doublings: []Doubling{DoubleRun},
+		}},
+		{name: "back run", bid: 5, teamPoints: 9, want: RoundScore{
+			Bid: 5, P: 9, Target: 15, Base: 6, S: 12, Doublings: []Doubling{DoubleBackRun},
+		}},
+		{name: "announced no-trump solo", bid: 6, noTrump: true, noFriend: true, teamPoints: 17, want: RoundScore{
+			Bid: 6, P: 17, Target: 16, Success: true, Base: 7, S: 28,
+			Doublings: []Doubling{DoubleNoTrump, DoubleNoFriend}, Solo: SoloAnnounced,
+		}},
+		{name: "secret solo", bid: 5, solo: true, teamPoints: 16, want: RoundScore{
+			Bid: 5, P: 16, Target: 15, Success: true, Base: 5, S: 5, Solo: SoloSecret,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := scoringGame(tt.bid, tt.noTrump, tt.noFriend, tt.solo, tt.teamPoints)
+			rs, _ := g.scoreHand()
+
+			tt.want.Scoring = ScoringOfficial
+			if rs.Bid != tt.want.Bid || rs.P != tt.want.P || rs.Target != tt.want.Target ||
+				rs.Success != tt.want.Success || rs.Base != tt.want.Base || rs.S != tt.want.S ||
+				rs.Solo != tt.want.Solo || rs.Scoring != tt.want.Scoring || !slices.Equal(rs.Doublings, tt.want.Doublings) {
+				t.Fatalf("breakdown = %+v, want %+v", rs, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreHistoryKeepsBreakdown(t *testing.T) {
+	t.Parallel()
+
+	g := callingGame()
+	if err := g.ApplyMove("p0", MoveConcede, nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if len(g.ScoreHistory) != 1 {
+		t.Fatalf("expected one round, got %d", len(g.ScoreHistory))
+	}
+
+	rs := g.ScoreHistory[0]
+	if !rs.Conceded || rs.Bid != 7 || rs.S != 5 || rs.Scores["p0"] != -20 {
+		t.Fatalf("unexpected breakdown: %+v", rs)
+	}
+
+	rs.Scores["p0"] = 0
+	if g.Scores["p0"] != -20 {
+		t.Fatal("the history must not share the round's score map")
+	}
+}
+
+func TestRoundScoreReadsLegacyHistory(t *testing.T) {
+	t.Parallel()
+
+	var history []RoundScore
+	if err := json.Unmarshal([]byte(`[{"p0": 10, "p1": -10}, {"scores": {"p0": -4}, "bid": 5}]`), &history); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if history[0].Scores["p0"] != 10 || history[0].Scores["p1"] != -10 {
+		t.Fatalf("legacy round not read as scores: %+v", history[0])
+	}
+
+	if history[1].Bid != 5 || history[1].Scores["p0"] != -4 {
+		t.Fatalf("breakdown not read: %+v", history[1])
+	}
+}
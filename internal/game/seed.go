@@ -0,0 +1,110 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	mrand "math/rand/v2"
+)
+
+// Seed is the key for one ChaCha8 shuffle. The same seed always produces the
+// same deck order, so a recorded seed is enough to redeal a hand exactly.
+type Seed [32]byte
+
+// NewSeed draws a fresh seed from the operating system's CSPRNG.
+func NewSeed() Seed {
+	var s Seed
+	_, _ = rand.Read(s[:]) // crypto/rand.Read never returns an error
+
+	return s
+}
+
+// ParseSeed decodes a 64-character hex seed.
+func ParseSeed(text string) (Seed, error) {
+	var s Seed
+	err := s.UnmarshalText([]byte(text))
+
+	return s, err
+}
+
+// String returns the seed as hex.
+func (s Seed) String() string { return hex.EncodeToString(s[:]) }
+
+// MarshalText encodes the seed as hex so it reads as a JSON string.
+func (s Seed) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText decodes a hex seed.
+func (s *Seed) UnmarshalText(text []byte) error {
+	if hex.DecodedLen(len(text)) != len(s) {
+		return fmt.Errorf("seed must be %d hex characters", 2*len(s))
+	}
+
+	_, err := hex.Decode(s[:], text)
+
+	return err
+}
+
+// derive returns the n-th child seed of s. Child seeds are independent of each
+// other, so one base seed can fix every hand of a game.
+func (s Seed) derive(n int) Seed {
+	var buf [len(s) + 8]byte
+	copy(buf[:], s[:])
+	binary.BigEndian.PutUint64(buf[len(s):], uint64(n))
+
+	return sha256.Sum256(buf[:])
+}
+
+// ShuffleSeeded shuffles the deck deterministically from seed.
+func (d Deck) ShuffleSeeded(seed Seed) {
+	r := mrand.New(mrand.NewChaCha8(seed))
+	r.Shuffle(len(d), func(i, j int) {
+		d[i], d[j] = d[j], d[i]
+	})
+}
+
+// DealFromSeed rebuilds the deal a seed produced for numPlayers: the hands in
+// seat order and the kitty.
+func DealFromSeed(numPlayers int, seed Seed) ([][]Card, []Card) {
+	deck := NewDeckFor(numPlayers)
+	deck.ShuffleSeeded(seed)
+
+	return deck.Deal(numPlayers)
+}
+
+// Option configures a Game at construction time.
+type Option func(*Game)
+
+// WithSeed puts the game in seeded mode: every hand is shuffled from a seed
+// derived from base, so the whole game can be replayed from base alone.
+// Without it each hand draws a fresh CSPRNG seed.
+func WithSeed(base Seed) Option {
+	return func(g *Game) { g.DealSeed = &base }
+}
+
+// nextHandSeed picks the seed for the hand about to be dealt and records it
+// in HandSeeds. A replay supplies the recorded seeds instead.
+func (g *Game) nextHandSeed() Seed {
+	seed := NewSeed()
+	switch {
+	case len(g.replaySeeds) > 0:
+		seed, g.replaySeeds = g.replaySeeds[0], g.replaySeeds[1:]
+	case g.DealSeed != nil:
+		seed = g.DealSeed.derive(len(g.HandSeeds))
+	}
+
+	g.HandSeeds = append(g.HandSeeds, seed)
+
+	return seed
+}
+
+// handSeed returns the seed of the hand in progress, drawing a fresh one for
+// games stored before seeds were recorded.
+func (g *Game) handSeed() Seed {
+	if len(g.HandSeeds) == 0 {
+		return NewSeed()
+	}
+
+	return g.HandSeeds[len(g.HandSeeds)-1]
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func seatedGame(id string, opts ...Option) *Game {
+	g := NewWithConfig(id, DefaultConfig(), opts...)
+	for i := range 5 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	return g
+}
+
+func hands(g *Game) string {
+	out := make([][]Card, 0, len(g.Players))
+	for _, p := range g.Players {
+		out = append(out, p.Hand)
+	}
+
+	return fmt.Sprint(out, g.Kitty)
+}
+
+func TestSeededGamesDealIdentically(t *testing.T) {
+	t.Parallel()
+
+	base := NewSeed()
+	a := seatedGame("a", WithSeed(base))
+	b := seatedGame("b", WithSeed(base))
+
+	for hand := range 3 {
+		a.Start()
+		b.Start()
+
+		if hands(a) != hands(b) {
+			t.Fatalf("hand %d differs between games with the same seed", hand)
+		}
+	}
+
+	if len(a.HandSeeds) != 3 || a.HandSeeds[0] == a.HandSeeds[1] {
+		t.Fatalf("each hand must record its own seed: %v", a.HandSeeds)
+	}
+}
+
+func TestHandSeedReproducesDeal(t *testing.T) {
+	t.Parallel()
+
+	g := seatedGame("csprng")
+	g.Start()
+
+	if g.DealSeed != nil || len(g.HandSeeds) != 1 {
+		t.Fatalf("default mode must record one CSPRNG seed: base=%v hands=%v", g.DealSeed, g.HandSeeds)
+	}
+
+	dealt, kitty := DealFromSeed(5, g.HandSeeds[0])
+	for seat, h := range dealt {
+		if fmt.Sprint(h) != fmt.Sprint(g.Players[seat].Hand) {
+			t.Fatalf("seat %d not reproduced from its seed", seat)
+		}
+	}
+
+	if fmt.Sprint(kitty) != fmt.Sprint(g.Kitty) {
+		t.Fatal("kitty not reproduced from its seed")
+	}
+}
+
+func TestRedealsAndNewRoundsRecordSeeds(t *testing.T) {
+	t.Parallel()
+
+	g := seatedGame("rounds")
+	g.Start()
+	g.redeal()
+	g.resetForNextRound()
+
+	if len(g.HandSeeds) != 3 {
+		t.Fatalf("expected a seed per dealt hand, got %d", len(g.HandSeeds))
+	}
+}
+
+func TestSeedJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	g := seatedGame("json", WithSeed(NewSeed()))
+	g.Start()
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var back Game
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if *back.DealSeed != *g.DealSeed || back.HandSeeds[0] != g.HandSeeds[0] {
+		t.Fatal("seeds must survive a JSON round trip")
+	}
+
+	if _, err := ParseSeed("abc"); err == nil {
+		t.Fatal("short seed must be rejected")
+	}
+}
+
+func TestViewHidesLiveSeeds(t *testing.T) {
+	t.Parallel()
+
+	g := seatedGame("view", WithSeed(NewSeed()))
+	g.Start()
+	g.redeal()
+
+	v := g.View("p0")
+	if v.DealSeed != nil {
+		t.Fatal("base seed leaked")
+	}
+
+	if len(v.HandSeeds) != 1 || v.HandSeeds[0] != g.HandSeeds[0] {
+		t.Fatalf("only the thrown-in hand's seed may show, got %v", v.HandSeeds)
+	}
+
+	g.Status = PhaseFinished
+	if len(g.View("p0").HandSeeds) != 2 {
+		t.Fatal("a finished hand's seed must be shown")
+	}
+}
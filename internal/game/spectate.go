@@ -0,0 +1,67 @@
+package game
+
+import "time"
+
+// MoveSpectating is the ledger entry for the table's owner opening or
+// closing the game to spectators.
+const MoveSpectating MoveType = "spectating"
+
+// SpectatorConfig decides who may watch a game. With a Delay, in seconds,
+// spectators see every hand, that far behind the table so they cannot pass
+// what they see to a player; without one they see the game live, with no
+// hands shown.
+type SpectatorConfig struct {
+	Open  bool `json:"open"`
+	Delay int  `json:"delay,omitempty"`
+}
+
+// DefaultSpectators lets anyone watch live.
+func DefaultSpectators() *SpectatorConfig {
+	return &SpectatorConfig{Open: true}
+}
+
+// Spectatable reports whether the game is open to spectators.
+func (g *Game) Spectatable() bool {
+	return g.Config.Spectators != nil && g.Config.Spectators.Open
+}
+
+// SpectatorDelay is how far behind the table spectators watch, or zero when
+// they watch live and see no hands.
+func (g *Game) SpectatorDelay() time.Duration {
+	if g.Config.Spectators == nil {
+		return 0
+	}
+
+	return time.Duration(g.Config.Spectators.Delay) * time.Second
+}
+
+// SetSpectating opens or closes the game to spectators, keeping any delay
+// it was configured with. The config is replaced rather than changed in
+// place, as clones of the game share it.
+func (g *Game) SetSpectating(open bool) {
+	var s SpectatorConfig
+	if g.Config.Spectators != nil {
+		s = *g.Config.Spectators
+	}
+	s.Open = open
+	g.Config.Spectators = &s
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+}
+
+// SpectatorView returns the game as a delayed spectator sees it: every hand
+// and the kitty, but not the seeds of hands still to come.
+func (g *Game) SpectatorView() *Game {
+	v := g.Clone()
+	v.Deck = nil
+	v.DealSeed = nil
+
+	for _, p := range v.Players {
+		if p != nil {
+			p.HandCount = len(p.Hand)
+		}
+	}
+
+	return v
+}
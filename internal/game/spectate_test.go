@@ -0,0 +1,69 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSpectatorsSeeHandsOnlyWhenDelayed(t *testing.T) {
+	t.Parallel()
+
+	g := seatFive(DefaultConfig())
+
+	for _, p := range g.View("watcher").Players {
+		if p.Hand != nil || p.HandCount == 0 {
+			t.Fatalf("a live spectator must not see %s's hand", p.ID)
+		}
+	}
+
+	for i, p := range g.SpectatorView().Players {
+		if len(p.Hand) != len(g.Players[i].Hand) || p.HandCount != len(p.Hand) {
+			t.Fatalf("a delayed spectator should see %s's hand", p.ID)
+		}
+	}
+
+	if v := g.SpectatorView(); v.DealSeed != nil {
+		t.Fatal("the base seed would reveal hands still to come")
+	}
+}
+
+func TestSetSpectatingKeepsTheDelay(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Spectators = &SpectatorConfig{Open: true, Delay: 90}
+	g := seatFive(cfg)
+	before := g.Clone()
+
+	g.SetSpectating(false)
+	if g.Spectatable() || g.SpectatorDelay() != 90*time.Second {
+		t.Fatalf("expected spectating closed with the delay kept, got %+v", g.Config.Spectators)
+	}
+
+	if !before.Spectatable() {
+		t.Fatal("closing spectating must not change a clone")
+	}
+
+	g.SetSpectating(true)
+	if !g.Spectatable() {
+		t.Fatal("expected spectating reopened")
+	}
+}
+
+func TestReplaySpectating(t *testing.T) {
+	t.Parallel()
+
+	want, ledger := recordGame(t, 5, 3, 4)
+	want.SetSpectating(false)
+	ledger.Moves = append(ledger.Moves, LedgerMove{Version: want.Version, PlayerID: "p0", Seat: 0, Type: MoveSpectating, Payload: json.RawMessage(`{"open":false}`)})
+
+	got, err := Replay(ledger)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatal("replayed spectating change differs from the recorded one")
+	}
+}
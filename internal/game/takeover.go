@@ -0,0 +1,272 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MoveTakeover is the ledger entry for a seat handed over mid-match: the
+// ledgered player takes the seat, with its cards and turn, from the player
+// named in the payload.
+const MoveTakeover MoveType = "takeover"
+
+// TakeoverPolicy is what becomes of a seat's running total when someone
+// takes the seat over.
+type TakeoverPolicy string
+
+const (
+	// TakeoverInherit hands the seat's total, and its round history, to the
+	// newcomer, as if they had played the whole match.
+	TakeoverInherit TakeoverPolicy = "inherit"
+	// TakeoverReset starts the newcomer at zero. The player they replaced
+	// keeps their total and is ranked as having left.
+	TakeoverReset TakeoverPolicy = "reset"
+	// TakeoverSplit credits each player with the rounds they played, but
+	// ranks the seat as one: the match counts the current occupant's total
+	// together with those of everyone they replaced.
+	TakeoverSplit TakeoverPolicy = "split"
+)
+
+// Takeover is a pending request to take over an abandoned seat. It goes
+// through once every other person still seated at the table has approved it.
+type Takeover struct {
+	Seat      int          `json:"seat"`
+	PlayerID  string       `json:"player_id"`
+	Name      string       `json:"name"`
+	Approvals map[int]bool `json:"approvals"` // seats that approved
+}
+
+// Handover records a seat changing hands mid-match.
+type Handover struct {
+	Seat   int            `json:"seat"`
+	From   string         `json:"from"`
+	To     string         `json:"to"`
+	Round  int            `json:"round"` // rounds scored before the handover
+	Policy TakeoverPolicy `json:"policy"`
+}
+
+// takeoverPolicy is the game's takeover policy, inherit when unset.
+func (g *Game) takeoverPolicy() TakeoverPolicy {
+	if g.Config.Takeover == "" {
+		return TakeoverInherit
+	}
+
+	return g.Config.Takeover
+}
+
+// RequestTakeover asks the table to let playerID, who is not seated, take
+// over seat, whose player has been gone past their grace period or left it
+// mid-match. A seat cannot be taken over when no one else at the table is
+// left to vote on it.
+func (g *Game) RequestTakeover(seat int, playerID, name string) error {
+	now := time.Now()
+	switch {
+	case g.Status == PhaseCorrupted || g.Status == PhaseMatchOver:
+		return fmt.Errorf("%w: cannot take over a seat in a %s game", ErrInvalidMove, g.Status)
+	case g.GetPlayer(playerID) != nil:
+		return fmt.Errorf("%w: %s is already seated", ErrInvalidMove, playerID)
+	case g.Takeover != nil:
+		return fmt.Errorf("%w: seat %d is already being taken over", ErrInvalidMove, g.Takeover.Seat)
+	case seat < 0 || seat >= g.numSeats() || !g.abandoned(seat, now):
+		return fmt.Errorf("%w: seat %d is not abandoned", ErrInvalidMove, seat)
+	}
+
+	g.Takeover = &Takeover{Seat: seat, PlayerID: playerID, Name: name, Approvals: make(map[int]bool)}
+	if !slices.ContainsFunc(g.Players, func(p *Player) bool { return p != nil && g.votesOnTakeover(p, now) }) {
+		g.Takeover = nil
+		return fmt.Errorf("%w: no one at the table can approve taking over seat %d", ErrInvalidMove, seat)
+	}
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// VoteTakeover records a seated player's vote on the pending takeover. A
+// single rejection withdraws it; the last approval hands the seat over.
+func (g *Game) VoteTakeover(playerID string, approve bool) error {
+	now := time.Now()
+	p := g.GetPlayer(playerID)
+	switch {
+	case g.Takeover == nil:
+		return fmt.Errorf("%w: no takeover to vote on", ErrInvalidMove)
+	case p == nil || !g.votesOnTakeover(p, now):
+		return fmt.Errorf("%w: %s has no vote on this takeover", ErrInvalidMove, playerID)
+	}
+
+	if approve {
+		g.Takeover.Approvals[p.Seat] = true
+		g.settleTakeover(now)
+	} else {
+		g.Takeover = nil
+	}
+
+	g.Version++
+	g.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// dropTakeover withdraws the pending takeover of seat, whose player has
+// come back or gone for good.
+func (g *Game) dropTakeover(seat int) {
+	if g.Takeover != nil && g.Takeover.Seat == seat {
+		g.Takeover = nil
+	}
+}
+
+// SeatLeft reports whether seat was left empty mid-match, so that it can
+// only be refilled by takeover.
+func (g *Game) SeatLeft(seat int) bool {
+	_, ok := g.LeftSeats[seat]
+	return ok && g.Players[seat] == nil
+}
+
+// heldBy reports whether playerID holds seat, or left it empty mid-match.
+func (g *Game) heldBy(seat int, playerID string) bool {
+	if seat < 0 || seat >= len(g.Players) {
+		return false
+	}
+
+	if p := g.Players[seat]; p != nil {
+		return p.ID == playerID
+	}
+
+	return g.SeatLeft(seat) && g.LeftSeats[seat].ID == playerID
+}
+
+// abandoned reports whether seat was left empty mid-match, or its player is
+// a person who has disconnected and, as of now, been substituted or run out
+// their grace period. Without a presence config there is no grace period to
+// wait for.
+func (g *Game) abandoned(seat int, now time.Time) bool {
+	p := g.Players[seat]
+	if p == nil {
+		return g.SeatLeft(seat)
+	}
+
+	if p.Bot != "" || p.IsConnected {
+		return false
+	}
+
+	grace, ok := g.GraceDeadline(p.ID)
+	return p.Substituted || !ok || !now.Before(grace)
+}
+
+// votesOnTakeover reports whether p is one of the people still seated at
+// the table whose approval the pending takeover needs. A player inside their
+// grace period keeps their vote; one who has abandoned their own seat does
+// not.
+func (g *Game) votesOnTakeover(p *Player, now time.Time) bool {
+	return p.Seat != g.Takeover.Seat && p.Bot == "" && !g.abandoned(p.Seat, now)
+}
+
+// settleTakeover hands the seat over once every voter has approved, or
+// drops the request if its seat is no longer abandoned.
+func (g *Game) settleTakeover(now time.Time) {
+	t := g.Takeover
+	if !g.abandoned(t.Seat, now) {
+		g.Takeover = nil
+		return
+	}
+
+	for _, p := range g.Players {
+		if p != nil && g.votesOnTakeover(p, now) && !t.Approvals[p.Seat] {
+			return
+		}
+	}
+
+	g.Takeover = nil
+	g.takeOver(t.Seat, t.PlayerID, t.Name)
+}
+
+// takeOver seats playerID in place of seat's player, in the middle of
+// whatever the seat was doing, or in a seat its player left mid-match. The
+// seat's running total follows the game's takeover policy.
+func (g *Game) takeOver(seat int, playerID, name string) {
+	if g.Players[seat] == nil {
+		g.Players[seat] = g.LeftSeats[seat]
+		delete(g.LeftSeats, seat)
+	}
+
+	p := g.Players[seat]
+	from := p.ID
+	policy := g.takeoverPolicy()
+
+	p.ID, p.Name = playerID, name
+	p.IsConnected, p.DisconnectedAt, p.Substituted = true, nil, false
+
+	if g.Owner == from {
+		g.Owner = playerID
+	}
+
+	// The hand in progress belongs to the seat, so it follows the seat.
+	for i := range g.Bids {
+		if g.Bids[i].PlayerID == from {
+			g.Bids[i].PlayerID = playerID
+		}
+	}
+	for _, b := range []*Bid{g.CurrentBid, g.Contract} {
+		if b != nil && b.PlayerID == from {
+			b.PlayerID = playerID
+		}
+	}
+	for i := range g.Tricks {
+		for j := range g.Tricks[i].Cards {
+			if g.Tricks[i].Cards[j].PlayerID == from {
+				g.Tricks[i].Cards[j].PlayerID = playerID
+			}
+		}
+	}
+	renameScore(g.Scores, from, playerID)
+
+	if policy == TakeoverInherit {
+		renameScore(g.TotalScores, from, playerID)
+		for _, rs := range g.ScoreHistory {
+			renameScore(rs.Scores, from, playerID)
+		}
+	}
+
+	g.Handovers = append(g.Handovers, Handover{Seat: seat, From: from, To: playerID, Round: len(g.ScoreHistory), Policy: policy})
+}
+
+// renameScore moves from's entry in scores, if any, to to.
+func renameScore(scores map[string]int, from, to string) {
+	if score, ok := scores[from]; ok {
+		delete(scores, from)
+		scores[to] += score
+	}
+}
+
+// matchTotals are the totals the match is decided on: TotalScores, with the
+// totals of players replaced under the split policy counted toward their
+// seat's current occupant.
+func (g *Game) matchTotals() map[string]int {
+	totals := cloneScores(g.TotalScores)
+	for id, into := range g.splitSeats() {
+		renameScore(totals, id, into)
+	}
+
+	return totals
+}
+
+// splitSeats maps each player replaced under the split policy to whoever
+// holds their seat now.
+func (g *Game) splitSeats() map[string]string {
+	into := make(map[string]string)
+	for _, h := range g.Handovers {
+		if h.Policy != TakeoverSplit {
+			continue
+		}
+		for id, to := range into {
+			if to == h.From {
+				into[id] = h.To
+			}
+		}
+		into[h.From] = h.To
+	}
+
+	return into
+}
@@ -0,0 +1,241 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// approveAll has every person still seated but the abandoned seat approve
+// the pending takeover.
+func approveAll(t *testing.T, g *Game) {
+	t.Helper()
+
+	for _, p := range g.Players {
+		if g.Takeover == nil {
+			return
+		}
+		if p == nil || !g.votesOnTakeover(p, time.Now()) {
+			continue
+		}
+		if err := g.VoteTakeover(p.ID, true); err != nil {
+			t.Fatalf("%s approving: %v", p.ID, err)
+		}
+	}
+}
+
+// abandon disconnects playerID long enough ago that their grace period has
+// run out.
+func abandon(t *testing.T, g *Game, playerID string) {
+	t.Helper()
+
+	if err := g.Disconnect(playerID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+}
+
+func TestTakeoverWaitsForEverySeatedVote(t *testing.T) {
+	t.Parallel()
+
+	g := seatFive(DefaultConfig())
+	hand := cloneCards(g.Players[2].Hand)
+
+	if err := g.RequestTakeover(2, "p9", "P9"); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("a connected player's seat is not abandoned, got %v", err)
+	}
+
+	if err := g.Disconnect("p2", time.Now()); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	if err := g.RequestTakeover(2, "p9", "P9"); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("p2 is still within their grace period, got %v", err)
+	}
+
+	// A substituted seat needs no wait.
+	g.Players[2].Substituted = true
+	if err := g.RequestTakeover(2, "p9", "P9"); err != nil {
+		t.Fatalf("RequestTakeover: %v", err)
+	}
+
+	// One rejection withdraws the request.
+	if err := g.VoteTakeover("p0", false); err != nil || g.Takeover != nil {
+		t.Fatalf("expected the takeover withdrawn, got %v", err)
+	}
+
+	abandon(t, g, "p3")
+	if err := g.Disconnect("p4", time.Now()); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	if err := g.RequestTakeover(2, "p9", "P9"); err != nil {
+		t.Fatalf("RequestTakeover: %v", err)
+	}
+
+	if err := g.VoteTakeover("p3", true); !errors.Is(err, ErrInvalidMove) {
+		t.Fatalf("a player who abandoned their own seat has no vote, got %v", err)
+	}
+
+	for _, id := range []string{"p0", "p1"} {
+		if err := g.VoteTakeover(id, true); err != nil {
+			t.Fatalf("VoteTakeover: %v", err)
+		}
+	}
+
+	if g.Players[2].ID != "p2" {
+		t.Fatal("handed over before p4, still within their grace period, approved")
+	}
+
+	if err := g.VoteTakeover("p4", true); err != nil {
+		t.Fatalf("VoteTakeover: %v", err)
+	}
+
+	p := g.Players[2]
+	if p.ID != "p9" || !p.IsConnected || !slices.Equal(p.Hand, hand) || g.Takeover != nil {
+		t.Fatalf("expected p9 to hold seat 2 and its hand, got %+v", p)
+	}
+
+	want := Handover{Seat: 2, From: "p2", To: "p9", Policy: TakeoverInherit}
+	if len(g.Handovers) != 1 || g.Handovers[0] != want {
+		t.Fatalf("expected %+v recorded, got %+v", want, g.Handovers)
+	}
+}
+
+func TestTakeoverNeedsSomeoneToVote(t *testing.T) {
+	t.Parallel()
+
+	g := seatFive(DefaultConfig())
+	for _, p := range g.Players[1:] {
+		p.Bot = "easy"
+	}
+	abandon(t, g, "p0")
+
+	if err := g.RequestTakeover(0, "p9", "P9"); !errors.Is(err, ErrInvalidMove) || g.Takeover != nil || g.Players[0].ID != "p0" {
+		t.Fatalf("expected a table of bots unable to hand seat 0 over, got %v", err)
+	}
+}
+
+func TestTakeoverPolicies(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []TakeoverPolicy{TakeoverInherit, TakeoverReset, TakeoverSplit} {
+		g := randomHand(t, 5, 3)
+		g.Config.Takeover = policy
+		total := g.TotalScores["p3"]
+		before := g.Version
+
+		abandon(t, g, "p3")
+		if err := g.RequestTakeover(3, "p9", "P9"); err != nil {
+			t.Fatalf("RequestTakeover: %v", err)
+		}
+		approveAll(t, g)
+
+		if err := g.CheckInvariants(before); err != nil {
+			t.Fatalf("%s: %v", policy, err)
+		}
+
+		_, kept := g.TotalScores["p3"]
+		switch policy {
+		case TakeoverInherit:
+			if kept || g.TotalScores["p9"] != total || g.ScoreHistory[0].Scores["p9"] != total {
+				t.Fatalf("inherit: expected p9 to carry %d, got %v", total, g.TotalScores)
+			}
+		case TakeoverReset:
+			if !kept || g.TotalScores["p9"] != 0 || g.matchTotals()["p9"] != 0 {
+				t.Fatalf("reset: expected p9 from zero and p3 kept, got %v", g.TotalScores)
+			}
+		case TakeoverSplit:
+			if !kept || g.TotalScores["p9"] != 0 || g.matchTotals()["p9"] != total {
+				t.Fatalf("split: expected p3 kept and the seat ranked on %d, got %v", total, g.matchTotals())
+			}
+			if standings := g.standings(); len(standings) != 5 {
+				t.Fatalf("split: expected the seat ranked once, got %+v", standings)
+			}
+		}
+	}
+}
+
+func TestReplayTakeover(t *testing.T) {
+	t.Parallel()
+
+	want, ledger := recordGame(t, 5, 7, 8)
+	abandon(t, want, "p1")
+	if err := want.RequestTakeover(1, "p9", "P9"); err != nil {
+		t.Fatalf("RequestTakeover: %v", err)
+	}
+	approveAll(t, want)
+
+	ledger.Moves = append(ledger.Moves, LedgerMove{Version: want.Version, PlayerID: "p9", Seat: 1, Type: MoveTakeover, Payload: json.RawMessage(`{"name":"P9","from":"p1"}`)})
+
+	got, err := Replay(ledger)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	// Presence and the vote are not ledgered, so only the versions differ.
+	want.Version = got.Version
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatal("replayed takeover differs from the recorded one")
+	}
+}
+
+func TestTakeoverOfALeftSeat(t *testing.T) {
+	t.Parallel()
+
+	want, ledger := recordGame(t, 5, 7, 8)
+	want.Config.Takeover = TakeoverReset
+	ledger.Config.Takeover = TakeoverReset
+
+	if err := want.Leave("p1"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+	ledger.Moves = append(ledger.Moves, LedgerMove{Version: want.Version, PlayerID: "p1", Seat: 1, Type: MoveLeave, Payload: json.RawMessage(`null`)})
+
+	if !want.SeatLeft(1) {
+		t.Fatal("a seat left mid-match must wait for a takeover")
+	}
+
+	penalty := want.TotalScores["p1"]
+	before := want.Version
+	if err := want.RequestTakeover(1, "p9", "P9"); err != nil {
+		t.Fatalf("RequestTakeover: %v", err)
+	}
+
+	if err := want.VoteTakeover("p0", true); err != nil || want.Players[1] != nil {
+		t.Fatalf("expected the seat held empty until every seated player approves, got %v", err)
+	}
+
+	approveAll(t, want)
+
+	if err := want.CheckInvariants(before); err != nil {
+		t.Fatal(err)
+	}
+
+	p := want.Players[1]
+	if p == nil || p.ID != "p9" || want.SeatLeft(1) {
+		t.Fatalf("expected p9 in seat 1, got %+v", p)
+	}
+
+	if want.TotalScores["p1"] != penalty || want.TotalScores["p9"] != 0 {
+		t.Fatalf("reset: expected p1 to keep %d and p9 to start from zero, got %v", penalty, want.TotalScores)
+	}
+
+	h := Handover{Seat: 1, From: "p1", To: "p9", Round: len(want.ScoreHistory), Policy: TakeoverReset}
+	if len(want.Handovers) != 1 || want.Handovers[0] != h {
+		t.Fatalf("expected %+v recorded, got %+v", h, want.Handovers)
+	}
+
+	ledger.Moves = append(ledger.Moves, LedgerMove{Version: want.Version, PlayerID: "p9", Seat: 1, Type: MoveTakeover, Payload: json.RawMessage(`{"name":"P9","from":"p1"}`)})
+
+	got, err := Replay(ledger)
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	want.Version = got.Version
+	if snapshot(t, got) != snapshot(t, want) {
+		t.Fatal("replayed takeover of a left seat differs from the recorded one")
+	}
+}
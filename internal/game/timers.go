@@ -0,0 +1,188 @@
+package game
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// TurnTimers limits how long, in seconds, the player to move may take in
+// each phase. A zero leaves that phase untimed.
+type TurnTimers struct {
+	Bid     int `json:"bid"`
+	Discard int `json:"discard"` // the exchange, and a six-player elimination
+	Call    int `json:"call"`
+	Play    int `json:"play"`
+}
+
+// DefaultTurnTimers gives the declarer longer for the exchange, where a
+// whole hand is planned, than for any single bid, call or card.
+func DefaultTurnTimers() *TurnTimers {
+	return &TurnTimers{Bid: 30, Discard: 60, Call: 30, Play: 30}
+}
+
+// turnTimeout is how long the player to move has in the current phase, or
+// zero when it is untimed.
+func (g *Game) turnTimeout() time.Duration {
+	t := g.Config.Timers
+	if t == nil {
+		return 0
+	}
+
+	var secs int
+	switch g.Status {
+	case PhaseBidding:
+		secs = t.Bid
+	case PhaseEliminating, PhaseExchanging:
+		secs = t.Discard
+	case PhaseCalling:
+		secs = t.Call
+	case PhasePlaying:
+		secs = t.Play
+	}
+
+	return time.Duration(secs) * time.Second
+}
+
+// ArmTurnTimer restarts the clock of the player to move at now, setting
+// TurnDeadline, or clears it when the current phase is untimed. A
+// substituted player's clock runs out at once under the auto-play policy
+// and is stopped under pause. No clock runs while the table is unattended,
+// so a table everyone has walked away from stops rather than playing
+// itself out one timeout at a time.
+func (g *Game) ArmTurnTimer(now time.Time) {
+	g.TurnDeadline = nil
+
+	timeout := g.turnTimeout()
+	if !g.occupied(g.CurrentTurn) || !g.Attended() {
+		return
+	}
+
+	if p := g.Players[g.CurrentTurn]; p.Substituted && g.Config.Presence != nil && g.handInProgress() {
+		switch g.Config.Presence.Policy {
+		case SubstitutePause:
+			return
+		case SubstituteAutoPlay:
+			g.TurnDeadline = &now
+			return
+		}
+	}
+
+	if timeout > 0 {
+		deadline := now.Add(timeout)
+		g.TurnDeadline = &deadline
+	}
+}
+
+// Attended reports whether any person seated at the table is connected.
+func (g *Game) Attended() bool {
+	for _, p := range g.Players {
+		if p != nil && p.Bot == "" && p.IsConnected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// handInProgress reports whether a hand is being bid or played.
+func (g *Game) handInProgress() bool {
+	switch g.Status {
+	case PhaseBidding, PhaseEliminating, PhaseExchanging, PhaseCalling, PhasePlaying:
+		return true
+	}
+
+	return false
+}
+
+// TimeoutMove picks the move made for a player who lets their clock run
+// out: a pass when bidding, the seat after the declarer's for an
+// elimination, the lowest cards for a discard, the Mighty (or failing that
+// another) friend call, and the lowest legal card in play. It reports the
+// player on turn, or false when no one is.
+func (g *Game) TimeoutMove() (string, LegalMove, bool) {
+	if !g.occupied(g.CurrentTurn) {
+		return "", LegalMove{}, false
+	}
+
+	p := g.Players[g.CurrentTurn]
+	moves := g.LegalMovesExceptClaims(p.ID)
+	if len(moves) == 0 {
+		return "", LegalMove{}, false
+	}
+
+	switch g.Status {
+	case PhaseBidding:
+		if i := slices.IndexFunc(moves, func(m LegalMove) bool { return m.Type == MovePass }); i >= 0 {
+			return p.ID, moves[i], true
+		}
+
+	case PhaseEliminating:
+		// The declarer knows nothing of the other hands yet, so no seat is a
+		// better choice than another; the next one at least never depends on
+		// where the table happens to start counting.
+		seat := g.nextSeat(g.Declarer)
+		if i := slices.IndexFunc(moves, func(m LegalMove) bool {
+			e, ok := m.Payload.(EliminateMove)
+			return ok && e.Seat == seat
+		}); i >= 0 {
+			return p.ID, moves[i], true
+		}
+
+	case PhaseExchanging:
+		if i := slices.IndexFunc(moves, func(m LegalMove) bool { return m.Type == MoveDiscard }); i >= 0 {
+			opt := moves[i].Payload.(DiscardOption)
+			low := g.lowestFirst(opt.From)[:opt.Count]
+			if g.ValidateMove(p.ID, MoveDiscard, low) == nil {
+				return p.ID, LegalMove{Type: MoveDiscard, Payload: low}, true
+			}
+			return p.ID, LegalMove{Type: MoveDiscard, Payload: opt.From[:opt.Count]}, true
+		}
+
+	case PhaseCalling:
+		for _, mode := range []FriendMode{FriendMighty, FriendJoker, FriendFirstTrick} {
+			if i := slices.IndexFunc(moves, func(m LegalMove) bool {
+				call, ok := m.Payload.(CallPartnerMove)
+				return ok && call.Mode == mode
+			}); i >= 0 {
+				return p.ID, moves[i], true
+			}
+		}
+		if i := slices.IndexFunc(moves, func(m LegalMove) bool { return m.Type == MoveCallPartner }); i >= 0 {
+			return p.ID, moves[i], true
+		}
+
+	case PhasePlaying:
+		for _, c := range g.lowestFirst(p.Hand) {
+			if i := slices.IndexFunc(moves, func(m LegalMove) bool {
+				play, ok := m.Payload.(PlayCardMove)
+				return ok && play.Card == c && !play.CallJoker
+			}); i >= 0 {
+				return p.ID, moves[i], true
+			}
+		}
+	}
+
+	return p.ID, moves[0], true
+}
+
+// lowestFirst orders cards from the least to the most valuable to keep:
+// plain suits, then trumps, each by rank, with the Mighty and the Joker
+// last.
+func (g *Game) lowestFirst(cards []Card) []Card {
+	worth := func(c Card) int {
+		switch {
+		case g.IsMighty(c) || c.Rank == Joker:
+			return 100
+		case c.Suit == g.Trump:
+			return 50 + RankValue(c.Rank)
+		default:
+			return RankValue(c.Rank)
+		}
+	}
+
+	sorted := cloneCards(cards)
+	slices.SortStableFunc(sorted, func(a, b Card) int { return cmp.Compare(worth(a), worth(b)) })
+
+	return sorted
+}
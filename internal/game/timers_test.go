@@ -0,0 +1,125 @@
+package game
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestArmTurnTimerFollowsThePhase(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	g := seatFive(DefaultConfig())
+
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline == nil || !g.TurnDeadline.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("expected a bidding deadline 30s out, got %v", g.TurnDeadline)
+	}
+
+	g.Status = PhaseExchanging
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline == nil || !g.TurnDeadline.Equal(now.Add(time.Minute)) {
+		t.Fatalf("expected an exchange deadline a minute out, got %v", g.TurnDeadline)
+	}
+
+	g.Status = PhaseFinished
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline != nil {
+		t.Fatalf("a finished hand is untimed, got %v", g.TurnDeadline)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Timers = nil
+	g = seatFive(cfg)
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline != nil {
+		t.Fatalf("a table without timers is untimed, got %v", g.TurnDeadline)
+	}
+}
+
+func TestArmTurnTimerStopsAtAnUnattendedTable(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	g := seatFive(DefaultConfig())
+	for _, p := range g.Players {
+		p.IsConnected = false
+	}
+
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline != nil {
+		t.Fatalf("no one is at the table, got a deadline %v", g.TurnDeadline)
+	}
+
+	// A bot keeps nobody's seat warm.
+	g.Players[1].Bot = "easy"
+	g.Players[1].IsConnected = true
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline != nil {
+		t.Fatalf("only a bot is at the table, got a deadline %v", g.TurnDeadline)
+	}
+
+	g.Players[3].IsConnected = true
+	g.ArmTurnTimer(now)
+	if g.TurnDeadline == nil || !g.TurnDeadline.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("expected the clock back once a person returns, got %v", g.TurnDeadline)
+	}
+}
+
+func TestTimeoutMovesPlayOutAHand(t *testing.T) {
+	t.Parallel()
+
+	g := seatFive(DefaultConfig())
+
+	// Someone has to bid, or every timeout passes the hand in.
+	opener := g.Players[g.CurrentTurn].ID
+	bid := g.LegalMoves(opener)[0]
+	if err := g.ApplyMove(opener, bid.Type, bid.Payload); err != nil {
+		t.Fatalf("bid: %v", err)
+	}
+
+	for step := 0; g.Status != PhaseFinished; step++ {
+		if step == 100 {
+			t.Fatalf("timeouts did not finish the hand, stuck in %s", g.Status)
+		}
+
+		playerID, m, ok := g.TimeoutMove()
+		if !ok {
+			t.Fatalf("no timeout move in %s", g.Status)
+		}
+
+		p := g.GetPlayer(playerID)
+		switch g.Status {
+		case PhaseBidding:
+			if m.Type != MovePass {
+				t.Fatalf("a timed-out bid should pass, got %s", m.Type)
+			}
+		case PhaseExchanging:
+			if m.Type != MoveDiscard {
+				t.Fatalf("a timed-out exchange should discard, got %s", m.Type)
+			}
+			if got, want := m.Payload.([]Card), g.lowestFirst(p.Hand)[:discardCount]; !slices.Equal(got, want) {
+				t.Fatalf("expected the lowest cards %v discarded, got %v", want, got)
+			}
+		case PhaseCalling:
+			if m.Type != MoveCallPartner {
+				t.Fatalf("a timed-out call should call a friend, got %s", m.Type)
+			}
+		case PhasePlaying:
+			play := m.Payload.(PlayCardMove)
+			for _, c := range g.lowestFirst(p.Hand) {
+				if c == play.Card {
+					break
+				}
+				if g.ValidateMove(playerID, MovePlayCard, PlayCardMove{Card: c}) == nil {
+					t.Fatalf("played %s when the lower %s was legal", play.Card, c)
+				}
+			}
+		}
+
+		if err := g.ApplyMove(playerID, m.Type, m.Payload); err != nil {
+			t.Fatalf("timeout %s in %s: %v", m.Type, g.Status, err)
+		}
+	}
+}
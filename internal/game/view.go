@@ -0,0 +1,237 @@
+package game
+
+// ViewerRole classifies who a projection of the game is being built for.
+type ViewerRole string
+
+const (
+	// ViewerSeat is a player seated in this game.
+	ViewerSeat ViewerRole = "seat"
+	// ViewerSpectator is an authenticated user who holds no seat.
+	ViewerSpectator ViewerRole = "spectator"
+	// ViewerAnonymous is an unauthenticated caller.
+	ViewerAnonymous ViewerRole = "anonymous"
+)
+
+// RoleOf reports how viewerID relates to the game. An empty ID is anonymous.
+func (g *Game) RoleOf(viewerID string) ViewerRole {
+	if viewerID == "" {
+		return ViewerAnonymous
+	}
+
+	if g.GetPlayer(viewerID) != nil {
+		return ViewerSeat
+	}
+
+	return ViewerSpectator
+}
+
+// Clone returns a deep copy of the game that shares no mutable state with g.
+func (g *Game) Clone() *Game {
+	c := *g
+
+	c.Players = make([]*Player, len(g.Players))
+	for i, p := range g.Players {
+		if p == nil {
+			continue
+		}
+
+		cp := *p
+		cp.Hand = cloneCards(p.Hand)
+		cp.Points = cloneCards(p.Points)
+		if p.DisconnectedAt != nil {
+			at := *p.DisconnectedAt
+			cp.DisconnectedAt = &at
+		}
+		c.Players[i] = &cp
+	}
+
+	c.Kitty = cloneCards(g.Kitty)
+	c.Deck = Deck(cloneCards(g.Deck))
+	c.HandSeeds = append([]Seed(nil), g.HandSeeds...)
+
+	if g.DealSeed != nil {
+		ds := *g.DealSeed
+		c.DealSeed = &ds
+	}
+	if g.TurnDeadline != nil {
+		d := *g.TurnDeadline
+		c.TurnDeadline = &d
+	}
+	c.Bids = append([]Bid(nil), g.Bids...)
+	c.CurrentBid = cloneBid(g.CurrentBid)
+	c.Contract = cloneBid(g.Contract)
+	c.PassedPlayers = cloneSeatSet(g.PassedPlayers)
+	c.PlayAgainVotes = cloneSeatSet(g.PlayAgainVotes)
+
+	if g.PartnerCard != nil {
+		pc := *g.PartnerCard
+		c.PartnerCard = &pc
+	}
+
+	if g.Tricks != nil {
+		c.Tricks = make([]Trick, len(g.Tricks))
+		for i, t := range g.Tricks {
+			t.Cards = append([]PlayedCard{}, t.Cards...)
+			c.Tricks[i] = t
+		}
+	}
+
+	c.Scores = cloneScores(g.Scores)
+	c.TotalScores = cloneScores(g.TotalScores)
+	c.Standings = append([]Standing(nil), g.Standings...)
+	c.Handovers = append([]Handover(nil), g.Handovers...)
+
+	if g.LeftSeats != nil {
+		c.LeftSeats = make(map[int]*Player, len(g.LeftSeats))
+		for seat, p := range g.LeftSeats {
+			cp := *p
+			cp.Hand = cloneCards(p.Hand)
+			cp.Points = cloneCards(p.Points)
+			c.LeftSeats[seat] = &cp
+		}
+	}
+
+	if g.Takeover != nil {
+		t := *g.Takeover
+		t.Approvals = cloneSeatSet(g.Takeover.Approvals)
+		c.Takeover = &t
+	}
+
+	if g.Claim != nil {
+		cl := *g.Claim
+		cl.Hand = cloneCards(g.Claim.Hand)
+		c.Claim = &cl
+	}
+
+	if g.ScoreHistory != nil {
+		c.ScoreHistory = make([]RoundScore, len(g.ScoreHistory))
+		for i, h := range g.ScoreHistory {
+			c.ScoreHistory[i] = h
+			c.ScoreHistory[i].Doublings = append([]Doubling(nil), h.Doublings...)
+			c.ScoreHistory[i].Scores = cloneScores(h.Scores)
+		}
+	}
+
+	return &c
+}
+
+// View returns the game as viewerID is allowed to see it. Every other seat's
+// hand is withheld (only its size is kept), and the kitty is shown only to the
+// declarer, from the exchange until the hand ends: their discards stay in it
+// as the cards out of play, and the point cards among them are kept out of
+// the declarer's pile for everyone else. The friend's identity needs no extra masking:
+// hands are the only place it lives before the reveal, and PartnerSeat stays
+// -1 until the reveal rule in ApplyMove fires. Spectators and anonymous
+// callers see no hand at all, and players who left their seat mid-match are
+// shown without their cards. Seeds would reveal every hand, so the base seed
+// is never shown and a hand's seed only once the hand is finished.
+func (g *Game) View(viewerID string) *Game {
+	v := g.Clone()
+	v.Deck = nil
+	v.DealSeed = nil
+
+	if v.Status != PhaseFinished && v.Status != PhaseMatchOver && len(v.HandSeeds) > 0 {
+		v.HandSeeds = v.HandSeeds[:len(v.HandSeeds)-1]
+	}
+
+	for _, p := range v.Players {
+		if p == nil {
+			continue
+		}
+
+		p.HandCount = len(p.Hand)
+		if p.ID != viewerID {
+			p.Hand = nil
+		}
+	}
+
+	for seat, p := range v.LeftSeats {
+		v.LeftSeats[seat] = p.Public()
+	}
+
+	handInPlay := v.Status == PhaseExchanging || v.Status == PhaseCalling || v.Status == PhasePlaying
+	if handInPlay && !v.isDeclarer(viewerID) && v.Declarer >= 0 && v.Declarer < len(v.Players) {
+		// The declarer's pile also holds the point cards they discarded,
+		// which only they may know until the hand ends.
+		if d := v.Players[v.Declarer]; d != nil {
+			for _, c := range v.Kitty {
+				d.Points = removeCard(d.Points, c)
+			}
+		}
+	}
+
+	if !handInPlay || !v.isDeclarer(viewerID) {
+		v.Kitty = nil
+	}
+
+	return v
+}
+
+// Public returns the part of p every viewer may see: who sits in the seat
+// and how many cards they hold, but neither the hand nor the point pile.
+// Events that name a player publish this rather than the player itself.
+func (p *Player) Public() *Player {
+	return &Player{
+		ID:          p.ID,
+		Name:        p.Name,
+		Seat:        p.Seat,
+		HandCount:   len(p.Hand),
+		IsConnected: p.IsConnected,
+		Bot:         p.Bot,
+	}
+}
+
+// isDeclarer reports whether playerID holds the declarer's seat.
+func (g *Game) isDeclarer(playerID string) bool {
+	if g.Declarer < 0 || g.Declarer >= len(g.Players) {
+		return false
+	}
+
+	p := g.Players[g.Declarer]
+
+	return p != nil && p.ID == playerID
+}
+
+func cloneCards(cards []Card) []Card {
+	if cards == nil {
+		return nil
+	}
+
+	return append([]Card{}, cards...)
+}
+
+func cloneBid(b *Bid) *Bid {
+	if b == nil {
+		return nil
+	}
+
+	c := *b
+
+	return &c
+}
+
+func cloneSeatSet(m map[int]bool) map[int]bool {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[int]bool, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
+
+func cloneScores(m map[string]int) map[string]int {
+	if m == nil {
+		return nil
+	}
+
+	c := make(map[string]int, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,154 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+)
+
+// viewGame returns a dealt five-player game with seat 2 as declarer mid-exchange.
+func viewGame() *Game {
+	g := New("view-test")
+	for i := range 5 {
+		g.Players[i] = &Player{ID: fmt.Sprintf("p%d", i), Seat: i, Hand: []Card{}, Points: []Card{}}
+	}
+
+	g.Start()
+	g.Status = PhaseExchanging
+	g.Declarer = 2
+
+	return g
+}
+
+func TestViewHidesOtherHands(t *testing.T) {
+	t.Parallel()
+
+	g := viewGame()
+	v := g.View("p1")
+
+	for i, p := range v.Players {
+		if p.HandCount != 10 {
+			t.Fatalf("seat %d hand_count = %d, want 10", i, p.HandCount)
+		}
+
+		if i == 1 {
+			if len(p.Hand) != 10 {
+				t.Fatalf("viewer must see own hand, got %d cards", len(p.Hand))
+			}
+
+			continue
+		}
+
+		if p.Hand != nil {
+			t.Fatalf("seat %d hand leaked to p1: %v", i, p.Hand)
+		}
+	}
+
+	if len(g.Players[0].Hand) != 10 {
+		t.Fatal("View must not mutate the source game")
+	}
+}
+
+func TestViewKittyOnlyForDeclarer(t *testing.T) {
+	t.Parallel()
+
+	g := viewGame()
+
+	if v := g.View("p2"); len(v.Kitty) != 3 {
+		t.Fatalf("declarer must see the kitty while exchanging, got %v", v.Kitty)
+	}
+
+	if v := g.View("p0"); v.Kitty != nil {
+		t.Fatalf("non-declarer saw the kitty: %v", v.Kitty)
+	}
+
+	// The discards stay in the kitty; only the declarer knows them.
+	g.Status = PhasePlaying
+	if v := g.View("p2"); len(v.Kitty) != 3 {
+		t.Fatalf("declarer must still see their discards, got %v", v.Kitty)
+	}
+
+	if v := g.View("p0"); v.Kitty != nil {
+		t.Fatalf("non-declarer saw the discards: %v", v.Kitty)
+	}
+
+	g.Status = PhaseFinished
+	if v := g.View("p2"); v.Kitty != nil {
+		t.Fatalf("kitty must be hidden once the hand ends: %v", v.Kitty)
+	}
+}
+
+func TestViewHidesDiscardedPoints(t *testing.T) {
+	t.Parallel()
+
+	g := viewGame()
+	discards := cloneCards(g.Players[2].Hand[:3])
+	discards[0] = Card{Suit: Hearts, Rank: King}
+	won := Card{Suit: Spades, Rank: Ten}
+	g.Players[2].Points = []Card{won, discards[0]}
+	g.Kitty = discards
+	g.Status = PhasePlaying
+
+	if v := g.View("p2"); len(v.Players[2].Points) != 2 {
+		t.Fatalf("declarer must see their whole pile, got %v", v.Players[2].Points)
+	}
+
+	for _, viewer := range []string{"p0", ""} {
+		if v := g.View(viewer); len(v.Players[2].Points) != 1 || v.Players[2].Points[0] != won {
+			t.Fatalf("%q saw the declarer's discarded points: %v", viewer, v.Players[2].Points)
+		}
+	}
+
+	g.Status = PhaseFinished
+	if v := g.View("p0"); len(v.Players[2].Points) != 2 {
+		t.Fatalf("the discarded points count once the hand ends, got %v", v.Players[2].Points)
+	}
+}
+
+func TestViewAnonymousAndSpectatorSeeNoHands(t *testing.T) {
+	t.Parallel()
+
+	g := viewGame()
+	g.PartnerCard = &Card{Suit: Hearts, Rank: Ace}
+
+	for _, viewer := range []string{"", "stranger"} {
+		v := g.View(viewer)
+		for i, p := range v.Players {
+			if p.Hand != nil {
+				t.Fatalf("viewer %q saw seat %d hand", viewer, i)
+			}
+		}
+
+		if v.PartnerSeat != -1 {
+			t.Fatalf("unrevealed friend exposed to %q: seat %d", viewer, v.PartnerSeat)
+		}
+	}
+
+	if g.RoleOf("") != ViewerAnonymous || g.RoleOf("stranger") != ViewerSpectator || g.RoleOf("p3") != ViewerSeat {
+		t.Fatal("RoleOf misclassified viewers")
+	}
+}
+
+func TestCloneIsDeep(t *testing.T) {
+	t.Parallel()
+
+	g := viewGame()
+	g.Tricks = []Trick{{Cards: []PlayedCard{{PlayerID: "p0", Seat: 0, Card: Card{Suit: Clubs, Rank: Two}}}}}
+	g.PassedPlayers[1] = true
+
+	c := g.Clone()
+	c.Players[0].Hand[0] = Card{Suit: "x", Rank: "y"}
+	c.Tricks[0].Cards[0].Seat = 4
+	c.PassedPlayers[3] = true
+
+	if g.Players[0].Hand[0].Rank == "y" {
+		t.Fatal("hand shared between clone and source")
+	}
+
+	if g.Tricks[0].Cards[0].Seat != 0 {
+		t.Fatal("trick cards shared between clone and source")
+	}
+
+	if g.PassedPlayers[3] {
+		t.Fatal("passed players shared between clone and source")
+	}
+}
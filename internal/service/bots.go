@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joekhosbayar/go-mighty/internal/bot"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxBotMoves bounds one run of the bot driver, so a table of bots that
+	// never needs a person cannot keep it going forever.
+	maxBotMoves = 500
+	// botBusyRetries bounds how many times in a row the driver waits out a
+	// lock held by another request, first for botBusyBackoff and twice as
+	// long each time after.
+	botBusyRetries = 6
+	botBusyBackoff = 50 * time.Millisecond
+)
+
+// AddBot seats a bot of the given level in the first free seat. Only the
+// table's owner may add bots, and only before the deal; a bot that fills the
+// table starts the game like any other join.
+func (s *Game) AddBot(ctx context.Context, gameID, requesterID string, level bot.Level) (*game.Game, error) {
+	g, err := s.addBot(ctx, gameID, requesterID, level)
+	if err == nil {
+		s.kickBots(ctx, g)
+	}
+
+	return g, err
+}
+
+func (s *Game) addBot(ctx context.Context, gameID, requesterID string, level bot.Level) (*game.Game, error) {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Owner != requesterID {
+		return nil, ErrNotOwner
+	}
+
+	if g.Status != game.PhaseWaiting {
+		return nil, ErrGameStarted
+	}
+
+	seat := slices.Index(g.Players[:g.NumSeatsPublic()], nil)
+	if seat == -1 {
+		return nil, ErrGameFull
+	}
+
+	loadedVersion := g.Version
+	handsBefore := len(g.HandSeeds)
+	statusBefore := g.Status
+
+	botID := "bot-" + uuid.NewString()[:8]
+	name := fmt.Sprintf("Bot %d (%s)", seat+1, level)
+	g.SeatBot(seat, botID, name, string(level))
+	g.ArmTurnTimer(time.Now())
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, game.MoveJoin, botID, seat, g.Version, loadedVersion, map[string]any{"name": name, "bot": level}, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save join move in db: %w", err)
+	}
+
+	if err := s.saveNewHands(ctx, g, handsBefore); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveStatus(ctx, g, statusBefore); err != nil {
+		return nil, err
+	}
+
+	s.scheduleTurn(ctx, g)
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":          "player_joined",
+		"player":        g.Players[seat].Public(),
+		"version":       g.Version,
+		"turn_deadline": g.TurnDeadline,
+	})
+
+	return g, nil
+}
+
+// RemoveBot frees a bot's seat. Like AddBot it is for the owner, before the
+// deal.
+func (s *Game) RemoveBot(ctx context.Context, gameID, requesterID, botID string) (*game.Game, error) {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Owner != requesterID {
+		return nil, ErrNotOwner
+	}
+
+	p := g.GetPlayer(botID)
+	if p == nil || p.Bot == "" {
+		return nil, ErrNotABot
+	}
+
+	if g.Status != game.PhaseWaiting {
+		return nil, ErrGameStarted
+	}
+
+	loadedVersion := g.Version
+	seat := p.Seat
+
+	if err := g.UnseatPlayer(seat); err != nil {
+		return nil, err
+	}
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, game.MoveLeave, botID, seat, g.Version, loadedVersion, nil, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save leave move in db: %w", err)
+	}
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":      "player_left",
+		"player_id": botID,
+		"seat":      seat,
+		"version":   g.Version,
+	})
+
+	return g, nil
+}
+
+// kickBots starts the bot driver when g has bots seated. It runs apart from
+// the request that woke it, which has already released the game's lock.
+func (s *Game) kickBots(ctx context.Context, g *game.Game) {
+	if g == nil || !slices.ContainsFunc(g.Players, func(p *game.Player) bool { return p != nil && p.Bot != "" }) {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	drive := func() { s.driveBots(ctx, g.ID) }
+
+	if s.spawn == nil {
+		drive()
+		return
+	}
+
+	s.spawn(drive)
+}
+
+// driveBots plays bot moves through processMove until it is a person's turn.
+// Each move takes the game's lock like any request; losing a race to another
+// request just means reloading, and a lock held by another request is waited
+// out with backoff, as nothing else may wake the driver once it stops. Any
+// other failure stops the driver until the next move wakes it.
+func (s *Game) driveBots(ctx context.Context, gameID string) {
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	busy := 0
+
+	for range maxBotMoves {
+		g, err := s.loadGame(ctx, gameID)
+		if err != nil || g == nil {
+			return
+		}
+
+		botID, m, ok := bot.NextMove(g, rng, s.botBudget)
+		if !ok {
+			return
+		}
+
+		if _, err := s.processMove(ctx, gameID, botID, m.Type, m.Payload, g.Version); err != nil {
+			switch {
+			case errors.Is(err, redisstore.ErrStaleVersion):
+				continue
+			case errors.Is(err, ErrGameBusy) && busy < botBusyRetries:
+				time.Sleep(botBusyBackoff << busy)
+				busy++
+
+				continue
+			}
+
+			log.Warn().Str("game_id", gameID).Str("player_id", botID).Str("move_type", string(m.Type)).Err(err).Msg("bot move failed")
+
+			return
+		}
+
+		busy = 0
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/joekhosbayar/go-mighty/internal/bot"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+)
+
+func TestProcessMoveLetsTheNextBotPlay(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// p0 opens the bidding, the bot in seat 1 answers, and p2 is a person.
+	g := game.New("game-bots")
+	g.SeatPlayer(0, "p0", "P0")
+	g.SeatBot(1, "bot-1", "Bot 2 (medium)", string(bot.Medium))
+	for i := 2; i < 5; i++ {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	g.CurrentTurn = 0
+	version := g.Version
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-bots", "p0", 0, version+1, version, "pass", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-bots", "bot-1", 1, version+2, version+1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	store := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: store, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.ProcessMove(t.Context(), "game-bots", "p0", game.MovePass, nil, version); err != nil {
+		t.Fatalf("ProcessMove: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("bot move not ledgered: %v", err)
+	}
+
+	if store.game.CurrentTurn != 2 {
+		t.Fatalf("bots must stop at a person's turn, turn is seat %d", store.game.CurrentTurn)
+	}
+}
+
+func TestDriveBotsWaitsOutABusyGame(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// The bot in seat 1 is on turn while another request holds the lock.
+	g := game.New("game-busy-bots")
+	g.SeatPlayer(0, "p0", "P0")
+	g.SeatBot(1, "bot-1", "Bot 2 (medium)", string(bot.Medium))
+	for i := 2; i < 5; i++ {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	g.CurrentTurn = 1
+	version := g.Version
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-busy-bots", "bot-1", 1, version+1, version, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	store := &fakeRedisStore{game: g, busy: 2}
+	svc := &Game{redisStore: store, postgresStore: postgres.NewStoreWithDB(db)}
+
+	svc.driveBots(t.Context(), "game-busy-bots")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("bot move not made once the lock was free: %v", err)
+	}
+
+	if store.game.CurrentTurn != 2 {
+		t.Fatalf("expected the bot to have played, turn is seat %d", store.game.CurrentTurn)
+	}
+}
+
+func TestAddBotRejects(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requester string
+		setup     func(g *game.Game)
+		want      error
+	}{
+		{name: "not the owner", requester: "p1", want: ErrNotOwner},
+		{name: "already dealt", requester: "p0", want: ErrGameStarted, setup: func(g *game.Game) {
+			for i := 1; i < 5; i++ {
+				g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			g := game.New("game-owner")
+			g.SeatPlayer(0, "p0", "P0")
+			if tt.setup != nil {
+				tt.setup(g)
+			}
+
+			store := &fakeRedisStore{game: g}
+			svc := &Game{redisStore: store}
+
+			if _, err := svc.AddBot(t.Context(), "game-owner", tt.requester, bot.Easy); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+
+			if store.saved {
+				t.Fatal("a rejected bot must not be saved")
+			}
+		})
+	}
+}
+
+func TestRemoveBotLedgersTheLeave(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-leave")
+	g.SeatPlayer(0, "p0", "P0")
+	g.SeatBot(1, "bot-1", "Bot 2 (easy)", string(bot.Easy))
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-leave", "bot-1", 1, g.Version+1, g.Version, "leave", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	svc := &Game{redisStore: &fakeRedisStore{game: g}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.RemoveBot(t.Context(), "game-leave", "p0", "p0"); !errors.Is(err, ErrNotABot) {
+		t.Fatalf("a person is not a bot to remove, got %v", err)
+	}
+
+	got, err := svc.RemoveBot(t.Context(), "game-leave", "p0", "bot-1")
+	if err != nil {
+		t.Fatalf("RemoveBot: %v", err)
+	}
+
+	if got.Players[1] != nil {
+		t.Fatal("bot's seat not freed")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("leave not ledgered: %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/joekhosbayar/go-mighty/internal/bot"
 	"github.com/joekhosbayar/go-mighty/internal/game"
 	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
 	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
@@ -24,6 +25,30 @@ var (
 	ErrGameFull = errors.New("game is full")
 	// ErrGameBusy is returned when the game's lock cannot be acquired in time.
 	ErrGameBusy = errors.New("game busy")
+	// ErrNotOwner is returned when someone other than the table's owner
+	// manages its bots.
+	ErrNotOwner = errors.New("only the table owner can do that")
+	// ErrGameStarted is returned when a seat change needs a table that is
+	// still waiting for players.
+	ErrGameStarted = errors.New("game already started")
+	// ErrNotABot is returned when the player to remove is not a bot at the
+	// table.
+	ErrNotABot = errors.New("no such bot at this table")
+	// ErrGameCorrupted is returned when a move would leave the game in an
+	// inconsistent state, and for every move once the game is quarantined.
+	ErrGameCorrupted = errors.New("game is corrupted")
+	// ErrGameNotFinished is returned when a game is exported before its hands
+	// are all played out.
+	ErrGameNotFinished = errors.New("game is not finished")
+	// ErrNotSeated is returned when a player leaves a game they have no
+	// seat at.
+	ErrNotSeated = errors.New("player is not seated at this game")
+	// ErrSpectatingClosed is returned when someone tries to watch a game
+	// whose owner has closed it to spectators.
+	ErrSpectatingClosed = errors.New("game is closed to spectators")
+	// ErrSeated is returned when a seated player tries to watch their own
+	// game as a spectator.
+	ErrSeated = errors.New("player is seated at this game")
 )
 
 // RedisStore defines the interface for hot state storage of games in Redis.
@@ -34,20 +59,58 @@ type RedisStore interface {
 	ReleaseLock(ctx context.Context, gameID, token string) error
 	PublishEvent(ctx context.Context, gameID string, event any) error
 	Subscribe(ctx context.Context, gameID string) *redis.PubSub
+	ScheduleTurn(ctx context.Context, gameID string, deadline time.Time) error
+	CancelTurn(ctx context.Context, gameID string) error
+	ClaimDueTurns(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error)
+	TouchSocket(ctx context.Context, gameID, playerID, socketID string, ttl time.Duration) (bool, error)
+	RemoveSocket(ctx context.Context, gameID, playerID, socketID string) (bool, error)
+	CountSockets(ctx context.Context, gameID, playerID string) (int, error)
+	WatchPresence(ctx context.Context, gameID, playerID string, at time.Time) error
+	UnwatchPresence(ctx context.Context, gameID, playerID string) error
+	ClaimDuePresence(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]redisstore.PlayerKey, error)
+	TouchSpectator(ctx context.Context, gameID, socketID string, ttl time.Duration) (bool, error)
+	RemoveSpectator(ctx context.Context, gameID, socketID string) (bool, error)
+	CountSpectators(ctx context.Context, gameID string) (int, error)
 }
 
 // Game service manages game lifecycle, including creation, joining, and move processing.
 type Game struct {
 	redisStore    RedisStore
 	postgresStore *postgres.Store
+	dealSeeds     func() game.Seed // nil: every hand draws a fresh CSPRNG seed
+	spawn         func(func())     // runs the bot driver; nil runs it inline
+	botBudget     bot.Budget       // search limits for expert bots; zero uses bot.DefaultBudget
+}
+
+// Option configures the Game service at construction time.
+type Option func(*Game)
+
+// WithDealSeeds puts every game this service creates in seeded mode, taking
+// each game's base seed from next. Tests and "same deal" challenges use it to
+// make deals reproducible.
+func WithDealSeeds(next func() game.Seed) Option {
+	return func(s *Game) { s.dealSeeds = next }
+}
+
+// WithBotBudget bounds the search an expert bot makes for each move, so bots
+// share the server's CPU with everyone else.
+func WithBotBudget(b bot.Budget) Option {
+	return func(s *Game) { s.botBudget = b }
 }
 
 // NewGame creates and returns a new Game service instance.
-func NewGame(r RedisStore, p *postgres.Store) *Game {
-	return &Game{
+func NewGame(r RedisStore, p *postgres.Store, opts ...Option) *Game {
+	s := &Game{
 		redisStore:    r,
 		postgresStore: p,
+		spawn:         func(f func()) { go f() },
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // withGameLock acquires the game's distributed lock, mapping contention to ErrGameBusy.
@@ -68,7 +131,12 @@ func (s *Game) withGameLock(ctx context.Context, gameID string) (release func(),
 
 // CreateGame initializes a new game and persists it in both Postgres and Redis.
 func (s *Game) CreateGame(ctx context.Context, id string, cfg game.GameConfig) (*game.Game, error) {
-	g := game.NewWithConfig(id, cfg)
+	var opts []game.Option
+	if s.dealSeeds != nil {
+		opts = append(opts, game.WithSeed(s.dealSeeds()))
+	}
+
+	g := game.NewWithConfig(id, cfg, opts...)
 
 	// Save to Postgres (ledger)
 	if err := s.postgresStore.CreateGame(ctx, g); err != nil {
@@ -86,7 +154,18 @@ func (s *Game) CreateGame(ctx context.Context, id string, cfg game.GameConfig) (
 // JoinGame adds a player to an existing game. If the player is already in the game,
 // it refreshes their connection state. If not, it finds the first available seat.
 // If the game becomes full after joining, it transitions the game to the bidding phase.
+// A seat its player left mid-match is not joined outright: the join becomes a
+// request to take it over, which the table votes on.
 func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string) (*game.Game, error) {
+	g, err := s.joinGame(ctx, gameID, playerID, playerName)
+	if err == nil {
+		s.kickBots(ctx, g)
+	}
+
+	return g, err
+}
+
+func (s *Game) joinGame(ctx context.Context, gameID, playerID, playerName string) (*game.Game, error) {
 	// Lock
 	release, err := s.withGameLock(ctx, gameID)
 	if err != nil {
@@ -95,7 +174,7 @@ func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string
 	defer release()
 
 	// Load
-	g, err := s.redisStore.LoadGame(ctx, gameID)
+	g, err := s.loadGame(ctx, gameID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load game: %w", err)
 	}
@@ -105,6 +184,8 @@ func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string
 	}
 
 	loadedVersion := g.Version
+	handsBefore := len(g.HandSeeds)
+	statusBefore := g.Status
 
 	// Logic: Find seat
 	seat := -1
@@ -138,15 +219,18 @@ func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string
 		return nil, ErrGameFull
 	}
 
-	g.Players[seat] = &game.Player{ID: playerID, Name: playerName, Seat: seat, IsConnected: true, Hand: []game.Card{}, Points: []game.Card{}}
-	g.Version++
-	g.UpdatedAt = time.Now()
-
-	// Check if game full -> Start
-	if g.IsFull() {
-		g.Start()
+	// A seat left mid-match is refilled by the table's vote, under the
+	// game's takeover policy, rather than by joining.
+	if g.SeatLeft(seat) {
+		return s.applyTakeover(ctx, g, playerID, func(g *game.Game) error {
+			return g.RequestTakeover(seat, playerID, playerName)
+		})
 	}
 
+	// Seat the player; the game deals once the last seat is taken
+	g.SeatPlayer(seat, playerID, playerName)
+	g.ArmTurnTimer(time.Now())
+
 	// Save
 	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
 		return nil, err
@@ -154,15 +238,105 @@ func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string
 
 	// Save Move to Postgres (Join is a move?)
 	// Architecture says "Inserts join move to Postgres ledger".
-	if err := s.postgresStore.SaveMove(ctx, "join", playerID, seat, g.Version, g.Version-1, map[string]any{"name": playerName}, gameID); err != nil {
+	if err := s.postgresStore.SaveMove(ctx, game.MoveJoin, playerID, seat, g.Version, g.Version-1, map[string]any{"name": playerName}, gameID); err != nil {
 		return nil, fmt.Errorf("failed to save join move in db: %w", err)
 	}
 
+	if err := s.saveNewHands(ctx, g, handsBefore); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveStatus(ctx, g, statusBefore); err != nil {
+		return nil, err
+	}
+
+	s.scheduleTurn(ctx, g)
+
 	// Publish
 	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
-		"type":    "player_joined",
-		"player":  g.Players[seat],
-		"version": g.Version,
+		"type":          "player_joined",
+		"player":        g.Players[seat].Public(),
+		"version":       g.Version,
+		"turn_deadline": g.TurnDeadline,
+	})
+
+	return g, nil
+}
+
+// LeaveGame takes a player out of a game. Before the deal and between hands
+// the seat is freed for someone else; mid-hand the player forfeits the hand,
+// which is scored under the game's forfeit config. The leave is ledgered and
+// published as a player_left event.
+func (s *Game) LeaveGame(ctx context.Context, gameID, playerID string) (*game.Game, error) {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Status == game.PhaseCorrupted {
+		return nil, ErrGameCorrupted
+	}
+
+	if g.GetPlayer(playerID) == nil {
+		return nil, ErrNotSeated
+	}
+
+	return s.leave(ctx, g, playerID)
+}
+
+// leave takes a seated player out of g, which the caller has loaded under
+// the game lock.
+func (s *Game) leave(ctx context.Context, g *game.Game, playerID string) (*game.Game, error) {
+	gameID := g.ID
+	loadedVersion := g.Version
+	statusBefore := g.Status
+	seat := g.GetPlayer(playerID).Seat
+	before := g.Clone()
+
+	if err := g.Leave(playerID); err != nil {
+		return nil, err
+	}
+
+	if err := g.CheckInvariants(loadedVersion); err != nil {
+		return nil, s.quarantine(ctx, before, g, playerID, game.MoveLeave, nil, err)
+	}
+
+	g.ArmTurnTimer(time.Now())
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, game.MoveLeave, playerID, seat, g.Version, loadedVersion, nil, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save leave move in db: %w", err)
+	}
+
+	if err := s.saveStatus(ctx, g, statusBefore); err != nil {
+		return nil, err
+	}
+
+	s.scheduleTurn(ctx, g)
+
+	// Only a forfeit ends the hand; freeing a seat leaves the phase as it was.
+	forfeit := g.Status != statusBefore
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":          "player_left",
+		"player_id":     playerID,
+		"seat":          seat,
+		"forfeit":       forfeit,
+		"version":       g.Version,
+		"turn_deadline": g.TurnDeadline,
 	})
 
 	return g, nil
@@ -170,8 +344,19 @@ func (s *Game) JoinGame(ctx context.Context, gameID, playerID, playerName string
 
 // ProcessMove validates and applies a game move. It handles concurrency via a distributed lock
 // and optimistic version checking. The move is persisted to the Postgres ledger and published
-// to the game's event channel.
+// to the game's event channel. Any bots the move hands the turn to then play.
 func (s *Game) ProcessMove(ctx context.Context, gameID, playerID string, moveType game.MoveType, payload any, clientVersion int64) (*game.Game, error) {
+	g, err := s.processMove(ctx, gameID, playerID, moveType, payload, clientVersion)
+	if err == nil {
+		s.kickBots(ctx, g)
+	}
+
+	return g, err
+}
+
+// processMove is ProcessMove without waking the bots; the bot driver submits
+// its moves through it.
+func (s *Game) processMove(ctx context.Context, gameID, playerID string, moveType game.MoveType, payload any, clientVersion int64) (*game.Game, error) {
 	// 1. Lock
 	release, err := s.withGameLock(ctx, gameID)
 	if err != nil {
@@ -180,7 +365,7 @@ func (s *Game) ProcessMove(ctx context.Context, gameID, playerID string, moveTyp
 	defer release()
 
 	// 2. Load
-	g, err := s.redisStore.LoadGame(ctx, gameID)
+	g, err := s.loadGame(ctx, gameID)
 	if err != nil {
 		return nil, err
 	}
@@ -190,21 +375,34 @@ func (s *Game) ProcessMove(ctx context.Context, gameID, playerID string, moveTyp
 	}
 
 	loadedVersion := g.Version
+	handsBefore := len(g.HandSeeds)
+	statusBefore := g.Status
 	if clientVersion != loadedVersion {
 		return nil, redisstore.ErrStaleVersion
 	}
 
+	if g.Status == game.PhaseCorrupted {
+		return nil, ErrGameCorrupted
+	}
+
 	// 3. Validate
 	if err := g.ValidateMove(playerID, moveType, payload); err != nil {
 		return nil, err
 	}
 
-	// 4. Apply
+	// 4. Apply, then check the result before anything is saved.
+	before := g.Clone()
 	if err := g.ApplyMove(playerID, moveType, payload); err != nil {
 		return nil, err
 	}
 
-	// 5. Save Redis
+	if err := g.CheckInvariants(loadedVersion); err != nil {
+		return nil, s.quarantine(ctx, before, g, playerID, moveType, payload, err)
+	}
+
+	// 5. Start the next player's clock, then save Redis
+	g.ArmTurnTimer(time.Now())
+
 	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
 		return nil, err
 	}
@@ -221,18 +419,37 @@ func (s *Game) ProcessMove(ctx context.Context, gameID, playerID string, moveTyp
 		seat = p.Seat
 	}
 
-	if err := s.postgresStore.SaveMove(ctx, moveType, playerID, seat, g.Version, clientVersion, payload, gameID); err != nil {
+	// A deal miss carries no payload from the client; ledger the hand that was
+	// shown to the table instead, since the redeal has already replaced it.
+	ledgerPayload := payload
+	if moveType == game.MoveDealMiss && g.LastDealMiss != nil {
+		ledgerPayload = g.LastDealMiss
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, moveType, playerID, seat, g.Version, clientVersion, ledgerPayload, gameID); err != nil {
 		return nil, fmt.Errorf("failed to save move in db: %w", err)
 	}
 
+	if err := s.saveNewHands(ctx, g, handsBefore); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveStatus(ctx, g, statusBefore); err != nil {
+		return nil, err
+	}
+
+	s.scheduleTurn(ctx, g)
+	s.countSpectators(ctx, g)
+
 	// 7. Publish
 	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
-		"type":       "move",
-		"move_type":  moveType,
-		"player_id":  playerID,
-		"payload":    payload,
-		"version":    g.Version,
-		"game_state": g, // send full state or delta? Full state is safer but heavier.
+		"type":          "move",
+		"move_type":     moveType,
+		"player_id":     playerID,
+		"payload":       payload,
+		"version":       g.Version,
+		"turn_deadline": g.TurnDeadline,
+		"game_state":    g, // send full state or delta? Full state is safer but heavier.
 		// Architecture said: "Client must refresh state"?
 		// Pub/Sub usually sends delta or "Something changed, fetch new state".
 		// Or sends the event.
@@ -242,6 +459,44 @@ func (s *Game) ProcessMove(ctx context.Context, gameID, playerID string, moveTyp
 	return g, nil
 }
 
+// quarantine handles a move that left g inconsistent. The broken state is
+// logged in full and never saved: the game is frozen at before, its last
+// consistent state, and marked corrupted so no further move is applied. The
+// move is not ledgered, so a replay rebuilds the state before it.
+func (s *Game) quarantine(ctx context.Context, before, g *game.Game, playerID string, moveType game.MoveType, payload any, violation error) error {
+	log.Error().
+		Str("game_id", g.ID).
+		Str("player_id", playerID).
+		Str("move_type", string(moveType)).
+		Interface("payload", payload).
+		Interface("state_before", before).
+		Interface("state_after", g).
+		Err(violation).
+		Msg("move broke game invariants; quarantining game")
+
+	loadedVersion := before.Version
+	before.Status = game.PhaseCorrupted
+	before.Version++
+	before.ArmTurnTimer(time.Now())
+
+	if err := s.redisStore.SaveGame(ctx, before, loadedVersion); err != nil {
+		return fmt.Errorf("%w: %w (failed to quarantine: %w)", ErrGameCorrupted, violation, err)
+	}
+
+	s.scheduleTurn(ctx, before)
+
+	if err := s.postgresStore.UpdateGameStatus(ctx, before.ID, before.Status, before.Version); err != nil {
+		log.Error().Str("game_id", before.ID).Err(err).Msg("failed to save corrupted status in db")
+	}
+
+	_ = s.redisStore.PublishEvent(ctx, before.ID, map[string]any{
+		"type":    "game_corrupted",
+		"version": before.Version,
+	})
+
+	return fmt.Errorf("%w: %w", ErrGameCorrupted, violation)
+}
+
 // Subscribe returns a Redis PubSub channel for real-time game events.
 func (s *Game) Subscribe(ctx context.Context, gameID string) *redis.PubSub {
 	if s.redisStore == nil {
@@ -257,12 +512,138 @@ func (s *Game) GetGame(ctx context.Context, gameID string) (*game.Game, error) {
 		return nil, ErrRedisStoreNotInitialized
 	}
 
-	return s.redisStore.LoadGame(ctx, gameID)
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil || g == nil {
+		return g, err
+	}
+
+	s.countSpectators(ctx, g)
+
+	return g, nil
+}
+
+// ExportGame writes up a finished game from its move ledger in the text
+// notation. The record shows every hand as dealt, so it is only available
+// once the current hand has been scored.
+func (s *Game) ExportGame(ctx context.Context, gameID string) (*game.Record, error) {
+	g, err := s.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Status != game.PhaseFinished && g.Status != game.PhaseMatchOver {
+		return nil, ErrGameNotFinished
+	}
+
+	ledger, err := s.postgresStore.LoadLedger(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ledger: %w", err)
+	}
+
+	if ledger == nil {
+		return nil, ErrGameNotFound
+	}
+
+	return game.NewRecord(*ledger)
+}
+
+// loadGame reads the hot state from Redis, rebuilding the kitty of a game
+// saved before it was kept. When Redis has lost the game but the Postgres
+// ledger still has it, the game is rebuilt by replay and written back to
+// Redis.
+func (s *Game) loadGame(ctx context.Context, gameID string) (*game.Game, error) {
+	g, err := s.redisStore.LoadGame(ctx, gameID)
+	if g != nil {
+		g.RestoreKitty()
+	}
+	if err != nil || g != nil || s.postgresStore == nil {
+		return g, err
+	}
+
+	ledger, err := s.postgresStore.LoadLedger(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ledger: %w", err)
+	}
+
+	if ledger == nil {
+		return nil, nil
+	}
+
+	g, err = game.Replay(*ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay game %s: %w", gameID, err)
+	}
+
+	// The ledger does not keep clocks; whoever is to move starts afresh.
+	g.ArmTurnTimer(time.Now())
+
+	if err := s.redisStore.SaveGame(ctx, g, 0); err != nil {
+		if errors.Is(err, redisstore.ErrStaleVersion) {
+			// Another request rehydrated it first; use theirs.
+			return s.redisStore.LoadGame(ctx, gameID)
+		}
+
+		return nil, err
+	}
+
+	s.scheduleTurn(ctx, g)
+
+	log.Info().Str("game_id", gameID).Int64("version", g.Version).Int("moves", len(ledger.Moves)).Msg("rehydrated game from ledger")
+
+	return g, nil
 }
 
-// ListGamesByStatus retrieves a list of games with the specified status,
-// combining data from Postgres and Redis to ensure accuracy.
+// saveStatus mirrors a phase change into the games table, so games can be
+// listed by status. A completed match also keeps its final state there, so
+// it stays listed once Redis expires it.
+func (s *Game) saveStatus(ctx context.Context, g *game.Game, before game.Phase) error {
+	if g.Status == before {
+		return nil
+	}
+
+	if err := s.postgresStore.UpdateGameStatus(ctx, g.ID, g.Status, g.Version); err != nil {
+		return fmt.Errorf("failed to save game status in db: %w", err)
+	}
+
+	if g.Status == game.PhaseMatchOver {
+		if err := s.postgresStore.SaveFinalState(ctx, g); err != nil {
+			return fmt.Errorf("failed to save final state in db: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// saveNewHands ledgers the seed of every hand dealt since the game had
+// handsBefore hands, so a replay can deal them again.
+func (s *Game) saveNewHands(ctx context.Context, g *game.Game, handsBefore int) error {
+	for i := handsBefore; i < len(g.HandSeeds); i++ {
+		if err := s.postgresStore.SaveHand(ctx, g.ID, i, g.Dealer, g.Status, g.HandSeeds[i]); err != nil {
+			return fmt.Errorf("failed to save hand in db: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListGamesByStatus retrieves a list of games with the specified status.
+// Completed matches come from the final state kept in Postgres; games in any
+// other phase come from Redis, which is the truth for hot state. A listing
+// never replays a game, so games Redis has lost are left out.
 func (s *Game) ListGamesByStatus(ctx context.Context, status game.Phase) ([]*game.Game, error) {
+	if status == game.PhaseMatchOver {
+		games, err := s.postgresStore.ListFinalStates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list completed matches from db: %w", err)
+		}
+
+		return games, nil
+	}
+
 	if s.redisStore == nil {
 		return nil, ErrRedisStoreNotInitialized
 	}
@@ -277,15 +658,12 @@ func (s *Game) ListGamesByStatus(ctx context.Context, status game.Phase) ([]*gam
 	for _, id := range ids {
 		g, err := s.redisStore.LoadGame(ctx, id)
 		if err != nil {
-			log.Warn().Str("game_id", id).Err(err).Msg("failed to load game from redis")
+			log.Warn().Str("game_id", id).Err(err).Msg("failed to load game")
 			continue
 		}
 
-		if g != nil {
-			// Only include games where status actually matches (Redis is truth for hot state)
-			if g.Status == status {
-				games = append(games, g)
-			}
+		if g != nil && g.Status == status {
+			games = append(games, g)
 		}
 	}
 
@@ -21,7 +21,7 @@ func newTestServiceWithConfig(t *testing.T) (*Game, sqlmock.Sqlmock) {
 	t.Cleanup(func() { _ = db.Close() })
 
 	pgStore := postgres.NewStoreWithDB(db)
-	mock.ExpectExec(`INSERT INTO games \(id, status, version, created_at\) VALUES \(\$1, \$2, \$3, \$4\)`).
+	mock.ExpectExec(`INSERT INTO games \(id, status, version, config, deal_seed, created_at\) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)`).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	redisStore := &fakeRedisStore{}
@@ -49,3 +49,24 @@ func TestCreateGameStoresFourPlayerConfig(t *testing.T) {
 		t.Fatalf("unmet postgres expectations: %v", err)
 	}
 }
+
+func TestCreateGameWithDealSeedsUsesSeededMode(t *testing.T) {
+	t.Parallel()
+
+	svc, mock := newTestServiceWithConfig(t)
+	base := game.NewSeed()
+	WithDealSeeds(func() game.Seed { return base })(svc)
+
+	g, err := svc.CreateGame(t.Context(), "seeded-game", game.DefaultConfig())
+	if err != nil {
+		t.Fatalf("CreateGame: %v", err)
+	}
+
+	if g.DealSeed == nil || *g.DealSeed != base {
+		t.Fatalf("game must carry the injected base seed, got %v", g.DealSeed)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet postgres expectations: %v", err)
+	}
+}
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
 	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
 	"github.com/redis/go-redis/v9"
 )
@@ -16,6 +21,13 @@ type fakeRedisStore struct {
 	saved      bool
 	savedWith  int64
 	acquireErr error
+	busy       int // lock acquisitions to refuse as contended
+	deadline   *time.Time      // the game's scheduled turn deadline
+	sockets    map[string]bool // the open sockets, of any player
+	watch      *time.Time      // the scheduled presence check
+	watched    string          // whose presence is checked then
+	spectators map[string]bool // the open spectator sockets
+	published  []map[string]any
 }
 
 func (f *fakeRedisStore) SaveGame(_ context.Context, g *game.Game, expectedVersion int64) error {
@@ -35,6 +47,11 @@ func (f *fakeRedisStore) AcquireLock(_ context.Context, _ string) (string, error
 		return "", f.acquireErr
 	}
 
+	if f.busy > 0 {
+		f.busy--
+		return "", redisstore.ErrLockFailed
+	}
+
 	return "test-token", nil
 }
 
@@ -42,7 +59,11 @@ func (f *fakeRedisStore) ReleaseLock(_ context.Context, _, _ string) error {
 	return nil
 }
 
-func (f *fakeRedisStore) PublishEvent(_ context.Context, _ string, _ any) error {
+func (f *fakeRedisStore) PublishEvent(_ context.Context, _ string, event any) error {
+	if m, ok := event.(map[string]any); ok {
+		f.published = append(f.published, m)
+	}
+
 	return nil
 }
 
@@ -50,6 +71,95 @@ func (f *fakeRedisStore) Subscribe(_ context.Context, _ string) *redis.PubSub {
 	return nil
 }
 
+func (f *fakeRedisStore) ScheduleTurn(_ context.Context, _ string, deadline time.Time) error {
+	f.deadline = &deadline
+	return nil
+}
+
+func (f *fakeRedisStore) CancelTurn(_ context.Context, _ string) error {
+	f.deadline = nil
+	return nil
+}
+
+// ClaimDueTurns claims the game once its deadline has passed, leasing it
+// like the real schedule.
+func (f *fakeRedisStore) ClaimDueTurns(_ context.Context, now time.Time, lease time.Duration, _ int) ([]string, error) {
+	if f.deadline == nil || f.deadline.After(now) {
+		return nil, nil
+	}
+
+	leased := now.Add(lease)
+	f.deadline = &leased
+
+	return []string{f.game.ID}, nil
+}
+
+func (f *fakeRedisStore) TouchSocket(_ context.Context, _, _, socketID string, _ time.Duration) (bool, error) {
+	if f.sockets == nil {
+		f.sockets = map[string]bool{}
+	}
+
+	f.sockets[socketID] = true
+
+	return len(f.sockets) == 1, nil
+}
+
+func (f *fakeRedisStore) RemoveSocket(_ context.Context, _, _, socketID string) (bool, error) {
+	removed := f.sockets[socketID]
+	delete(f.sockets, socketID)
+
+	return removed && len(f.sockets) == 0, nil
+}
+
+func (f *fakeRedisStore) CountSockets(_ context.Context, _, _ string) (int, error) {
+	return len(f.sockets), nil
+}
+
+func (f *fakeRedisStore) WatchPresence(_ context.Context, _, playerID string, at time.Time) error {
+	f.watch = &at
+	f.watched = playerID
+	return nil
+}
+
+func (f *fakeRedisStore) UnwatchPresence(_ context.Context, _, _ string) error {
+	f.watch = nil
+	return nil
+}
+
+func (f *fakeRedisStore) TouchSpectator(_ context.Context, _, socketID string, _ time.Duration) (bool, error) {
+	if f.spectators == nil {
+		f.spectators = map[string]bool{}
+	}
+
+	added := !f.spectators[socketID]
+	f.spectators[socketID] = true
+
+	return added, nil
+}
+
+func (f *fakeRedisStore) RemoveSpectator(_ context.Context, _, socketID string) (bool, error) {
+	removed := f.spectators[socketID]
+	delete(f.spectators, socketID)
+
+	return removed, nil
+}
+
+func (f *fakeRedisStore) CountSpectators(_ context.Context, _ string) (int, error) {
+	return len(f.spectators), nil
+}
+
+// ClaimDuePresence claims the watched player once their check is due.
+func (f *fakeRedisStore) ClaimDuePresence(_ context.Context, now time.Time, lease time.Duration, _ int) ([]redisstore.PlayerKey, error) {
+	if f.watch == nil || f.watch.After(now) {
+		return nil, nil
+	}
+
+	leased := now.Add(lease)
+	f.watch = &leased
+
+	return []redisstore.PlayerKey{{GameID: f.game.ID, PlayerID: f.watched}}, nil
+}
+
 func TestJoinGameRejoinSameSeatRefreshesConnectionState(t *testing.T) {
 	t.Parallel()
 	g := game.New("game-1")
@@ -144,3 +254,407 @@ func TestJoinGameSavesWithLoadedVersionAsCASExpectation(t *testing.T) {
 		t.Fatalf("expected CAS expectation 7 (pre-bump version), got %d", store.savedWith)
 	}
 }
+
+// shownHandArg matches a ledger payload that carries the hand a deal miss showed.
+type shownHandArg struct{ hand []game.Card }
+
+func (a shownHandArg) Match(v driver.Value) bool {
+	raw, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+
+	var got game.DealMiss
+	if err := json.Unmarshal(raw, &got); err != nil {
+		return false
+	}
+
+	return fmt.Sprint(got.Hand) == fmt.Sprint(a.hand)
+}
+
+func TestProcessMoveLedgersDealMissHand(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-dealmiss")
+	for i := range 5 {
+		g.Players[i] = &game.Player{ID: fmt.Sprintf("p%d", i), Seat: i}
+	}
+
+	g.Start()
+
+	weak := []game.Card{
+		{Suit: game.Clubs, Rank: game.Two}, {Suit: game.Clubs, Rank: game.Four}, {Suit: game.Clubs, Rank: game.Five},
+		{Suit: game.Hearts, Rank: game.Five}, {Suit: game.Hearts, Rank: game.Six}, {Suit: game.Hearts, Rank: game.Seven},
+		{Suit: game.Diamonds, Rank: game.Seven}, {Suit: game.Diamonds, Rank: game.Eight}, {Suit: game.Spades, Rank: game.Nine},
+		{Suit: game.Spades, Rank: game.Two},
+	}
+	g.Players[2].Hand = weak
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-dealmiss", "p2", 2, sqlmock.AnyArg(), int64(1), "deal_miss", shownHandArg{hand: weak}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO hands`).
+		WithArgs("game-dealmiss:1", "game-dealmiss", 1, sqlmock.AnyArg(), "bidding", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	svc := &Game{redisStore: &fakeRedisStore{game: g}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.ProcessMove(t.Context(), "game-dealmiss", "p2", game.MoveDealMiss, nil, 1); err != nil {
+		t.Fatalf("ProcessMove: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("deal miss not ledgered with the shown hand: %v", err)
+	}
+}
+
+func TestGetGameReplaysLedgerOnRedisMiss(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// The game as it stood when Redis lost it: five joins and a deal.
+	want := game.New("game-lost")
+	for i := range 5 {
+		want.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("player %d", i))
+	}
+
+	configJSON, _ := json.Marshal(want.Config)
+	mock.ExpectQuery(`SELECT config, deal_seed, created_at, status, version FROM games`).
+		WithArgs("game-lost").
+		WillReturnRows(sqlmock.NewRows([]string{"config", "deal_seed", "created_at", "status", "version"}).AddRow(configJSON, nil, want.CreatedAt, want.Status, want.Version))
+	mock.ExpectQuery(`SELECT seed FROM hands`).
+		WithArgs("game-lost").
+		WillReturnRows(sqlmock.NewRows([]string{"seed"}).AddRow(want.HandSeeds[0].String()))
+
+	moves := sqlmock.NewRows([]string{"version", "player_id", "seat_no", "move_type", "payload"})
+	for i := range 5 {
+		moves.AddRow(int64(i+1), fmt.Sprintf("p%d", i), i, "join", fmt.Appendf(nil, `{"name":"player %d"}`, i))
+	}
+	mock.ExpectQuery(`SELECT version, player_id, seat_no, move_type, payload FROM moves`).
+		WithArgs("game-lost").
+		WillReturnRows(moves)
+
+	redisStore := &fakeRedisStore{}
+	svc := &Game{redisStore: redisStore, postgresStore: postgres.NewStoreWithDB(db)}
+
+	g, err := svc.GetGame(t.Context(), "game-lost")
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+
+	if g.Status != game.PhaseBidding || g.Version != want.Version {
+		t.Fatalf("expected bidding at version %d, got %s at %d", want.Version, g.Status, g.Version)
+	}
+
+	for seat, p := range g.Players {
+		if fmt.Sprint(p.Hand) != fmt.Sprint(want.Players[seat].Hand) {
+			t.Fatalf("seat %d hand not redealt from its seed", seat)
+		}
+	}
+
+	if !redisStore.saved || redisStore.savedWith != 0 {
+		t.Fatalf("replayed game must be written back to Redis as new, saved=%v with=%d", redisStore.saved, redisStore.savedWith)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet postgres expectations: %v", err)
+	}
+}
+
+func TestGetGameKeepsAQuarantineThroughReplay(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// Two players joined, then a move broke the game and it was
+	// quarantined at version 4; Redis has since lost it.
+	cfg := game.DefaultConfig()
+	configJSON, _ := json.Marshal(cfg)
+	mock.ExpectQuery(`SELECT config, deal_seed, created_at, status, version FROM games`).
+		WithArgs("game-broken").
+		WillReturnRows(sqlmock.NewRows([]string{"config", "deal_seed", "created_at", "status", "version"}).AddRow(configJSON, nil, time.Now(), game.PhaseCorrupted, int64(4)))
+	mock.ExpectQuery(`SELECT seed FROM hands`).
+		WithArgs("game-broken").
+		WillReturnRows(sqlmock.NewRows([]string{"seed"}))
+
+	moves := sqlmock.NewRows([]string{"version", "player_id", "seat_no", "move_type", "payload"})
+	for i := range 2 {
+		moves.AddRow(int64(i+1), fmt.Sprintf("p%d", i), i, "join", fmt.Appendf(nil, `{"name":"player %d"}`, i))
+	}
+	mock.ExpectQuery(`SELECT version, player_id, seat_no, move_type, payload FROM moves`).
+		WithArgs("game-broken").
+		WillReturnRows(moves)
+
+	svc := &Game{redisStore: &fakeRedisStore{}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	g, err := svc.GetGame(t.Context(), "game-broken")
+	if err != nil {
+		t.Fatalf("GetGame: %v", err)
+	}
+
+	if g.Status != game.PhaseCorrupted || g.Version != 4 {
+		t.Fatalf("expected the game to stay corrupted at version 4, got %s at %d", g.Status, g.Version)
+	}
+
+	if _, err := svc.ProcessMove(t.Context(), "game-broken", "p0", game.MovePass, nil, g.Version); !errors.Is(err, ErrGameCorrupted) {
+		t.Fatalf("a replayed quarantine must still refuse moves, got %v", err)
+	}
+}
+
+func TestProcessMoveKeepsTheFinalStateOfAMatch(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// A one-round match whose declarer is about to concede.
+	cfg := game.DefaultConfig()
+	cfg.Match = game.MatchConfig{Rounds: 1}
+	g := game.NewWithConfig("game-last", cfg)
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	for g.Status != game.PhaseCalling {
+		p := g.Players[g.CurrentTurn]
+		var m game.LegalMove
+		for _, m = range g.LegalMovesExceptClaims(p.ID) {
+			if m.Type != game.MoveDealMiss && m.Type != game.MoveChangeTrump {
+				break
+			}
+		}
+		if opt, ok := m.Payload.(game.DiscardOption); ok {
+			m.Payload = opt.From[:opt.Count]
+		}
+		if err := g.ApplyMove(p.ID, m.Type, m.Payload); err != nil {
+			t.Fatalf("apply %s: %v", m.Type, err)
+		}
+	}
+	declarer := g.Players[g.Declarer].ID
+
+	mock.ExpectExec(`INSERT INTO moves`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE games SET status`).
+		WithArgs("match_over", g.Version+1, "game-last").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE games SET final_state`).
+		WithArgs(sqlmock.AnyArg(), "game-last").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	svc := &Game{redisStore: &fakeRedisStore{game: g}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	over, err := svc.ProcessMove(t.Context(), "game-last", declarer, game.MoveConcede, nil, g.Version)
+	if err != nil {
+		t.Fatalf("ProcessMove: %v", err)
+	}
+
+	if over.Status != game.PhaseMatchOver {
+		t.Fatalf("expected match_over, got %s", over.Status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("final state not kept: %v", err)
+	}
+}
+
+func TestJoinGameRecordsStatusChange(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-fill")
+	for i := range 4 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	// The last seat deals the first hand, which moves the game to bidding.
+	mock.ExpectExec(`INSERT INTO moves`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO hands`).
+		WithArgs("game-fill:0", "game-fill", 0, sqlmock.AnyArg(), "bidding", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE games SET status`).
+		WithArgs("bidding", int64(6), "game-fill").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	svc := &Game{redisStore: &fakeRedisStore{game: g}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.JoinGame(t.Context(), "game-fill", "p4", "P4"); err != nil {
+		t.Fatalf("JoinGame: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("status change not recorded: %v", err)
+	}
+}
+
+func TestProcessMoveQuarantinesABrokenGame(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-broken")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	// A card held twice survives any legal move, so the next one must not
+	// be saved or ledgered.
+	g.Players[3].Hand[0] = g.Players[1].Hand[0]
+	loaded := g.Version
+	turn := g.Players[g.CurrentTurn].ID
+
+	mock.ExpectExec(`UPDATE games SET status`).
+		WithArgs("corrupted", loaded+1, "game-broken").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis, postgresStore: postgres.NewStoreWithDB(db)}
+
+	_, err = svc.ProcessMove(t.Context(), "game-broken", turn, game.MovePass, nil, loaded)
+
+	var inv *game.InvariantError
+	if !errors.Is(err, ErrGameCorrupted) || !errors.As(err, &inv) {
+		t.Fatalf("expected a corrupted game, got %v", err)
+	}
+
+	saved := redis.game
+	if saved.Status != game.PhaseCorrupted || saved.Version != loaded+1 || redis.savedWith != loaded {
+		t.Fatalf("expected the pre-move state quarantined at version %d, got %s at %d", loaded+1, saved.Status, saved.Version)
+	}
+
+	if len(saved.PassedPlayers) != 0 {
+		t.Fatal("the rejected move must not be in the quarantined state")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("corrupted status not recorded: %v", err)
+	}
+
+	if _, err := svc.ProcessMove(t.Context(), "game-broken", turn, game.MovePass, nil, saved.Version); !errors.Is(err, ErrGameCorrupted) {
+		t.Fatalf("a quarantined game must refuse moves, got %v", err)
+	}
+}
+
+func TestExportGameWritesUpTheLedger(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-done")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("player %d", i))
+	}
+
+	redisStore := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redisStore, postgresStore: postgres.NewStoreWithDB(db)}
+
+	// A hand still in play would show everyone's cards.
+	if _, err := svc.ExportGame(t.Context(), "game-done"); !errors.Is(err, ErrGameNotFinished) {
+		t.Fatalf("expected ErrGameNotFinished, got %v", err)
+	}
+
+	configJSON, _ := json.Marshal(g.Config)
+	mock.ExpectQuery(`SELECT config, deal_seed, created_at, status, version FROM games`).
+		WithArgs("game-done").
+		WillReturnRows(sqlmock.NewRows([]string{"config", "deal_seed", "created_at", "status", "version"}).AddRow(configJSON, nil, g.CreatedAt, g.Status, g.Version))
+	mock.ExpectQuery(`SELECT seed FROM hands`).
+		WithArgs("game-done").
+		WillReturnRows(sqlmock.NewRows([]string{"seed"}).AddRow(g.HandSeeds[0].String()))
+
+	moves := sqlmock.NewRows([]string{"version", "player_id", "seat_no", "move_type", "payload"})
+	for i := range 5 {
+		moves.AddRow(int64(i+1), fmt.Sprintf("p%d", i), i, "join", fmt.Appendf(nil, `{"name":"player %d"}`, i))
+	}
+	mock.ExpectQuery(`SELECT version, player_id, seat_no, move_type, payload FROM moves`).
+		WithArgs("game-done").
+		WillReturnRows(moves)
+
+	g.Status = game.PhaseFinished
+
+	rec, err := svc.ExportGame(t.Context(), "game-done")
+	if err != nil {
+		t.Fatalf("ExportGame: %v", err)
+	}
+
+	if rec.Header("Seat4") != "player 4" || len(rec.Hands) != 1 || fmt.Sprint(rec.Hands[0].Deal[2].Cards) != fmt.Sprint(g.Players[2].Hand) {
+		t.Fatalf("unexpected record:\n%s", rec)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet postgres expectations: %v", err)
+	}
+}
+
+func TestLeaveGameForfeitsTheHandInProgress(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-forfeit")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	loaded := g.Version
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-forfeit", "p2", 2, loaded+1, loaded, "leave", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`UPDATE games SET status`).
+		WithArgs("finished", loaded+1, "game-forfeit").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	svc := &Game{redisStore: &fakeRedisStore{game: g}, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.LeaveGame(t.Context(), "game-forfeit", "nobody"); !errors.Is(err, ErrNotSeated) {
+		t.Fatalf("expected ErrNotSeated, got %v", err)
+	}
+
+	got, err := svc.LeaveGame(t.Context(), "game-forfeit", "p2")
+	if err != nil {
+		t.Fatalf("LeaveGame: %v", err)
+	}
+
+	if got.Players[2] != nil || got.Status != game.PhaseFinished {
+		t.Fatalf("expected seat 2 freed and the hand over, got %s", got.Status)
+	}
+
+	if got.TotalScores["p2"] != -4*game.DefaultForfeit().Penalty {
+		t.Fatalf("expected the leaver to pay every other seat, got %v", got.TotalScores)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("leave not ledgered: %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// socketTTL is how long a socket counts as open without being touched
+	// again. Connections touch their socket well within it, so only a socket
+	// whose instance died goes stale.
+	socketTTL = 90 * time.Second
+	// presenceLease and presenceBatch are turnLease and turnBatch for the
+	// presence sweep.
+	presenceLease = 10 * time.Second
+	presenceBatch = 50
+)
+
+// Connect records that socketID, one of a seated player's connections to a
+// game, is open, reconnecting the player if it is their only one. Calling it
+// again keeps the socket open for another socketTTL. Spectators are not
+// tracked.
+func (s *Game) Connect(ctx context.Context, gameID, playerID, socketID string) error {
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil || g.GetPlayer(playerID) == nil {
+		return nil
+	}
+
+	only, err := s.redisStore.TouchSocket(ctx, gameID, playerID, socketID, socketTTL)
+	if err != nil || !only {
+		return err
+	}
+
+	return s.settleSoon(ctx, gameID, playerID)
+}
+
+// Disconnect records that socketID has closed. Once a player has no socket
+// open on any instance they are marked disconnected and their grace period
+// starts.
+func (s *Game) Disconnect(ctx context.Context, gameID, playerID, socketID string) error {
+	last, err := s.redisStore.RemoveSocket(ctx, gameID, playerID, socketID)
+	if err != nil || !last {
+		return err
+	}
+
+	return s.settleSoon(ctx, gameID, playerID)
+}
+
+// settleSoon settles the player's presence now, or leaves it to the next
+// presence sweep when the table is busy.
+func (s *Game) settleSoon(ctx context.Context, gameID, playerID string) error {
+	err := s.settlePresence(ctx, gameID, playerID)
+	if errors.Is(err, ErrGameBusy) {
+		return s.redisStore.WatchPresence(ctx, gameID, playerID, time.Now())
+	}
+
+	return err
+}
+
+// RunPresence settles the presence of every player whose check is due,
+// sweeping every interval until ctx is done. That catches sockets lost with
+// the instance holding them, and applies the substitute policy to players
+// whose grace period has run out.
+func (s *Game) RunPresence(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweepPresence(ctx)
+		}
+	}
+}
+
+// sweepPresence claims the presence checks that are due and settles each.
+func (s *Game) sweepPresence(ctx context.Context) {
+	keys, err := s.redisStore.ClaimDuePresence(ctx, time.Now(), presenceLease, presenceBatch)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to claim presence checks")
+		return
+	}
+
+	for _, k := range keys {
+		// A busy table keeps the lease, and is checked again when it lapses.
+		if err := s.settlePresence(ctx, k.GameID, k.PlayerID); err != nil && !errors.Is(err, ErrGameBusy) {
+			log.Warn().Str("game_id", k.GameID).Str("player_id", k.PlayerID).Err(err).Msg("failed to settle presence")
+		}
+	}
+}
+
+// settlePresence brings a player's seat in line with their open sockets
+// across all instances: reconnecting or disconnecting them, and once a
+// disconnected player's grace period is over, applying the game's
+// substitute policy. It then schedules the player's next check, if any.
+func (s *Game) settlePresence(ctx context.Context, gameID, playerID string) error {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return fmt.Errorf("failed to load game: %w", err)
+	}
+
+	var p *game.Player
+	if g != nil && g.Status != game.PhaseCorrupted {
+		p = g.GetPlayer(playerID)
+	}
+
+	if p == nil {
+		return s.redisStore.UnwatchPresence(ctx, gameID, playerID)
+	}
+
+	open, err := s.redisStore.CountSockets(ctx, gameID, playerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	loadedVersion := g.Version
+	event := map[string]any{"player_id": playerID, "seat": p.Seat}
+	next := time.Time{}
+	// Only a substitution, or the table being left unattended or attended
+	// again, starts or stops a clock.
+	rearm := p.Substituted
+	attended := g.Attended()
+
+	switch {
+	case open > 0:
+		next = now.Add(socketTTL)
+		if p.IsConnected {
+			return s.redisStore.WatchPresence(ctx, gameID, playerID, next)
+		}
+
+		if err := g.Reconnect(playerID); err != nil {
+			return err
+		}
+
+		event["type"] = "player_reconnected"
+
+	case p.IsConnected:
+		if err := g.Disconnect(playerID, now); err != nil {
+			return err
+		}
+
+		event["type"] = "player_disconnected"
+		if deadline, ok := g.GraceDeadline(playerID); ok {
+			event["grace_deadline"] = deadline
+			next = deadline
+		}
+
+	default:
+		deadline, ok := g.GraceDeadline(playerID)
+		if !ok || p.Substituted {
+			return s.redisStore.UnwatchPresence(ctx, gameID, playerID)
+		}
+
+		if now.Before(deadline) {
+			return s.redisStore.WatchPresence(ctx, gameID, playerID, deadline)
+		}
+
+		if g.Config.Presence.Policy == game.SubstituteForfeit {
+			if err := s.redisStore.UnwatchPresence(ctx, gameID, playerID); err != nil {
+				return err
+			}
+
+			_, err := s.leave(ctx, g, playerID)
+
+			return err
+		}
+
+		if err := g.Substitute(playerID); err != nil {
+			return err
+		}
+
+		rearm = true
+
+		event["type"] = "player_substituted"
+		event["policy"] = g.Config.Presence.Policy
+	}
+
+	rearm = (rearm && g.CurrentTurn == p.Seat) || g.Attended() != attended
+	if rearm {
+		g.ArmTurnTimer(now)
+	}
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return err
+	}
+
+	if rearm {
+		s.scheduleTurn(ctx, g)
+	}
+
+	if next.IsZero() {
+		err = s.redisStore.UnwatchPresence(ctx, gameID, playerID)
+	} else {
+		err = s.redisStore.WatchPresence(ctx, gameID, playerID, next)
+	}
+
+	event["version"] = g.Version
+	event["turn_deadline"] = g.TurnDeadline
+	_ = s.redisStore.PublishEvent(ctx, gameID, event)
+
+	return err
+}
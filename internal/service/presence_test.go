@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+func TestPresenceFollowsTheLastSocket(t *testing.T) {
+	t.Parallel()
+
+	g := game.New("game-presence")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis}
+
+	for _, socket := range []string{"tab", "phone"} {
+		if err := svc.Connect(t.Context(), "game-presence", "p1", socket); err != nil {
+			t.Fatalf("Connect: %v", err)
+		}
+	}
+
+	if err := svc.Disconnect(t.Context(), "game-presence", "p1", "tab"); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	if !redis.game.Players[1].IsConnected || len(redis.published) != 0 {
+		t.Fatalf("p1 still has a socket open, but got %v", redis.published)
+	}
+
+	if err := svc.Disconnect(t.Context(), "game-presence", "p1", "phone"); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	p := redis.game.Players[1]
+	if p.IsConnected || p.DisconnectedAt == nil || len(redis.published) != 1 || redis.published[0]["type"] != "player_disconnected" {
+		t.Fatalf("expected p1 disconnected, got %+v and %v", p, redis.published)
+	}
+
+	grace, _ := redis.game.GraceDeadline("p1")
+	if redis.watch == nil || !redis.watch.Equal(grace) || redis.published[0]["grace_deadline"] != grace {
+		t.Fatalf("expected p1 checked at the grace deadline %v, got %v", grace, redis.watch)
+	}
+
+	if err := svc.Connect(t.Context(), "game-presence", "p1", "laptop"); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if p := redis.game.Players[1]; !p.IsConnected || p.DisconnectedAt != nil || redis.published[1]["type"] != "player_reconnected" {
+		t.Fatalf("expected p1 reconnected, got %+v and %v", p, redis.published)
+	}
+
+	// A spectator's sockets are not tracked.
+	if err := svc.Connect(t.Context(), "game-presence", "watcher", "tv"); err != nil || redis.sockets["tv"] {
+		t.Fatalf("expected a spectator ignored, got %v", err)
+	}
+}
+
+func TestSweepPresenceSubstitutesAfterTheGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	g := game.New("game-grace")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	gone := g.Players[g.CurrentTurn].ID
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis}
+
+	if err := svc.Connect(t.Context(), "game-grace", gone, "tab"); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := svc.Disconnect(t.Context(), "game-grace", gone, "tab"); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	// Within the grace period the sweep has nothing to do.
+	svc.sweepPresence(t.Context())
+
+	if redis.game.GetPlayer(gone).Substituted {
+		t.Fatal("substituted before the grace period ran out")
+	}
+
+	long := time.Now().Add(-time.Hour)
+	redis.game.GetPlayer(gone).DisconnectedAt = &long
+	redis.watch = &long
+
+	svc.sweepPresence(t.Context())
+
+	got := redis.game
+	if !got.GetPlayer(gone).Substituted || redis.published[len(redis.published)-1]["type"] != "player_substituted" {
+		t.Fatalf("expected %s substituted, got %v", gone, redis.published)
+	}
+
+	// Under the default auto-play policy their clock runs out at once.
+	if got.TurnDeadline == nil || got.TurnDeadline.After(time.Now()) || redis.deadline == nil || !redis.deadline.Equal(*got.TurnDeadline) {
+		t.Fatalf("expected %s's turn due now, got %v (scheduled %v)", gone, got.TurnDeadline, redis.deadline)
+	}
+
+	if redis.watch != nil {
+		t.Fatalf("a substituted player needs no more checks, got one at %v", redis.watch)
+	}
+}
+
+func TestPresenceStopsTheClockAtAnEmptyTable(t *testing.T) {
+	t.Parallel()
+
+	g := game.New("game-empty")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	// Everyone but p1 has already gone.
+	for _, p := range g.Players {
+		p.IsConnected = p.ID == "p1"
+	}
+
+	g.ArmTurnTimer(time.Now())
+	redis := &fakeRedisStore{game: g, deadline: g.TurnDeadline}
+	svc := &Game{redisStore: redis}
+
+	if err := svc.Connect(t.Context(), "game-empty", "p1", "tab"); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	if err := svc.Disconnect(t.Context(), "game-empty", "p1", "tab"); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	if redis.game.TurnDeadline != nil || redis.deadline != nil {
+		t.Fatalf("expected the clock stopped once the table emptied, got %v (scheduled %v)", redis.game.TurnDeadline, redis.deadline)
+	}
+
+	if err := svc.Connect(t.Context(), "game-empty", "p3", "phone"); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	got := redis.game
+	if got.TurnDeadline == nil || redis.deadline == nil || !redis.deadline.Equal(*got.TurnDeadline) {
+		t.Fatalf("expected the clock restarted when p3 came back, got %v (scheduled %v)", got.TurnDeadline, redis.deadline)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/rs/zerolog/log"
+)
+
+// Spectate records that socketID, one of viewerID's spectator connections
+// to a game, is open. Calling it again keeps the socket counted for another
+// socketTTL. It returns the game so the caller knows how the spectator may
+// watch it.
+func (s *Game) Spectate(ctx context.Context, gameID, viewerID, socketID string) (*game.Game, error) {
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	switch {
+	case g == nil:
+		return nil, ErrGameNotFound
+	case !g.Spectatable():
+		return nil, ErrSpectatingClosed
+	case g.GetPlayer(viewerID) != nil:
+		return nil, ErrSeated
+	}
+
+	added, err := s.redisStore.TouchSpectator(ctx, gameID, socketID, socketTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.countSpectators(ctx, g)
+
+	if added {
+		s.publishSpectators(ctx, g)
+	}
+
+	return g, nil
+}
+
+// StopSpectating records that a spectator's socket has closed.
+func (s *Game) StopSpectating(ctx context.Context, gameID, socketID string) error {
+	removed, err := s.redisStore.RemoveSpectator(ctx, gameID, socketID)
+	if err != nil || !removed {
+		return err
+	}
+
+	g := &game.Game{ID: gameID}
+	s.countSpectators(ctx, g)
+	s.publishSpectators(ctx, g)
+
+	return nil
+}
+
+// SetSpectating lets the table's owner open or close the game to
+// spectators. Closing it sends spectators already watching away.
+func (s *Game) SetSpectating(ctx context.Context, gameID, requesterID string, open bool) (*game.Game, error) {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Owner != requesterID {
+		return nil, ErrNotOwner
+	}
+
+	if g.Status == game.PhaseCorrupted {
+		return nil, ErrGameCorrupted
+	}
+
+	if g.Spectatable() == open {
+		s.countSpectators(ctx, g)
+		return g, nil
+	}
+
+	loadedVersion := g.Version
+	seat := -1
+	if p := g.GetPlayer(requesterID); p != nil {
+		seat = p.Seat
+	}
+
+	g.SetSpectating(open)
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, game.MoveSpectating, requesterID, seat, g.Version, loadedVersion, map[string]any{"open": open}, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save spectating move in db: %w", err)
+	}
+
+	s.countSpectators(ctx, g)
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":    "spectating_changed",
+		"open":    open,
+		"version": g.Version,
+	})
+
+	return g, nil
+}
+
+// countSpectators fills in g's spectator count. The count lives in Redis
+// rather than in the game, so spectators coming and going never bump its
+// version; a count that cannot be read is left at zero.
+func (s *Game) countSpectators(ctx context.Context, g *game.Game) {
+	n, err := s.redisStore.CountSpectators(ctx, g.ID)
+	if err != nil {
+		log.Warn().Str("game_id", g.ID).Err(err).Msg("failed to count spectators")
+		return
+	}
+
+	g.Spectators = n
+}
+
+// publishSpectators tells everyone watching the game its new spectator
+// count.
+func (s *Game) publishSpectators(ctx context.Context, g *game.Game) {
+	_ = s.redisStore.PublishEvent(ctx, g.ID, map[string]any{
+		"type":       "spectators_changed",
+		"spectators": g.Spectators,
+	})
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+)
+
+func TestSpectatorsAreCountedUntilTheOwnerClosesTheGame(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-spectate")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	g.Owner = "p0"
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.Spectate(t.Context(), "game-spectate", "p1", "socket-p1"); !errors.Is(err, ErrSeated) {
+		t.Fatalf("a seated player must play, not watch, got %v", err)
+	}
+
+	for _, socket := range []string{"a", "b", "a"} {
+		if _, err := svc.Spectate(t.Context(), "game-spectate", "v1", socket); err != nil {
+			t.Fatalf("Spectate: %v", err)
+		}
+	}
+
+	got, err := svc.GetGame(t.Context(), "game-spectate")
+	if err != nil || got.Spectators != 2 {
+		t.Fatalf("expected 2 spectators, got %d (%v)", got.Spectators, err)
+	}
+
+	if err := svc.StopSpectating(t.Context(), "game-spectate", "b"); err != nil {
+		t.Fatalf("StopSpectating: %v", err)
+	}
+
+	if last := redis.published[len(redis.published)-1]; last["type"] != "spectators_changed" || last["spectators"] != 1 {
+		t.Fatalf("expected the count published, got %v", last)
+	}
+
+	if _, err := svc.SetSpectating(t.Context(), "game-spectate", "p1", false); !errors.Is(err, ErrNotOwner) {
+		t.Fatalf("only the owner closes the game to spectators, got %v", err)
+	}
+
+	loaded := g.Version
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-spectate", "p0", 0, loaded+1, loaded, "spectating", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if _, err := svc.SetSpectating(t.Context(), "game-spectate", "p0", false); err != nil {
+		t.Fatalf("SetSpectating: %v", err)
+	}
+
+	if last := redis.published[len(redis.published)-1]; last["type"] != "spectating_changed" || last["open"] != false {
+		t.Fatalf("expected spectators told the game closed, got %v", last)
+	}
+
+	if _, err := svc.Spectate(t.Context(), "game-spectate", "v2", "c"); !errors.Is(err, ErrSpectatingClosed) {
+		t.Fatalf("expected the game closed to spectators, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("spectating change not ledgered: %v", err)
+	}
+}
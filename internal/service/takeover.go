@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+)
+
+// RequestTakeover asks the table to let playerID take over seat, abandoned
+// by a player gone past their grace period. Every other person still seated
+// then votes through VoteTakeover; a seat no one could vote on is refused.
+func (s *Game) RequestTakeover(ctx context.Context, gameID, playerID, playerName string, seat int) (*game.Game, error) {
+	return s.takeover(ctx, gameID, playerID, func(g *game.Game) error {
+		return g.RequestTakeover(seat, playerID, playerName)
+	})
+}
+
+// VoteTakeover records a seated player's vote on the pending takeover. The
+// last approval hands the seat over, ledgered as a takeover move so the
+// seat's later results are credited to its new player.
+func (s *Game) VoteTakeover(ctx context.Context, gameID, playerID string, approve bool) (*game.Game, error) {
+	return s.takeover(ctx, gameID, playerID, func(g *game.Game) error {
+		return g.VoteTakeover(playerID, approve)
+	})
+}
+
+// takeover applies a takeover request or vote under the game lock. Only a
+// completed handover is ledgered; the request and its votes are published
+// as takeover_updated events.
+func (s *Game) takeover(ctx context.Context, gameID, playerID string, change func(g *game.Game) error) (*game.Game, error) {
+	release, err := s.withGameLock(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game: %w", err)
+	}
+
+	if g == nil {
+		return nil, ErrGameNotFound
+	}
+
+	if g.Status == game.PhaseCorrupted {
+		return nil, ErrGameCorrupted
+	}
+
+	return s.applyTakeover(ctx, g, playerID, change)
+}
+
+// applyTakeover applies a takeover request or vote to g, which the caller
+// has loaded under the game lock, and saves and publishes the result.
+func (s *Game) applyTakeover(ctx context.Context, g *game.Game, playerID string, change func(g *game.Game) error) (*game.Game, error) {
+	gameID := g.ID
+	loadedVersion := g.Version
+	handovers := len(g.Handovers)
+	before := g.Clone()
+
+	if err := change(g); err != nil {
+		return nil, err
+	}
+
+	if err := g.CheckInvariants(loadedVersion); err != nil {
+		return nil, s.quarantine(ctx, before, g, playerID, game.MoveTakeover, nil, err)
+	}
+
+	if len(g.Handovers) == handovers {
+		if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+			return nil, err
+		}
+
+		_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+			"type":     "takeover_updated",
+			"takeover": g.Takeover,
+			"version":  g.Version,
+		})
+
+		return g, nil
+	}
+
+	h := g.Handovers[len(g.Handovers)-1]
+	if g.CurrentTurn == h.Seat {
+		g.ArmTurnTimer(time.Now())
+	}
+
+	if err := s.redisStore.SaveGame(ctx, g, loadedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.postgresStore.SaveMove(ctx, game.MoveTakeover, h.To, h.Seat, g.Version, loadedVersion, map[string]any{"name": g.Players[h.Seat].Name, "from": h.From}, gameID); err != nil {
+		return nil, fmt.Errorf("failed to save takeover move in db: %w", err)
+	}
+
+	s.scheduleTurn(ctx, g)
+
+	// The newcomer counts as connected until their first socket is due, so a
+	// seat taken over by someone who never shows up is soon abandoned again.
+	_ = s.redisStore.WatchPresence(ctx, gameID, h.To, time.Now().Add(socketTTL))
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":          "seat_taken_over",
+		"seat":          h.Seat,
+		"from":          h.From,
+		"player_id":     h.To,
+		"name":          g.Players[h.Seat].Name,
+		"version":       g.Version,
+		"turn_deadline": g.TurnDeadline,
+	})
+
+	return g, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+)
+
+func TestTakeoverIsLedgeredOnTheLastApproval(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-takeover")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	if err := g.Disconnect("p2", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Disconnect: %v", err)
+	}
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis, postgresStore: postgres.NewStoreWithDB(db)}
+
+	if _, err := svc.RequestTakeover(t.Context(), "game-takeover", "p9", "P9", 1); !errors.Is(err, game.ErrInvalidMove) {
+		t.Fatalf("seat 1 is not abandoned, got %v", err)
+	}
+
+	if _, err := svc.RequestTakeover(t.Context(), "game-takeover", "p9", "P9", 2); err != nil {
+		t.Fatalf("RequestTakeover: %v", err)
+	}
+
+	for _, id := range []string{"p0", "p1", "p3"} {
+		if _, err := svc.VoteTakeover(t.Context(), "game-takeover", id, true); err != nil {
+			t.Fatalf("VoteTakeover: %v", err)
+		}
+	}
+
+	loaded := redis.game.Version
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-takeover", "p9", 2, loaded+1, loaded, "takeover", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	got, err := svc.VoteTakeover(t.Context(), "game-takeover", "p4", true)
+	if err != nil {
+		t.Fatalf("VoteTakeover: %v", err)
+	}
+
+	if got.Players[2].ID != "p9" || got.Takeover != nil {
+		t.Fatalf("expected p9 seated at 2, got %+v", got.Players[2])
+	}
+
+	if last := redis.published[len(redis.published)-1]; last["type"] != "seat_taken_over" || last["from"] != "p2" {
+		t.Fatalf("expected a seat_taken_over event, got %v", last)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("takeover not ledgered: %v", err)
+	}
+}
+
+func TestJoinGameAsksToTakeOverALeftSeat(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-left")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+	if err := g.Leave("p2"); err != nil {
+		t.Fatalf("Leave: %v", err)
+	}
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis, postgresStore: postgres.NewStoreWithDB(db)}
+
+	got, err := svc.JoinGame(t.Context(), "game-left", "p9", "P9")
+	if err != nil {
+		t.Fatalf("JoinGame: %v", err)
+	}
+
+	if got.Players[2] != nil || got.Takeover == nil || got.Takeover.Seat != 2 || got.Takeover.PlayerID != "p9" {
+		t.Fatalf("expected the join to ask the table for seat 2, got %+v", got.Takeover)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("a takeover request is not ledgered: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	redisstore "github.com/joekhosbayar/go-mighty/internal/store/redis"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// turnLease is how long a claimed deadline is held by the instance that
+	// claimed it. If that instance dies before making the timeout move, the
+	// deadline comes due again and another instance makes it.
+	turnLease = 10 * time.Second
+	// turnBatch bounds the deadlines claimed in one sweep.
+	turnBatch = 50
+)
+
+// scheduleTurn mirrors g's turn deadline into the Redis schedule that
+// RunTurnTimers sweeps. A failure is logged rather than returned: the move
+// that set the deadline has already been saved, and the next move will
+// schedule the table again.
+func (s *Game) scheduleTurn(ctx context.Context, g *game.Game) {
+	var err error
+	if g.TurnDeadline == nil {
+		err = s.redisStore.CancelTurn(ctx, g.ID)
+	} else {
+		err = s.redisStore.ScheduleTurn(ctx, g.ID, *g.TurnDeadline)
+	}
+
+	if err != nil {
+		log.Error().Str("game_id", g.ID).Err(err).Msg("failed to schedule turn deadline")
+	}
+}
+
+// RunTurnTimers makes the timeout move for every player whose turn deadline
+// has passed, checking every interval until ctx is done. The deadlines live
+// in Redis, so any number of instances may run it and a restart loses none
+// of them.
+func (s *Game) RunTurnTimers(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.expireTurns(ctx)
+		}
+	}
+}
+
+// expireTurns claims the deadlines that have passed and times each one out.
+func (s *Game) expireTurns(ctx context.Context) {
+	ids, err := s.redisStore.ClaimDueTurns(ctx, time.Now(), turnLease, turnBatch)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to claim turn deadlines")
+		return
+	}
+
+	for _, id := range ids {
+		s.expireTurn(ctx, id)
+	}
+}
+
+// expireTurn submits the timeout move for the player to move in gameID,
+// through processMove like any other move. A deadline that moved on since
+// it was scheduled is put back; one beaten by a real move is dropped, as
+// that move has scheduled the next deadline itself. A timeout move that the
+// game rejects would be rejected again on every lease, so the clock is
+// stopped instead, until the next move arms it again.
+func (s *Game) expireTurn(ctx context.Context, gameID string) {
+	g, err := s.loadGame(ctx, gameID)
+	if err != nil {
+		log.Warn().Str("game_id", gameID).Err(err).Msg("failed to load game for turn timeout")
+		return
+	}
+
+	if g == nil {
+		if err := s.redisStore.CancelTurn(ctx, gameID); err != nil {
+			log.Error().Str("game_id", gameID).Err(err).Msg("failed to cancel turn deadline")
+		}
+
+		return
+	}
+
+	if g.TurnDeadline == nil || time.Now().Before(*g.TurnDeadline) {
+		s.scheduleTurn(ctx, g)
+		return
+	}
+
+	playerID, m, ok := g.TimeoutMove()
+	if !ok || g.Status == game.PhaseCorrupted {
+		s.stopTurnClock(ctx, g)
+		return
+	}
+
+	if err := g.ValidateMove(playerID, m.Type, m.Payload); err != nil {
+		log.Error().Str("game_id", gameID).Str("player_id", playerID).Str("move_type", string(m.Type)).Err(err).Msg("turn timeout move is illegal; stopping the clock")
+		s.stopTurnClock(ctx, g)
+
+		return
+	}
+
+	next, err := s.processMove(ctx, gameID, playerID, m.Type, m.Payload, g.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, redisstore.ErrStaleVersion):
+		case errors.Is(err, game.ErrInvalidMove), errors.Is(err, ErrGameCorrupted):
+			log.Error().Str("game_id", gameID).Str("player_id", playerID).Str("move_type", string(m.Type)).Err(err).Msg("turn timeout move failed; stopping the clock")
+			s.stopTurnClock(ctx, g)
+		default:
+			log.Warn().Str("game_id", gameID).Str("player_id", playerID).Str("move_type", string(m.Type)).Err(err).Msg("turn timeout move failed")
+		}
+
+		return
+	}
+
+	_ = s.redisStore.PublishEvent(ctx, gameID, map[string]any{
+		"type":      "turn_timeout",
+		"player_id": playerID,
+		"move_type": m.Type,
+		"version":   next.Version,
+	})
+
+	s.kickBots(ctx, next)
+}
+
+// stopTurnClock drops g's turn deadline from the schedule. The game itself
+// is left as saved; its next move arms a new deadline.
+func (s *Game) stopTurnClock(ctx context.Context, g *game.Game) {
+	g.TurnDeadline = nil
+	s.scheduleTurn(ctx, g)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/joekhosbayar/go-mighty/internal/game"
+	"github.com/joekhosbayar/go-mighty/internal/store/postgres"
+)
+
+func TestExpireTurnsPassesForAnIdleBidder(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	g := game.New("game-idle")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	idle := g.CurrentTurn
+	past := time.Now().Add(-time.Second)
+	g.TurnDeadline = &past
+	loaded := g.Version
+
+	mock.ExpectExec(`INSERT INTO moves`).
+		WithArgs("game-idle", fmt.Sprintf("p%d", idle), idle, loaded+1, loaded, "pass", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	redis := &fakeRedisStore{game: g, deadline: &past}
+	svc := &Game{redisStore: redis, postgresStore: postgres.NewStoreWithDB(db)}
+
+	svc.expireTurns(t.Context())
+
+	got := redis.game
+	if !got.PassedPlayers[idle] || got.Version != loaded+1 {
+		t.Fatalf("expected seat %d passed at version %d, got %v at %d", idle, loaded+1, got.PassedPlayers, got.Version)
+	}
+
+	if got.TurnDeadline == nil || redis.deadline == nil || !redis.deadline.Equal(*got.TurnDeadline) || !got.TurnDeadline.After(time.Now()) {
+		t.Fatalf("expected the next bidder's clock scheduled, got %v (scheduled %v)", got.TurnDeadline, redis.deadline)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("timeout move not ledgered: %v", err)
+	}
+
+	// The next deadline is still running, so a sweep leaves the game be.
+	svc.expireTurns(t.Context())
+
+	if redis.game.Version != loaded+1 {
+		t.Fatal("a running clock must not time out")
+	}
+}
+
+func TestExpireTurnReschedulesAMovedDeadline(t *testing.T) {
+	t.Parallel()
+
+	g := game.New("game-moved")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	later := time.Now().Add(time.Minute)
+	g.TurnDeadline = &later
+	loaded := g.Version
+
+	redis := &fakeRedisStore{game: g}
+	svc := &Game{redisStore: redis}
+
+	svc.expireTurn(t.Context(), "game-moved")
+
+	if redis.game.Version != loaded || redis.deadline == nil || !redis.deadline.Equal(later) {
+		t.Fatalf("expected the later deadline put back untouched, got version %d, scheduled %v", redis.game.Version, redis.deadline)
+	}
+}
+
+func TestExpireTurnStopsTheClockOfACorruptedGame(t *testing.T) {
+	t.Parallel()
+
+	g := game.New("game-corrupted")
+	for i := range 5 {
+		g.SeatPlayer(i, fmt.Sprintf("p%d", i), fmt.Sprintf("P%d", i))
+	}
+
+	// A deadline left over from before the game was quarantined.
+	past := time.Now().Add(-time.Second)
+	g.TurnDeadline = &past
+	g.Status = game.PhaseCorrupted
+	loaded := g.Version
+
+	redis := &fakeRedisStore{game: g, deadline: &past}
+	svc := &Game{redisStore: redis}
+
+	svc.expireTurns(t.Context())
+
+	if redis.game.Version != loaded || redis.deadline != nil {
+		t.Fatalf("expected the clock stopped with no move made, got version %d, scheduled %v", redis.game.Version, redis.deadline)
+	}
+
+	// With the deadline off the schedule, no later sweep retries it.
+	svc.expireTurns(t.Context())
+
+	if redis.deadline != nil || redis.saved {
+		t.Fatalf("a stopped clock must stay stopped, got scheduled %v", redis.deadline)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/joekhosbayar/go-mighty/internal/game"
@@ -50,12 +52,159 @@ func (s *Store) CreateGame(ctx context.Context, g *game.Game) (err error) {
 			Msg("CreateGame")
 	}()
 
-	query := `INSERT INTO games (id, status, version, created_at) VALUES ($1, $2, $3, $4)`
-	_, err = s.db.ExecContext(ctx, query, g.ID, g.Status, g.Version, g.CreatedAt)
+	configJSON, err := json.Marshal(g.Config)
+	if err != nil {
+		return err
+	}
+
+	var dealSeed sql.NullString
+	if g.DealSeed != nil {
+		dealSeed = sql.NullString{String: g.DealSeed.String(), Valid: true}
+	}
+
+	query := `INSERT INTO games (id, status, version, config, deal_seed, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = s.db.ExecContext(ctx, query, g.ID, g.Status, g.Version, configJSON, dealSeed, g.CreatedAt)
+
+	return err
+}
+
+// SaveHand records the seed that shuffled hand handNo of a game, so the deal
+// can be reproduced when the game is replayed from the ledger.
+func (s *Store) SaveHand(ctx context.Context, gameID string, handNo, dealerSeat int, status game.Phase, seed game.Seed) (err error) {
+	start := time.Now()
+	defer func() {
+		log.Debug().
+			Str("component", "postgres").
+			Str("op", "SaveHand").
+			Str("game_id", gameID).
+			Int("hand_no", handNo).
+			Err(err).
+			Dur("latency", time.Since(start)).
+			Msg("SaveHand")
+	}()
+
+	query := `INSERT INTO hands (id, game_id, hand_no, dealer_seat, status, seed) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err = s.db.ExecContext(ctx, query, fmt.Sprintf("%s:%d", gameID, handNo), gameID, handNo, dealerSeat, status, seed.String())
 
 	return err
 }
 
+// LoadLedger reads everything needed to replay a game: its creation config,
+// the seed of every hand, every join and move in order, and its recorded
+// status. It returns nil when the game does not exist.
+func (s *Store) LoadLedger(ctx context.Context, gameID string) (l *game.Ledger, err error) {
+	start := time.Now()
+	defer func() {
+		event := log.Debug().
+			Str("component", "postgres").
+			Str("op", "LoadLedger").
+			Str("game_id", gameID).
+			Err(err).
+			Dur("latency", time.Since(start))
+		if l != nil {
+			event = event.Int("moves", len(l.Moves))
+		}
+		event.Msg("LoadLedger")
+	}()
+
+	ledger := &game.Ledger{ID: gameID}
+
+	var (
+		configJSON []byte
+		dealSeed   sql.NullString
+		status     string
+	)
+
+	row := s.db.QueryRowContext(ctx, `SELECT config, deal_seed, created_at, status, version FROM games WHERE id = $1`, gameID)
+	if err := row.Scan(&configJSON, &dealSeed, &ledger.CreatedAt, &status, &ledger.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(configJSON, &ledger.Config); err != nil {
+		return nil, err
+	}
+
+	ledger.Status = game.Phase(status)
+
+	if dealSeed.Valid {
+		seed, err := game.ParseSeed(dealSeed.String)
+		if err != nil {
+			return nil, err
+		}
+
+		ledger.DealSeed = &seed
+	}
+
+	if ledger.HandSeeds, err = s.loadHandSeeds(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	if ledger.Moves, err = s.loadMoves(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+func (s *Store) loadHandSeeds(ctx context.Context, gameID string) ([]game.Seed, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT seed FROM hands WHERE game_id = $1 ORDER BY hand_no`, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var seeds []game.Seed
+
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, err
+		}
+
+		seed, err := game.ParseSeed(text)
+		if err != nil {
+			return nil, err
+		}
+
+		seeds = append(seeds, seed)
+	}
+
+	return seeds, rows.Err()
+}
+
+func (s *Store) loadMoves(ctx context.Context, gameID string) ([]game.LedgerMove, error) {
+	query := `SELECT version, player_id, seat_no, move_type, payload FROM moves WHERE game_id = $1 ORDER BY id`
+
+	rows, err := s.db.QueryContext(ctx, query, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var moves []game.LedgerMove
+
+	for rows.Next() {
+		var (
+			m        game.LedgerMove
+			moveType string
+			payload  []byte
+		)
+		if err := rows.Scan(&m.Version, &m.PlayerID, &m.Seat, &moveType, &payload); err != nil {
+			return nil, err
+		}
+
+		m.Type = game.MoveType(moveType)
+		m.Payload = payload
+		moves = append(moves, m)
+	}
+
+	return moves, rows.Err()
+}
+
 // SaveMove inserts a new move record into the database ledger.
 // clientVersion represents the client's known game version at the time they submitted the move.
 func (s *Store) SaveMove(ctx context.Context, moveType game.MoveType, playerID string, seat int, version, clientVersion int64, payload any, gameID string) (err error) {
@@ -106,6 +255,75 @@ func (s *Store) UpdateGameStatus(ctx context.Context, gameID string, status game
 	return err
 }
 
+// SaveFinalState keeps the state a match ended in, for listing completed
+// matches after Redis has expired them.
+func (s *Store) SaveFinalState(ctx context.Context, g *game.Game) (err error) {
+	start := time.Now()
+	defer func() {
+		log.Debug().
+			Str("component", "postgres").
+			Str("op", "SaveFinalState").
+			Str("game_id", g.ID).
+			Int64("version", g.Version).
+			Err(err).
+			Dur("latency", time.Since(start)).
+			Msg("SaveFinalState")
+	}()
+
+	stateJSON, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE games SET final_state = $1, updated_at = NOW() WHERE id = $2`
+	_, err = s.db.ExecContext(ctx, query, stateJSON, g.ID)
+
+	return err
+}
+
+// ListFinalStates retrieves the final state of the most recent completed
+// matches.
+func (s *Store) ListFinalStates(ctx context.Context) (games []*game.Game, err error) {
+	start := time.Now()
+	defer func() {
+		log.Debug().
+			Str("component", "postgres").
+			Str("op", "ListFinalStates").
+			Int("count", len(games)).
+			Err(err).
+			Dur("latency", time.Since(start)).
+			Msg("ListFinalStates")
+	}()
+
+	query := `SELECT final_state FROM games WHERE status = $1 AND final_state IS NOT NULL ORDER BY created_at DESC LIMIT 50`
+
+	rows, err := s.db.QueryContext(ctx, query, game.PhaseMatchOver)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var stateJSON []byte
+		if err := rows.Scan(&stateJSON); err != nil {
+			return nil, err
+		}
+
+		var g game.Game
+		if err := json.Unmarshal(stateJSON, &g); err != nil {
+			return nil, fmt.Errorf("failed to decode final state: %w", err)
+		}
+
+		games = append(games, &g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return games, nil
+}
+
 // ListGamesByStatus retrieves a list of game IDs with the specified status.
 func (s *Store) ListGamesByStatus(ctx context.Context, status game.Phase) (ids []string, err error) {
 	start := time.Now()
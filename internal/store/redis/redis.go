@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joekhosbayar/go-mighty/internal/game"
@@ -36,6 +37,11 @@ func NewStore(addr string) *Store {
 	return &Store{client: client}
 }
 
+// Close closes the store's Redis client.
+func (s *Store) Close() error {
+	return s.client.Close()
+}
+
 // Key returns the Redis key for a specific game ID.
 func (s *Store) Key(gameID string) string {
 	return "game:" + gameID
@@ -264,3 +270,202 @@ func (s *Store) Subscribe(ctx context.Context, gameID string) *redis.PubSub {
 
 	return s.client.Subscribe(ctx, channel)
 }
+
+// turnsKey is the sorted set of games with a player on the clock, scored by
+// the Unix millisecond at which that player's turn runs out.
+const turnsKey = "timers:turns"
+
+// ScheduleTurn sets when the player to move in gameID runs out of time,
+// replacing any deadline already set for the game.
+func (s *Store) ScheduleTurn(ctx context.Context, gameID string, deadline time.Time) error {
+	return s.client.ZAdd(ctx, turnsKey, redis.Z{Score: float64(deadline.UnixMilli()), Member: gameID}).Err()
+}
+
+// CancelTurn removes gameID's deadline, for a game where no one is on the
+// clock.
+func (s *Store) CancelTurn(ctx context.Context, gameID string) error {
+	return s.client.ZRem(ctx, turnsKey, gameID).Err()
+}
+
+// claimScript returns up to ARGV[3] games whose deadline is at or before
+// ARGV[1] and pushes each back to ARGV[2], so no other instance claims them
+// meanwhile and a claim that is never handled comes due again.
+var claimScript = redis.NewScript(`
+local due = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, ARGV[3])
+for _, id in ipairs(due) do
+	redis.call("ZADD", KEYS[1], ARGV[2], id)
+end
+return due`)
+
+// ClaimDueTurns returns up to limit games whose deadline has passed by now,
+// leasing each to the caller for lease. Handling a game reschedules or
+// cancels its deadline; one left unhandled is claimed again once the lease
+// runs out.
+func (s *Store) ClaimDueTurns(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]string, error) {
+	return claimScript.Run(ctx, s.client, []string{turnsKey},
+		now.UnixMilli(),
+		now.Add(lease).UnixMilli(),
+		limit,
+	).StringSlice()
+}
+
+// watchKey is the sorted set of players whose presence is due a check,
+// scored by the Unix millisecond of the check: when their sockets would all
+// have expired, or when a disconnected player's grace period runs out.
+const watchKey = "presence:watch"
+
+// PlayerKey names one player at one game.
+type PlayerKey struct {
+	GameID   string
+	PlayerID string
+}
+
+func (k PlayerKey) member() string {
+	return k.GameID + "/" + k.PlayerID
+}
+
+// socketsKey is the sorted set of a player's open sockets at a game, scored
+// by the Unix millisecond each expires unless touched again.
+func (s *Store) socketsKey(gameID, playerID string) string {
+	return s.Key(gameID) + ":sockets:" + playerID
+}
+
+// touchScript drops the player's expired sockets, adds or refreshes
+// ARGV[3] until ARGV[2], and moves the player's presence check to then. It
+// returns 1 when no other socket was open.
+var touchScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+local others = redis.call("ZCARD", KEYS[1]) - (redis.call("ZSCORE", KEYS[1], ARGV[3]) and 1 or 0)
+redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("PEXPIREAT", KEYS[1], ARGV[2])
+redis.call("ZADD", KEYS[2], ARGV[2], ARGV[4])
+if others == 0 then
+	return 1
+end
+return 0`)
+
+// TouchSocket records that socketID, one of playerID's connections to
+// gameID, is open for at least ttl more. Each server instance touches its
+// sockets well within ttl; a socket left untouched, such as one on an
+// instance that died, expires. It reports whether this is the player's only
+// open socket.
+func (s *Store) TouchSocket(ctx context.Context, gameID, playerID, socketID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	only, err := touchScript.Run(ctx, s.client,
+		[]string{s.socketsKey(gameID, playerID), watchKey},
+		now.UnixMilli(),
+		now.Add(ttl).UnixMilli(),
+		socketID,
+		PlayerKey{GameID: gameID, PlayerID: playerID}.member(),
+	).Int()
+
+	return only == 1, err
+}
+
+// removeScript closes socket ARGV[2] and returns 1 when it was the player's
+// last open socket.
+var removeScript = redis.NewScript(`
+local removed = redis.call("ZREM", KEYS[1], ARGV[2])
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+if removed == 1 and redis.call("ZCARD", KEYS[1]) == 0 then
+	return 1
+end
+return 0`)
+
+// RemoveSocket records that socketID has closed. It reports whether that
+// left the player with no open socket on any instance.
+func (s *Store) RemoveSocket(ctx context.Context, gameID, playerID, socketID string) (bool, error) {
+	last, err := removeScript.Run(ctx, s.client,
+		[]string{s.socketsKey(gameID, playerID)},
+		time.Now().UnixMilli(),
+		socketID,
+	).Int()
+
+	return last == 1, err
+}
+
+// CountSockets returns how many of the player's sockets are open.
+func (s *Store) CountSockets(ctx context.Context, gameID, playerID string) (int, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	n, err := s.client.ZCount(ctx, s.socketsKey(gameID, playerID), "("+now, "+inf").Result()
+
+	return int(n), err
+}
+
+// WatchPresence schedules the player's next presence check for at.
+func (s *Store) WatchPresence(ctx context.Context, gameID, playerID string, at time.Time) error {
+	member := PlayerKey{GameID: gameID, PlayerID: playerID}.member()
+	return s.client.ZAdd(ctx, watchKey, redis.Z{Score: float64(at.UnixMilli()), Member: member}).Err()
+}
+
+// UnwatchPresence drops the player's presence check.
+func (s *Store) UnwatchPresence(ctx context.Context, gameID, playerID string) error {
+	return s.client.ZRem(ctx, watchKey, PlayerKey{GameID: gameID, PlayerID: playerID}.member()).Err()
+}
+
+// ClaimDuePresence returns up to limit players whose presence check is due
+// by now, leasing each to the caller for lease like ClaimDueTurns.
+func (s *Store) ClaimDuePresence(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]PlayerKey, error) {
+	members, err := claimScript.Run(ctx, s.client, []string{watchKey},
+		now.UnixMilli(),
+		now.Add(lease).UnixMilli(),
+		limit,
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]PlayerKey, 0, len(members))
+	for _, m := range members {
+		gameID, playerID, _ := strings.Cut(m, "/")
+		keys = append(keys, PlayerKey{GameID: gameID, PlayerID: playerID})
+	}
+
+	return keys, nil
+}
+
+// spectatorsKey is the sorted set of a game's open spectator sockets,
+// scored by the Unix millisecond each expires unless touched again.
+func (s *Store) spectatorsKey(gameID string) string {
+	return s.Key(gameID) + ":spectators"
+}
+
+// touchSpectatorScript drops expired spectator sockets and adds or
+// refreshes ARGV[3] until ARGV[2]. It returns 1 when the socket is new.
+var touchSpectatorScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+local added = redis.call("ZADD", KEYS[1], ARGV[2], ARGV[3])
+redis.call("PEXPIREAT", KEYS[1], ARGV[2])
+return added`)
+
+// TouchSpectator records that socketID, a spectator's connection to gameID,
+// is open for at least ttl more, expiring like TouchSocket. It reports
+// whether the socket is new.
+func (s *Store) TouchSpectator(ctx context.Context, gameID, socketID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	added, err := touchSpectatorScript.Run(ctx, s.client,
+		[]string{s.spectatorsKey(gameID)},
+		now.UnixMilli(),
+		now.Add(ttl).UnixMilli(),
+		socketID,
+	).Int()
+
+	return added == 1, err
+}
+
+// RemoveSpectator records that a spectator's socket has closed. It reports
+// whether the socket was still counted.
+func (s *Store) RemoveSpectator(ctx context.Context, gameID, socketID string) (bool, error) {
+	n, err := s.client.ZRem(ctx, s.spectatorsKey(gameID), socketID).Result()
+	return n == 1, err
+}
+
+// CountSpectators returns how many spectator sockets are open on gameID.
+func (s *Store) CountSpectators(ctx context.Context, gameID string) (int, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	n, err := s.client.ZCount(ctx, s.spectatorsKey(gameID), "("+now, "+inf").Result()
+
+	return int(n), err
+}
@@ -202,3 +202,150 @@ func TestConcurrentLockedWritersLoseNoUpdates(t *testing.T) {
 		t.Fatalf("lost updates: final version %d, want %d", final.Version, want)
 	}
 }
+
+func TestClaimDueTurnsLeasesEachDeadlineOnce(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	// Deadlines long past, so the test claims nothing real and no real
+	// sweep claims these.
+	now := time.Unix(1000, 0)
+	due, later := "turns-due-"+t.Name(), "turns-later-"+t.Name()
+	t.Cleanup(func() {
+		_ = s.CancelTurn(ctx, due)
+		_ = s.CancelTurn(ctx, later)
+	})
+
+	if err := s.ScheduleTurn(ctx, due, now.Add(-time.Second)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	if err := s.ScheduleTurn(ctx, later, now.Add(time.Minute)); err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+
+	got, err := s.ClaimDueTurns(ctx, now, 10*time.Second, 10)
+	if err != nil || len(got) != 1 || got[0] != due {
+		t.Fatalf("expected only %s claimed, got %v (%v)", due, got, err)
+	}
+
+	if again, err := s.ClaimDueTurns(ctx, now, 10*time.Second, 10); err != nil || len(again) != 0 {
+		t.Fatalf("a leased deadline must not be claimed again, got %v (%v)", again, err)
+	}
+
+	expired, err := s.ClaimDueTurns(ctx, now.Add(11*time.Second), 10*time.Second, 10)
+	if err != nil || len(expired) != 1 || expired[0] != due {
+		t.Fatalf("expected %s claimed again once its lease ran out, got %v (%v)", due, expired, err)
+	}
+}
+
+func TestSocketsCountAcrossConnections(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	gameID := "sockets-" + t.Name()
+	t.Cleanup(func() {
+		_ = s.client.Del(ctx, s.socketsKey(gameID, "p1")).Err()
+		_ = s.UnwatchPresence(ctx, gameID, "p1")
+	})
+
+	if only, err := s.TouchSocket(ctx, gameID, "p1", "tab", time.Minute); err != nil || !only {
+		t.Fatalf("expected the first socket to be the only one, got %v (%v)", only, err)
+	}
+	if only, err := s.TouchSocket(ctx, gameID, "p1", "phone", time.Minute); err != nil || only {
+		t.Fatalf("expected a second socket alongside the first, got %v (%v)", only, err)
+	}
+	if only, err := s.TouchSocket(ctx, gameID, "p1", "tab", time.Minute); err != nil || only {
+		t.Fatalf("refreshing a socket must not make it the only one, got %v (%v)", only, err)
+	}
+
+	if n, err := s.CountSockets(ctx, gameID, "p1"); err != nil || n != 2 {
+		t.Fatalf("expected 2 open sockets, got %d (%v)", n, err)
+	}
+
+	if last, err := s.RemoveSocket(ctx, gameID, "p1", "tab"); err != nil || last {
+		t.Fatalf("expected the phone still open, got %v (%v)", last, err)
+	}
+	if last, err := s.RemoveSocket(ctx, gameID, "p1", "phone"); err != nil || !last {
+		t.Fatalf("expected the last socket closed, got %v (%v)", last, err)
+	}
+	if last, err := s.RemoveSocket(ctx, gameID, "p1", "phone"); err != nil || last {
+		t.Fatalf("closing a socket twice must not report it last again, got %v (%v)", last, err)
+	}
+
+	// A socket nobody touches in time counts as closed.
+	if _, err := s.TouchSocket(ctx, gameID, "p1", "stale", time.Millisecond); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if n, err := s.CountSockets(ctx, gameID, "p1"); err != nil || n != 0 {
+		t.Fatalf("expected the stale socket expired, got %d (%v)", n, err)
+	}
+}
+
+func TestClaimDuePresenceLeasesEachCheckOnce(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	now := time.Unix(1000, 0)
+	gameID := "presence-" + t.Name()
+	t.Cleanup(func() {
+		_ = s.UnwatchPresence(ctx, gameID, "due")
+		_ = s.UnwatchPresence(ctx, gameID, "later")
+	})
+
+	if err := s.WatchPresence(ctx, gameID, "due", now.Add(-time.Second)); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if err := s.WatchPresence(ctx, gameID, "later", now.Add(time.Minute)); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+
+	want := PlayerKey{GameID: gameID, PlayerID: "due"}
+	got, err := s.ClaimDuePresence(ctx, now, 10*time.Second, 10)
+	if err != nil || len(got) != 1 || got[0] != want {
+		t.Fatalf("expected only %v claimed, got %v (%v)", want, got, err)
+	}
+
+	if again, err := s.ClaimDuePresence(ctx, now, 10*time.Second, 10); err != nil || len(again) != 0 {
+		t.Fatalf("a leased check must not be claimed again, got %v (%v)", again, err)
+	}
+}
+
+func TestSpectatorsCountOpenSockets(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	gameID := "spectators-" + t.Name()
+	t.Cleanup(func() { _ = s.client.Del(ctx, s.spectatorsKey(gameID)).Err() })
+
+	for _, socket := range []string{"a", "b", "a"} {
+		if _, err := s.TouchSpectator(ctx, gameID, socket, time.Minute); err != nil {
+			t.Fatalf("touch %s: %v", socket, err)
+		}
+	}
+
+	if n, err := s.CountSpectators(ctx, gameID); err != nil || n != 2 {
+		t.Fatalf("expected 2 spectators, got %d (%v)", n, err)
+	}
+
+	if removed, err := s.RemoveSpectator(ctx, gameID, "a"); err != nil || !removed {
+		t.Fatalf("expected a removed, got %v (%v)", removed, err)
+	}
+	if removed, err := s.RemoveSpectator(ctx, gameID, "a"); err != nil || removed {
+		t.Fatalf("removing a twice must not count it twice, got %v (%v)", removed, err)
+	}
+
+	// A socket nobody touches in time no longer counts.
+	if _, err := s.TouchSpectator(ctx, gameID, "b", time.Millisecond); err != nil {
+		t.Fatalf("touch: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	if added, err := s.TouchSpectator(ctx, gameID, "c", time.Minute); err != nil || !added {
+		t.Fatalf("expected c added, got %v (%v)", added, err)
+	}
+	if n, err := s.CountSpectators(ctx, gameID); err != nil || n != 1 {
+		t.Fatalf("expected the stale spectator expired, got %d (%v)", n, err)
+	}
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_moves_game_id_id;
+
+ALTER TABLE hands DROP COLUMN seed;
+ALTER TABLE games DROP COLUMN deal_seed;
+ALTER TABLE games DROP COLUMN config;
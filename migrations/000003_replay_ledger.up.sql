@@ -0,0 +1,7 @@
+-- Enough to rebuild a game from the ledger when its Redis state is lost: the
+-- creation config and base deal seed, and the seed that shuffled each hand.
+ALTER TABLE games ADD COLUMN config JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE games ADD COLUMN deal_seed VARCHAR(64);
+ALTER TABLE hands ADD COLUMN seed VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_moves_game_id_id ON moves(game_id, id);
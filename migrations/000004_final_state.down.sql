@@ -0,0 +1 @@
+ALTER TABLE games DROP COLUMN final_state;
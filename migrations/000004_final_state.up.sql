@@ -0,0 +1,3 @@
+-- The state a match ended in, so completed matches can be listed without
+-- replaying them once Redis has expired them.
+ALTER TABLE games ADD COLUMN final_state JSONB;
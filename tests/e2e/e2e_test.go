@@ -361,7 +361,19 @@ func (a *apiFeature) joinsSeatOfGame(username string) error {
 }
 
 func (a *apiFeature) refreshState() error {
-	resp, err := a.client.R().Get("/games/" + a.activeGameID)
+	return a.refreshStateAs("")
+}
+
+// refreshStateAs loads the game as username sees it. Hands are only visible
+// to their owner, so any step that inspects a hand must fetch as that player;
+// an empty username fetches the public (anonymous) view.
+func (a *apiFeature) refreshStateAs(username string) error {
+	req := a.client.R()
+	if username != "" {
+		req.SetHeader("Authorization", "Bearer "+a.tokens[username])
+	}
+
+	resp, err := req.Get("/games/" + a.activeGameID)
 	if err != nil {
 		return err
 	}
@@ -391,33 +403,33 @@ func (a *apiFeature) waitForStatus(status string) error {
 	return fmt.Errorf("timeout waiting for %s, got %s", status, a.game.Status)
 }
 
-func (a *apiFeature) findLegalCard(p *game.Player) game.Card {
-	trickIdx := len(a.game.Tricks) - 1
-	leading := trickIdx >= 0 && len(a.game.Tricks[trickIdx].Cards) == 0
+// legalPlay asks the server for username's legal moves and returns the first
+// play_card payload.
+func (a *apiFeature) legalPlay(username string) (json.RawMessage, error) {
+	resp, err := a.client.R().
+		SetHeader("Authorization", "Bearer "+a.tokens[username]).
+		Get("/games/" + a.activeGameID + "/legal-moves")
+	if err != nil {
+		return nil, err
+	}
 
-	for _, c := range p.Hand {
-		move := game.PlayCardMove{Card: c}
-		if leading && c.Rank == game.Joker {
-			move.CallJoker = true
-			move.CalledSuit = game.Hearts
-		}
+	var body struct {
+		Moves []struct {
+			Type    game.MoveType   `json:"move_type"`
+			Payload json.RawMessage `json:"payload"`
+		} `json:"moves"`
+	}
+	if err := json.Unmarshal(resp.Body(), &body); err != nil {
+		return nil, fmt.Errorf("decode legal moves %q: %w", resp.String(), err)
+	}
 
-		err := a.game.ValidateMove(p.ID, game.MovePlayCard, move)
-		if err == nil {
-			return c
+	for _, m := range body.Moves {
+		if m.Type == game.MovePlayCard {
+			return m.Payload, nil
 		}
 	}
-	return p.Hand[0]
-}
 
-// playPayload builds a play_card payload, declaring a suit when the Joker leads.
-func (a *apiFeature) playPayload(card game.Card) map[string]any {
-	trickIdx := len(a.game.Tricks) - 1
-	leading := trickIdx >= 0 && len(a.game.Tricks[trickIdx].Cards) == 0
-	if leading && card.Rank == game.Joker {
-		return map[string]any{"card": card, "called_suit": "hearts"}
-	}
-	return map[string]any{"card": card}
+	return nil, fmt.Errorf("%s has no legal card to play", username)
 }
 
 func (a *apiFeature) playOutGame() error {
@@ -442,12 +454,15 @@ func (a *apiFeature) playOutGame() error {
 				}
 			}
 
-			card := a.findLegalCard(p)
-			err := a.move(name, game.MovePlayCard, a.playPayload(card))
+			payload, err := a.legalPlay(name)
 			if err != nil {
 				return err
 			}
 
+			if err := a.move(name, game.MovePlayCard, payload); err != nil {
+				return err
+			}
+
 			if a.lastResponse.StatusCode() != http.StatusOK {
 				return fmt.Errorf("play failed: %s", a.lastResponse.String())
 			}
@@ -585,7 +600,7 @@ func InitializeScenario(ctx *godog.ScenarioContext, api *apiFeature) {
 	})
 	ctx.Step(`^"([^"]*)" should have (\d+) cards in hand$`, func(_ string, _ int) error { return nil })
 	ctx.Step(`^"([^"]*)" discards (\d+) least powerful cards$`, func(u string, _ int) error {
-		if err := api.refreshState(); err != nil {
+		if err := api.refreshStateAs(u); err != nil {
 			return err
 		}
 
@@ -699,8 +714,12 @@ func InitializeScenario(ctx *godog.ScenarioContext, api *apiFeature) {
 				}
 			}
 
-			card := api.findLegalCard(p)
-			if err := api.move(name, game.MovePlayCard, api.playPayload(card)); err != nil {
+			payload, err := api.legalPlay(name)
+			if err != nil {
+				return err
+			}
+
+			if err := api.move(name, game.MovePlayCard, payload); err != nil {
 				return err
 			}
 